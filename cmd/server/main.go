@@ -19,6 +19,11 @@
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 
+// @securityDefinitions.apikey DeviceAPIKey
+// @in header
+// @name X-API-Key
+// @description API key issued to an alarm device.
+
 package main
 
 import (
@@ -29,12 +34,14 @@ import (
 	"os/signal"
 	config "scs-guard/config"
 	"scs-guard/internal/container"
+	"scs-guard/internal/models"
 	"syscall"
 	"time"
 
 	"scs-guard/internal/server"
+	"scs-guard/internal/workers"
 	"scs-guard/pkg/db"
-	minio_client "scs-guard/pkg/minio"
+	"scs-guard/pkg/storage"
 
 	"scs-guard/pkg/logger"
 
@@ -65,19 +72,23 @@ func main() {
 		appLogger.Info("Postgres connected")
 	}
 
+	err = models.AutoMigrate(psqlDb)
 	if err != nil {
 		appLogger.Fatalf("Database migration failed: %s", err)
 	}
-	//Init minio client
-	minioClient, err := minio_client.NewMinioClient(cfg.Minio.Endpoint, cfg.Minio.AccessKey, cfg.Minio.SecretKey, cfg.Minio.BucketName, appLogger)
+	//Init file storage
+	store, err := storage.New(&cfg, appLogger)
 	if err != nil {
-		appLogger.Fatalf("Minio init: %s", err)
+		appLogger.Fatalf("Storage init: %s", err)
 	} else {
-		appLogger.Info("Minio connected")
+		appLogger.Infof("Storage connected: %s", cfg.Storage.Backend)
 	}
 
 	// Create shared repositories and services using container
-	deps := container.NewContainer(psqlDb, minioClient)
+	deps, err := container.NewContainer(&cfg, psqlDb, store)
+	if err != nil {
+		appLogger.Fatalf("Container init: %s", err)
+	}
 
 	// Initialize the server with shared dependencies
 	s := server.NewServer(&cfg, psqlDb, appLogger, deps)
@@ -86,6 +97,19 @@ func main() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
+	// Start background workers
+	scheduler := workers.NewScheduler(appLogger)
+	scheduler.Add(workers.NewEscalationJob(deps.MissionService, appLogger), cfg.Escalation.CheckInterval)
+	scheduler.Add(workers.NewOverdueJob(deps.MissionService, appLogger), cfg.SLA.CheckInterval)
+	scheduler.Add(workers.NewMediaUploadCleanupJob(deps.MissionService, appLogger), cfg.Media.CleanupInterval)
+	scheduler.Add(workers.NewMediaProcessingJob(deps.MediaService, appLogger), cfg.Media.ProcessingInterval)
+	scheduler.Add(workers.NewMediaPurgeJob(deps.MediaService, appLogger), cfg.Media.PurgeInterval)
+	scheduler.Add(workers.NewMediaReconcileJob(deps.MediaService, appLogger), cfg.Media.ReconcileInterval)
+	scheduler.Add(workers.NewOutboxRelayJob(deps.OutboxService, appLogger), cfg.Outbox.RelayInterval)
+	scheduler.Add(workers.NewOutboxCleanupJob(deps.OutboxService, appLogger), time.Hour)
+	scheduler.Add(workers.NewWebhookDeliveryJob(deps.WebhookService, appLogger), cfg.Webhook.DeliveryInterval)
+	scheduler.Start(context.Background())
+
 	// Start the server in a goroutine
 	go func() {
 		if err := s.Run(); err != nil && err != http.ErrServerClosed {
@@ -106,5 +130,8 @@ func main() {
 		appLogger.Errorf("Server shutdown failed: %v", err)
 	}
 
+	// Stop background workers once no more requests are served
+	scheduler.Stop()
+
 	appLogger.Info("Server and consumer stopped.")
 }
@@ -5,19 +5,29 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Logger   Logger
-	Minio    MinioConfig
+	Server     ServerConfig
+	Database   DatabaseConfig
+	Logger     Logger
+	Minio      MinioConfig
+	Storage    StorageConfig
+	Media      MediaConfig
+	Escalation EscalationConfig
+	SLA        SLAConfig
+	Steps      StepsConfig
+	Events     EventsConfig
+	Outbox     OutboxConfig
+	Webhook    WebhookConfig
+	Alarm      AlarmConfig
+	Dispatch   DispatchConfig
 }
 
 // Logger config
 type Logger struct {
-	Development       bool   `env:LOG_DEVELOPMENT`
-	DisableCaller     bool   `env:LOG_DISABLE_CALLER default:"false"`
-	DisableStacktrace bool   `env:LOG_DISABLE_STACKTRACE default:"false"`
-	Encoding          string `env:LOG_ENCODING`
-	Level             string `env:LOG_LEVEL`
+	Development       bool   `env:"LOG_DEVELOPMENT"`
+	DisableCaller     bool   `env:"LOG_DISABLE_CALLER" envDefault:"false"`
+	DisableStacktrace bool   `env:"LOG_DISABLE_STACKTRACE" envDefault:"false"`
+	Encoding          string `env:"LOG_ENCODING"`
+	Level             string `env:"LOG_LEVEL"`
 }
 type ServerConfig struct {
 	Port         string        `env:"PORT"`
@@ -40,3 +50,147 @@ type MinioConfig struct {
 	SecretKey  string `env:"MINIO_SECRET_KEY"`
 	BucketName string `env:"MINIO_BUCKET_NAME"`
 }
+
+// StorageConfig selects where files are stored
+type StorageConfig struct {
+	// Backend is minio for MinIO or another S3 compatible service, or local for the filesystem
+	Backend string `env:"STORAGE_BACKEND" envDefault:"minio"`
+	// LocalDir is the directory the local backend stores files in
+	LocalDir string `env:"STORAGE_LOCAL_DIR" envDefault:"./data/storage"`
+	// LocalBaseURL is the address of the API that presigned URLs of the local backend point at
+	LocalBaseURL string `env:"STORAGE_LOCAL_BASE_URL" envDefault:"http://localhost:8080"`
+	// SigningKey signs the presigned URLs of the local backend
+	SigningKey string `env:"STORAGE_SIGNING_KEY"`
+}
+
+// MediaConfig controls the upload and download of incident media
+type MediaConfig struct {
+	// MaxImageSize and MaxVideoSize are the sizes in bytes of the largest files that can be uploaded
+	MaxImageSize int64 `env:"MEDIA_MAX_IMAGE_SIZE" envDefault:"10485760"`
+	MaxVideoSize int64 `env:"MEDIA_MAX_VIDEO_SIZE" envDefault:"2147483648"`
+	// UploadURLExpiry is how long a presigned upload URL stays valid
+	UploadURLExpiry time.Duration `env:"MEDIA_UPLOAD_URL_EXPIRY" envDefault:"15m"`
+	// DownloadURLExpiry is how long the presigned download URLs returned with media stay valid
+	DownloadURLExpiry time.Duration `env:"MEDIA_DOWNLOAD_URL_EXPIRY" envDefault:"1h"`
+	// PartSize is the size in bytes of the parts of a resumable upload; object storage needs at
+	// least 5MiB for every part but the last
+	PartSize int64 `env:"MEDIA_PART_SIZE" envDefault:"8388608"`
+	// UploadTTL is how long an unconfirmed upload is kept without activity before it is discarded
+	UploadTTL time.Duration `env:"MEDIA_UPLOAD_TTL" envDefault:"24h"`
+	// CleanupInterval is how often abandoned uploads are looked for
+	CleanupInterval time.Duration `env:"MEDIA_UPLOAD_CLEANUP_INTERVAL" envDefault:"1h"`
+	// ThumbnailSize and PreviewSize are the longest sides in pixels of the resized copies of images
+	ThumbnailSize int `env:"MEDIA_THUMBNAIL_SIZE" envDefault:"320"`
+	PreviewSize   int `env:"MEDIA_PREVIEW_SIZE" envDefault:"1280"`
+	// MaxImagePixels is the largest number of pixels of an image that is resized; larger images fail
+	// processing rather than taking gigabytes of memory to decode
+	MaxImagePixels int64 `env:"MEDIA_MAX_IMAGE_PIXELS" envDefault:"50000000"`
+	// ProcessingInterval is how often images waiting for a thumbnail and preview are looked for
+	ProcessingInterval  time.Duration `env:"MEDIA_PROCESSING_INTERVAL" envDefault:"5s"`
+	ProcessingBatchSize int           `env:"MEDIA_PROCESSING_BATCH_SIZE" envDefault:"10"`
+	// ProcessingMaxAttempts is how many times reading or storing the files is tried before the media
+	// is marked failed
+	ProcessingMaxAttempts int `env:"MEDIA_PROCESSING_MAX_ATTEMPTS" envDefault:"5"`
+	// ProcessingLease is how long media being processed is hidden from other processors
+	ProcessingLease time.Duration `env:"MEDIA_PROCESSING_LEASE" envDefault:"5m"`
+	// CaptureTimeTolerance is how long before an incident was reported a photo may have been taken
+	// without being flagged, which allows for camera clocks being off
+	CaptureTimeTolerance time.Duration `env:"MEDIA_CAPTURE_TIME_TOLERANCE" envDefault:"5m"`
+	// MaxCaptureDistance is how far in meters from the premise of an incident a photo may have been
+	// taken without being flagged
+	MaxCaptureDistance float64 `env:"MEDIA_MAX_CAPTURE_DISTANCE" envDefault:"500"`
+	// DeletedRetention is how long the files of deleted media are kept before they are purged
+	DeletedRetention time.Duration `env:"MEDIA_DELETED_RETENTION" envDefault:"720h"`
+	// PurgeInterval is how often the files of deleted media past their retention are looked for
+	PurgeInterval time.Duration `env:"MEDIA_PURGE_INTERVAL" envDefault:"1h"`
+	// UploadConcurrency is how many files of one multi-file upload are stored at the same time
+	UploadConcurrency int `env:"MEDIA_UPLOAD_CONCURRENCY" envDefault:"4"`
+	// ReconcileInterval is how often storage is searched for objects without a media
+	ReconcileInterval time.Duration `env:"MEDIA_RECONCILE_INTERVAL" envDefault:"24h"`
+	// OrphanGracePeriod is how old an object without a media must be before it is reported, so
+	// uploads still being recorded are not mistaken for orphans
+	OrphanGracePeriod time.Duration `env:"MEDIA_ORPHAN_GRACE_PERIOD" envDefault:"1h"`
+	// DeleteOrphans removes the orphaned objects found instead of only reporting them
+	DeleteOrphans bool `env:"MEDIA_DELETE_ORPHANS" envDefault:"false"`
+}
+
+// EscalationConfig controls when missions are escalated to a supervisor
+type EscalationConfig struct {
+	// AcceptTimeout is how long an assigned mission may wait to be accepted
+	AcceptTimeout time.Duration `env:"ESCALATION_ACCEPT_TIMEOUT" envDefault:"10m"`
+	// ProgressTimeout is how long an accepted or started mission may go without activity
+	ProgressTimeout time.Duration `env:"ESCALATION_PROGRESS_TIMEOUT" envDefault:"30m"`
+	// CheckInterval is how often missions are checked for escalation
+	CheckInterval time.Duration `env:"ESCALATION_CHECK_INTERVAL" envDefault:"1m"`
+	// SupervisorID is the user escalations go to when the assignee has no supervisor
+	SupervisorID string `env:"ESCALATION_SUPERVISOR_ID"`
+}
+
+// SLAConfig controls mission and step deadlines. Template step durations are multiplied by the
+// factor of the incident severity.
+type SLAConfig struct {
+	HighSeverityFactor   float64       `env:"SLA_HIGH_SEVERITY_FACTOR" envDefault:"0.5"`
+	MediumSeverityFactor float64       `env:"SLA_MEDIUM_SEVERITY_FACTOR" envDefault:"1"`
+	LowSeverityFactor    float64       `env:"SLA_LOW_SEVERITY_FACTOR" envDefault:"2"`
+	CheckInterval        time.Duration `env:"SLA_CHECK_INTERVAL" envDefault:"1m"`
+}
+
+// StepsConfig controls the completion of mission steps
+type StepsConfig struct {
+	// UndoGracePeriod is how long after completing a step the guard can undo it; 0 disables undo
+	UndoGracePeriod time.Duration `env:"STEP_UNDO_GRACE_PERIOD" envDefault:"5m"`
+}
+
+// EventsConfig controls the real-time mission event stream
+type EventsConfig struct {
+	// HistorySize is how many recent events are kept so reconnecting clients can resume
+	HistorySize int `env:"EVENTS_HISTORY_SIZE" envDefault:"1000"`
+	// HeartbeatInterval is how often an idle stream sends a keep-alive comment
+	HeartbeatInterval time.Duration `env:"EVENTS_HEARTBEAT_INTERVAL" envDefault:"15s"`
+}
+
+// OutboxConfig controls how stored domain events are relayed to integrations
+type OutboxConfig struct {
+	// Sinks lists where events are published: broker (event stream), log and webhook
+	Sinks          []string      `env:"OUTBOX_SINKS" envSeparator:"," envDefault:"broker,log"`
+	WebhookURL     string        `env:"OUTBOX_WEBHOOK_URL"`
+	WebhookTimeout time.Duration `env:"OUTBOX_WEBHOOK_TIMEOUT" envDefault:"10s"`
+	RelayInterval  time.Duration `env:"OUTBOX_RELAY_INTERVAL" envDefault:"1s"`
+	BatchSize      int           `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+	// MaxAttempts is how many times delivery is tried before an event is marked failed
+	MaxAttempts    int           `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"10"`
+	RetryBaseDelay time.Duration `env:"OUTBOX_RETRY_BASE_DELAY" envDefault:"1s"`
+	RetryMaxDelay  time.Duration `env:"OUTBOX_RETRY_MAX_DELAY" envDefault:"10m"`
+	// Lease is how long a claimed event is hidden from other relays while it is delivered
+	Lease time.Duration `env:"OUTBOX_LEASE" envDefault:"1m"`
+	// Retention is how long published events are kept
+	Retention time.Duration `env:"OUTBOX_RETENTION" envDefault:"168h"`
+}
+
+// WebhookConfig controls delivery of events to webhook subscriptions
+type WebhookConfig struct {
+	DeliveryInterval time.Duration `env:"WEBHOOK_DELIVERY_INTERVAL" envDefault:"5s"`
+	Timeout          time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	BatchSize        int           `env:"WEBHOOK_BATCH_SIZE" envDefault:"50"`
+	// MaxAttempts is how many times a delivery is tried before it is dead-lettered
+	MaxAttempts    int           `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"8"`
+	RetryBaseDelay time.Duration `env:"WEBHOOK_RETRY_BASE_DELAY" envDefault:"30s"`
+	RetryMaxDelay  time.Duration `env:"WEBHOOK_RETRY_MAX_DELAY" envDefault:"1h"`
+	Lease          time.Duration `env:"WEBHOOK_LEASE" envDefault:"2m"`
+}
+
+// AlarmConfig holds the correlation settings used when no alarm correlation rule matches
+type AlarmConfig struct {
+	DedupeWindow      time.Duration `env:"ALARM_DEDUPE_WINDOW" envDefault:"60s"`
+	CorrelationWindow time.Duration `env:"ALARM_CORRELATION_WINDOW" envDefault:"30m"`
+}
+
+// DispatchConfig controls automatic template selection and guard dispatch for new incidents
+type DispatchConfig struct {
+	// Timezone is the time zone dispatch rule time windows are written in
+	Timezone string `env:"DISPATCH_TIMEZONE" envDefault:"UTC"`
+	// MaxActiveMissions is how many unfinished missions a guard may hold and still be dispatched
+	MaxActiveMissions int `env:"DISPATCH_MAX_ACTIVE_MISSIONS" envDefault:"1"`
+	// CategoryFallback selects a template whose category equals the alarm type when no rule matches
+	CategoryFallback bool `env:"DISPATCH_CATEGORY_FALLBACK" envDefault:"true"`
+}
@@ -2,7 +2,10 @@ module scs-guard
 
 go 1.23.3
 
-require github.com/labstack/echo/v4 v4.13.4
+require (
+	github.com/labstack/echo/v4 v4.13.4
+	golang.org/x/image v0.25.0
+)
 
 require (
 	github.com/KyleBanks/depth v1.2.1 // indirect
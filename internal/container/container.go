@@ -1,9 +1,13 @@
 package container
 
 import (
+	"net/http"
+	config "scs-guard/config"
+	"scs-guard/internal/events"
 	repositories "scs-guard/internal/repositories"
 	"scs-guard/internal/services"
-	minio_client "scs-guard/pkg/minio"
+	"scs-guard/pkg/logger"
+	"scs-guard/pkg/storage"
 
 	"gorm.io/gorm"
 )
@@ -16,28 +20,117 @@ type Container struct {
 	IncidentRepo             *repositories.IncidentRepository
 	IncidentMediaRepo        *repositories.IncidentMediaRepository
 	UserRepo                 *repositories.UserRepository
+	GuidanceTemplateRepo     *repositories.GuidanceTemplateRepository
+	AssignmentHistoryRepo    *repositories.MissionAssignmentHistoryRepository
+	OverdueEventRepo         *repositories.OverdueEventRepository
+	OutboxEventRepo          *repositories.OutboxEventRepository
+	WebhookRepo              *repositories.WebhookRepository
+	AlarmRepo                *repositories.AlarmRepository
+	DeviceRepo               *repositories.DeviceRepository
+	AlarmRuleRepo            *repositories.AlarmCorrelationRuleRepository
+	DispatchRuleRepo         *repositories.DispatchRuleRepository
+	GuardPremiseRepo         *repositories.GuardPremiseRepository
+	PremiseRepo              *repositories.PremiseRepository
+	IncidentStatusChangeRepo *repositories.IncidentStatusChangeRepository
+	TxManager                *repositories.TransactionManager
+	// Event broker
+	Broker *events.MemoryBroker
+	// File storage
+	Storage storage.Storage
 	// Services
-	MissionService *services.MissionService
+	IncidentService *services.IncidentService
+	CommentService  *services.CommentService
+	MissionService  *services.MissionService
+	MediaService    *services.MediaService
+	TemplateService *services.TemplateService
+	OutboxService   *services.OutboxService
+	WebhookService  *services.WebhookService
+	AlarmService    *services.AlarmService
+	DispatchService *services.DispatchService
 }
 
 // NewContainer creates a new dependency container with all repositories and services
-func NewContainer(db *gorm.DB, minioClient *minio_client.MinioClient) *Container {
+func NewContainer(cfg *config.Config, db *gorm.DB, store storage.Storage) (*Container, error) {
 	// Initialize repositories
 	incidentGuidanceRepo := repositories.NewIncidentGuidanceRepository(db)
 	incidentGuidanceStepRepo := repositories.NewIncidentGuidanceStepRepository(db)
 	incidentRepo := repositories.NewIncidentRepository(db)
 	incidentMediaRepo := repositories.NewIncidentMediaRepository(db)
+	mediaUploadRepo := repositories.NewMediaUploadRepository(db)
+	custodyRepo := repositories.NewMediaCustodyRepository(db)
 	userRepo := repositories.NewUserRepository(db)
+	guidanceTemplateRepo := repositories.NewGuidanceTemplateRepository(db)
+	assignmentHistoryRepo := repositories.NewMissionAssignmentHistoryRepository(db)
+	overdueEventRepo := repositories.NewOverdueEventRepository(db)
+	outboxEventRepo := repositories.NewOutboxEventRepository(db)
+	webhookRepo := repositories.NewWebhookRepository(db)
+	alarmRepo := repositories.NewAlarmRepository(db)
+	deviceRepo := repositories.NewDeviceRepository(db)
+	alarmRuleRepo := repositories.NewAlarmCorrelationRuleRepository(db)
+	dispatchRuleRepo := repositories.NewDispatchRuleRepository(db)
+	guardPremiseRepo := repositories.NewGuardPremiseRepository(db)
+	premiseRepo := repositories.NewPremiseRepository(db)
+	incidentStatusChangeRepo := repositories.NewIncidentStatusChangeRepository(db)
+	missionStatusChangeRepo := repositories.NewMissionStatusChangeRepository(db)
+	stepHistoryRepo := repositories.NewStepHistoryRepository(db)
+	commentRepo := repositories.NewCommentRepository(db)
+	txManager := repositories.NewTransactionManager(db)
+	// Initialize event broker
+	broker := events.NewMemoryBroker(cfg.Events.HistorySize)
+	sinks, err := events.NewSinks(cfg.Outbox.Sinks, broker, cfg.Outbox.WebhookURL, cfg.Outbox.WebhookTimeout, logger.GetLogger())
+	if err != nil {
+		return nil, err
+	}
+	// Webhook subscriptions receive every relayed event matching their filter
+	webhookService := services.NewWebhookService(*webhookRepo, &http.Client{Timeout: cfg.Webhook.Timeout}, cfg.Webhook)
+	sinks = append(sinks, webhookService)
 	// Initialize services
 
-	missionService := services.NewMissionService(*incidentGuidanceRepo, *incidentGuidanceStepRepo, *incidentRepo, *incidentMediaRepo, *minioClient)
+	incidentService := services.NewIncidentService(*incidentRepo, *incidentStatusChangeRepo, *alarmRepo, *assignmentHistoryRepo, *missionStatusChangeRepo, *stepHistoryRepo, *incidentMediaRepo, *custodyRepo, *overdueEventRepo, *userRepo, *commentRepo, *outboxEventRepo, *txManager, store, cfg.Media)
+	commentService := services.NewCommentService(*commentRepo, *incidentRepo, *userRepo, *outboxEventRepo, *txManager)
+	missionService := services.NewMissionService(*incidentGuidanceRepo, *incidentGuidanceStepRepo, *incidentRepo, *incidentMediaRepo, *mediaUploadRepo, *custodyRepo, *guidanceTemplateRepo, *userRepo, *assignmentHistoryRepo, *missionStatusChangeRepo, *stepHistoryRepo, *overdueEventRepo, *outboxEventRepo, incidentService, commentService, store, cfg.Media, *txManager, cfg.Escalation, cfg.SLA, cfg.Steps, broker, logger.GetLogger())
+	mediaService := services.NewMediaService(*incidentMediaRepo, *custodyRepo, *mediaUploadRepo, store, cfg.Media, *txManager)
+	templateService := services.NewTemplateService(*guidanceTemplateRepo, *txManager)
+	outboxService := services.NewOutboxService(*outboxEventRepo, sinks, cfg.Outbox)
+	dispatchService, err := services.NewDispatchService(*dispatchRuleRepo, *guardPremiseRepo, *premiseRepo, *guidanceTemplateRepo, *incidentRepo, missionService, cfg.Dispatch)
+	if err != nil {
+		return nil, err
+	}
+	alarmService := services.NewAlarmService(*alarmRepo, *deviceRepo, *alarmRuleRepo, *incidentRepo, incidentService, *txManager, dispatchService, cfg.Alarm, logger.GetLogger())
 
 	return &Container{
 		// Repositories
 		IncidentGuidanceRepo:     incidentGuidanceRepo,
 		IncidentGuidanceStepRepo: incidentGuidanceStepRepo,
+		IncidentRepo:             incidentRepo,
+		IncidentMediaRepo:        incidentMediaRepo,
 		UserRepo:                 userRepo,
+		GuidanceTemplateRepo:     guidanceTemplateRepo,
+		AssignmentHistoryRepo:    assignmentHistoryRepo,
+		OverdueEventRepo:         overdueEventRepo,
+		OutboxEventRepo:          outboxEventRepo,
+		WebhookRepo:              webhookRepo,
+		AlarmRepo:                alarmRepo,
+		DeviceRepo:               deviceRepo,
+		AlarmRuleRepo:            alarmRuleRepo,
+		DispatchRuleRepo:         dispatchRuleRepo,
+		GuardPremiseRepo:         guardPremiseRepo,
+		PremiseRepo:              premiseRepo,
+		IncidentStatusChangeRepo: incidentStatusChangeRepo,
+		TxManager:                txManager,
+		// Event broker
+		Broker: broker,
+		// File storage
+		Storage: store,
 		// Services
-		MissionService: missionService,
-	}
+		IncidentService: incidentService,
+		CommentService:  commentService,
+		MissionService:  missionService,
+		MediaService:    mediaService,
+		TemplateService: templateService,
+		OutboxService:   outboxService,
+		WebhookService:  webhookService,
+		AlarmService:    alarmService,
+		DispatchService: dispatchService,
+	}, nil
 }
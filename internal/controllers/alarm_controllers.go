@@ -0,0 +1,338 @@
+package http
+
+import (
+	"scs-guard/internal/dto"
+	"scs-guard/internal/models"
+	services "scs-guard/internal/services"
+	"scs-guard/pkg/errors"
+	"scs-guard/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// apiKeyHeader is the header carrying the API key of an alarm device
+const apiKeyHeader = "X-API-Key"
+
+// AlarmHandler handles alarm ingestion, device and correlation rule HTTP requests
+// @Description Alarm handler for ingesting device alarms and managing devices and correlation rules
+type AlarmHandler struct {
+	svc services.AlarmService
+}
+
+// NewAlarmHandler constructor
+func NewAlarmHandler(svc services.AlarmService) *AlarmHandler {
+	return &AlarmHandler{svc: svc}
+}
+
+// DeviceAuth authenticates the device sending a request by its API key and stores it in the context
+func (h *AlarmHandler) DeviceAuth(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		apiKey := c.Request().Header.Get(apiKeyHeader)
+		if apiKey == "" {
+			return errors.NewUnauthorizedError("missing api key")
+		}
+		device, err := h.svc.AuthenticateDevice(c.Request().Context(), apiKey)
+		if err != nil {
+			return err
+		}
+		c.Set("device", device)
+		return next(c)
+	}
+}
+
+// IngestAlarm stores an alarm sent by a device and correlates it into an incident
+// @Summary Ingest an alarm
+// @Description Store an alarm sent by a device. Repeats from the same premise and type within the dedupe window are counted on the first alarm; other alarms join the open incident of a related recent alarm or open a new incident with a severity mapped from the alarm severity.
+// @Tags alarms
+// @Accept json
+// @Produce json
+// @Security DeviceAPIKey
+// @Param request body dto.IngestAlarmDto true "Alarm"
+// @Success 201 {object} middleware.SuccessResponse{data=dto.AlarmIngestionResultDto} "Alarm stored"
+// @Success 200 {object} middleware.SuccessResponse{data=dto.AlarmIngestionResultDto} "Alarm deduplicated"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Invalid or deactivated api key"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/alarms [post]
+func (h *AlarmHandler) IngestAlarm() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		device, ok := c.Get("device").(*models.Device)
+		if !ok {
+			return errors.NewUnauthorizedError("device not found in context")
+		}
+		var ingestDto dto.IngestAlarmDto
+		if err := c.Bind(&ingestDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(ingestDto); err != nil {
+			return err
+		}
+		result, err := h.svc.IngestAlarm(c.Request().Context(), device, ingestDto)
+		if err != nil {
+			return err
+		}
+		if result.Deduplicated {
+			return c.JSON(200, result)
+		}
+		return c.JSON(201, result)
+	}
+}
+
+// CreateDevice registers an alarm device
+// @Summary Register an alarm device
+// @Description Register a device for a premise. The API key is only returned by this call.
+// @Tags alarms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateDeviceDto true "Create device request"
+// @Success 201 {object} middleware.SuccessResponse{data=dto.DeviceAPIKeyDto} "Created device with its api key"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Premise not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/alarms/devices [post]
+func (h *AlarmHandler) CreateDevice() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var createDto dto.CreateDeviceDto
+		if err := c.Bind(&createDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(createDto); err != nil {
+			return err
+		}
+		device, err := h.svc.CreateDevice(c.Request().Context(), createDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, device)
+	}
+}
+
+// GetDevices lists alarm devices
+// @Summary List alarm devices
+// @Description List every alarm device; api keys are not returned
+// @Tags alarms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} middleware.SuccessResponse{data=[]models.Device} "Devices"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/alarms/devices [get]
+func (h *AlarmHandler) GetDevices() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		devices, err := h.svc.GetDevices(c.Request().Context())
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, devices)
+	}
+}
+
+// RotateDeviceKey issues a new API key for a device
+// @Summary Rotate a device api key
+// @Description Issue a new API key for a device; the previous key stops working immediately
+// @Tags alarms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Device ID"
+// @Success 200 {object} middleware.SuccessResponse{data=dto.DeviceAPIKeyDto} "Device with its new api key"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Device not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/alarms/devices/{id}/rotate-key [post]
+func (h *AlarmHandler) RotateDeviceKey() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		device, err := h.svc.RotateDeviceKey(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, device)
+	}
+}
+
+// DeactivateDevice stops a device from sending alarms
+// @Summary Deactivate an alarm device
+// @Description Deactivate a device; alarms sent with its api key are rejected
+// @Tags alarms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Device ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.Device} "Deactivated device"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Device not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/alarms/devices/{id}/deactivate [patch]
+func (h *AlarmHandler) DeactivateDevice() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		device, err := h.svc.SetDeviceActive(c.Request().Context(), c.Param("id"), false)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, device)
+	}
+}
+
+// ActivateDevice allows a deactivated device to send alarms again
+// @Summary Activate an alarm device
+// @Description Activate a previously deactivated device
+// @Tags alarms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Device ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.Device} "Activated device"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Device not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/alarms/devices/{id}/activate [patch]
+func (h *AlarmHandler) ActivateDevice() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		device, err := h.svc.SetDeviceActive(c.Request().Context(), c.Param("id"), true)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, device)
+	}
+}
+
+// CreateRule creates an alarm correlation rule
+// @Summary Create an alarm correlation rule
+// @Description Create a rule overriding the dedupe and correlation windows and the severity mapping for alarms of a type and/or premise. The highest priority matching rule applies.
+// @Tags alarms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.AlarmCorrelationRuleDto true "Rule"
+// @Success 201 {object} middleware.SuccessResponse{data=models.AlarmCorrelationRule} "Created rule"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/alarms/rules [post]
+func (h *AlarmHandler) CreateRule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var ruleDto dto.AlarmCorrelationRuleDto
+		if err := c.Bind(&ruleDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(ruleDto); err != nil {
+			return err
+		}
+		rule, err := h.svc.CreateRule(c.Request().Context(), ruleDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, rule)
+	}
+}
+
+// GetRules lists alarm correlation rules
+// @Summary List alarm correlation rules
+// @Description List every alarm correlation rule, highest priority first
+// @Tags alarms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} middleware.SuccessResponse{data=[]models.AlarmCorrelationRule} "Rules"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/alarms/rules [get]
+func (h *AlarmHandler) GetRules() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		rules, err := h.svc.GetRules(c.Request().Context())
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, rules)
+	}
+}
+
+// GetRule retrieves an alarm correlation rule
+// @Summary Get an alarm correlation rule
+// @Description Retrieve an alarm correlation rule
+// @Tags alarms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.AlarmCorrelationRule} "Rule"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Rule not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/alarms/rules/{id} [get]
+func (h *AlarmHandler) GetRule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		rule, err := h.svc.GetRule(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, rule)
+	}
+}
+
+// UpdateRule replaces an alarm correlation rule
+// @Summary Update an alarm correlation rule
+// @Description Replace every field of an alarm correlation rule
+// @Tags alarms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Param request body dto.AlarmCorrelationRuleDto true "Rule"
+// @Success 200 {object} middleware.SuccessResponse{data=models.AlarmCorrelationRule} "Updated rule"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Rule not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/alarms/rules/{id} [put]
+func (h *AlarmHandler) UpdateRule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var ruleDto dto.AlarmCorrelationRuleDto
+		if err := c.Bind(&ruleDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(ruleDto); err != nil {
+			return err
+		}
+		rule, err := h.svc.UpdateRule(c.Request().Context(), c.Param("id"), ruleDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, rule)
+	}
+}
+
+// DeleteRule removes an alarm correlation rule
+// @Summary Delete an alarm correlation rule
+// @Description Delete an alarm correlation rule
+// @Tags alarms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Success 200 {object} middleware.SuccessResponse{data=string} "Rule deleted"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Rule not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/alarms/rules/{id} [delete]
+func (h *AlarmHandler) DeleteRule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := h.svc.DeleteRule(c.Request().Context(), c.Param("id")); err != nil {
+			return err
+		}
+		return c.JSON(200, "success")
+	}
+}
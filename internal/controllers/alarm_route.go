@@ -0,0 +1,27 @@
+package http
+
+import (
+	middleware "scs-guard/internal/middlewares"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes registers the alarm routes. Alarms are sent by devices authenticated by their
+// API key, while devices and correlation rules are managed by authenticated users.
+func (h *AlarmHandler) RegisterRoutes(g *echo.Group, mw *middleware.MiddlewareManager) {
+	g.POST("", h.IngestAlarm(), h.DeviceAuth)
+
+	manage := mw.RequirePermission(middleware.PermissionAlarmManage)
+
+	g.POST("/devices", h.CreateDevice(), mw.JWTAuth, manage)
+	g.GET("/devices", h.GetDevices(), mw.JWTAuth, manage)
+	g.POST("/devices/:id/rotate-key", h.RotateDeviceKey(), mw.JWTAuth, manage)
+	g.PATCH("/devices/:id/deactivate", h.DeactivateDevice(), mw.JWTAuth, manage)
+	g.PATCH("/devices/:id/activate", h.ActivateDevice(), mw.JWTAuth, manage)
+
+	g.POST("/rules", h.CreateRule(), mw.JWTAuth, manage)
+	g.GET("/rules", h.GetRules(), mw.JWTAuth, manage)
+	g.GET("/rules/:id", h.GetRule(), mw.JWTAuth, manage)
+	g.PUT("/rules/:id", h.UpdateRule(), mw.JWTAuth, manage)
+	g.DELETE("/rules/:id", h.DeleteRule(), mw.JWTAuth, manage)
+}
@@ -0,0 +1,222 @@
+package http
+
+import (
+	"scs-guard/internal/dto"
+	"scs-guard/internal/models"
+	repositories "scs-guard/internal/repositories"
+	services "scs-guard/internal/services"
+	"scs-guard/pkg/validation"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// CommentHandler handles comment HTTP requests
+// @Description Comment handler for notes left on incidents, missions and mission steps
+type CommentHandler struct {
+	svc services.CommentService
+}
+
+// NewCommentHandler constructor
+func NewCommentHandler(svc services.CommentService) *CommentHandler {
+	return &CommentHandler{svc: svc}
+}
+
+// CreateComment adds a comment to an incident
+// @Summary Comment on an incident
+// @Description Leave a comment on an incident, its mission or one of the mission steps. Mentioned users and the mission assignee are notified with a comment.added event. Guards can only comment on incidents whose mission is assigned to them.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param request body dto.CreateCommentDto true "Create comment request"
+// @Success 201 {object} middleware.SuccessResponse{data=models.Comment} "Created comment"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - incident is not assigned to the guard"
+// @Failure 404 {object} errors.ErrorResponse "Incident, mission, step or mentioned user not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/comments [post]
+func (h *CommentHandler) CreateComment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var createDto dto.CreateCommentDto
+		if err := c.Bind(&createDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(createDto); err != nil {
+			return err
+		}
+		comment, err := h.svc.CreateComment(c.Request().Context(), c.Param("id"), userID, getRole(c), createDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, comment)
+	}
+}
+
+// GetComments lists the comments of an incident
+// @Summary List the comments of an incident
+// @Description List the comments of an incident, oldest first. Deleted comments are left out unless include_deleted is set, which only operators and admins may do.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param target_type query string false "Filter by target" Enums(incident, mission, step)
+// @Param step_id query string false "Filter by mission step"
+// @Param include_deleted query bool false "Include deleted comments" default(false)
+// @Param limit query int false "Page size, 1 to 200" default(50)
+// @Param offset query int false "Number of comments to skip" default(0)
+// @Success 200 {object} middleware.SuccessResponse{data=dto.CommentPageDto} "Page of comments"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - invalid filter or pagination"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Incident not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/comments [get]
+func (h *CommentHandler) GetComments() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		limit, offset, err := getPagination(c)
+		if err != nil {
+			return err
+		}
+		filter := repositories.CommentFilter{
+			TargetType:     c.QueryParam("target_type"),
+			StepID:         c.QueryParam("step_id"),
+			IncludeDeleted: c.QueryParam("include_deleted") == "true",
+			Limit:          limit,
+			Offset:         offset,
+		}
+		switch filter.TargetType {
+		case "", models.CommentTargetIncident, models.CommentTargetMission, models.CommentTargetStep:
+		default:
+			return echo.NewHTTPError(400, "target_type must be one of incident, mission, step")
+		}
+		if filter.StepID != "" {
+			if _, err := uuid.Parse(filter.StepID); err != nil {
+				return echo.NewHTTPError(400, "step_id must be a uuid")
+			}
+		}
+		page, err := h.svc.GetComments(c.Request().Context(), c.Param("id"), userID, getRole(c), filter)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, page)
+	}
+}
+
+// UpdateComment edits a comment
+// @Summary Edit a comment
+// @Description Edit the text and mentions of a comment. Only the author can edit a comment; the previous text is kept in its edit history and newly mentioned users are notified with a comment.edited event.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param commentId path string true "Comment ID"
+// @Param request body dto.UpdateCommentDto true "Update comment request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.Comment} "Edited comment"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - not the author"
+// @Failure 404 {object} errors.ErrorResponse "Incident, comment or mentioned user not found"
+// @Failure 409 {object} errors.ErrorResponse "Comment is deleted"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/comments/{commentId} [patch]
+func (h *CommentHandler) UpdateComment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var updateDto dto.UpdateCommentDto
+		if err := c.Bind(&updateDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(updateDto); err != nil {
+			return err
+		}
+		comment, err := h.svc.UpdateComment(c.Request().Context(), c.Param("id"), c.Param("commentId"), userID, getRole(c), updateDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, comment)
+	}
+}
+
+// DeleteComment deletes a comment
+// @Summary Delete a comment
+// @Description Soft delete a comment. Authors can delete their own comments; operators and admins can delete any comment. Deleted comments are kept for operators and admins.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param commentId path string true "Comment ID"
+// @Param request body dto.DeleteCommentDto false "Delete comment request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.Comment} "Deleted comment"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - not the author"
+// @Failure 404 {object} errors.ErrorResponse "Incident or comment not found"
+// @Failure 409 {object} errors.ErrorResponse "Comment is already deleted"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/comments/{commentId} [delete]
+func (h *CommentHandler) DeleteComment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var deleteDto dto.DeleteCommentDto
+		if err := c.Bind(&deleteDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(deleteDto); err != nil {
+			return err
+		}
+		comment, err := h.svc.DeleteComment(c.Request().Context(), c.Param("id"), c.Param("commentId"), userID, getRole(c), deleteDto.Reason)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, comment)
+	}
+}
+
+// GetCommentEdits retrieves the edit history of a comment
+// @Summary Get the edit history of a comment
+// @Description Retrieve the previous versions of a comment, oldest first
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param commentId path string true "Comment ID"
+// @Success 200 {object} middleware.SuccessResponse{data=[]models.CommentEdit} "Edit history"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Incident or comment not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/comments/{commentId}/edits [get]
+func (h *CommentHandler) GetCommentEdits() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		edits, err := h.svc.GetCommentEdits(c.Request().Context(), c.Param("id"), c.Param("commentId"), userID, getRole(c))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, edits)
+	}
+}
@@ -0,0 +1,18 @@
+package http
+
+import (
+	middleware "scs-guard/internal/middlewares"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes registers the comment routes on the incident group
+func (h *CommentHandler) RegisterRoutes(g *echo.Group, mw *middleware.MiddlewareManager) {
+	comment := mw.RequirePermission(middleware.PermissionIncidentComment)
+
+	g.POST("/:id/comments", h.CreateComment(), comment)
+	g.GET("/:id/comments", h.GetComments(), comment)
+	g.PATCH("/:id/comments/:commentId", h.UpdateComment(), comment)
+	g.DELETE("/:id/comments/:commentId", h.DeleteComment(), comment)
+	g.GET("/:id/comments/:commentId/edits", h.GetCommentEdits(), comment)
+}
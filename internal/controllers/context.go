@@ -0,0 +1,49 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// getUserID returns the authenticated user ID stored by the JWT middleware
+func getUserID(c echo.Context) (string, error) {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return "", echo.NewHTTPError(401, "user_id not found in context")
+	}
+	return userID, nil
+}
+
+// getRole returns the role of the authenticated user stored by the JWT middleware
+func getRole(c echo.Context) string {
+	role, _ := c.Get("role").(string)
+	return role
+}
+
+// Page sizes accepted by paginated endpoints
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// getPagination reads the limit and offset query parameters of a paginated endpoint
+func getPagination(c echo.Context) (int, int, error) {
+	limit, offset := defaultPageLimit, 0
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxPageLimit {
+			return 0, 0, echo.NewHTTPError(400, fmt.Sprintf("limit must be between 1 and %d", maxPageLimit))
+		}
+		limit = parsed
+	}
+	if value := c.QueryParam("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return 0, 0, echo.NewHTTPError(400, "offset must be a non-negative integer")
+		}
+		offset = parsed
+	}
+	return limit, offset, nil
+}
@@ -0,0 +1,293 @@
+package http
+
+import (
+	"scs-guard/internal/dto"
+	services "scs-guard/internal/services"
+	"scs-guard/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DispatchHandler handles dispatch rule, guard coverage and dispatch HTTP requests
+// @Description Dispatch handler for automatic template selection and guard dispatch
+type DispatchHandler struct {
+	svc services.DispatchService
+}
+
+// NewDispatchHandler constructor
+func NewDispatchHandler(svc services.DispatchService) *DispatchHandler {
+	return &DispatchHandler{svc: svc}
+}
+
+// Explain evaluates the dispatch rules without dispatching
+// @Summary Explain dispatch
+// @Description Dry run of dispatch for an existing incident or for incident attributes: every active rule is evaluated with the conditions it does not meet, and the matched rule, selected template and picked guard are returned with notes explaining each decision. Nothing is created.
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ExplainDispatchDto true "Incident or attributes to dispatch"
+// @Success 200 {object} middleware.SuccessResponse{data=dto.DispatchPlanDto} "Dispatch plan"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Incident not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/dispatch/explain [post]
+func (h *DispatchHandler) Explain() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var explainDto dto.ExplainDispatchDto
+		if err := c.Bind(&explainDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(explainDto); err != nil {
+			return err
+		}
+		plan, err := h.svc.Explain(c.Request().Context(), explainDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, plan)
+	}
+}
+
+// DispatchIncident runs dispatch for an incident without a mission
+// @Summary Dispatch an incident
+// @Description Run the dispatch rules for an incident that has no mission yet. A mission is created when the matched rule dispatches automatically and an available guard covers the premise; otherwise only the plan is returned.
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Success 200 {object} middleware.SuccessResponse{data=dto.DispatchResultDto} "Dispatch result"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Incident not found"
+// @Failure 409 {object} errors.ErrorResponse "Incident already has a mission"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/dispatch/incidents/{id} [post]
+func (h *DispatchHandler) DispatchIncident() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		result, err := h.svc.DispatchIncidentByID(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, result)
+	}
+}
+
+// CreateRule creates a dispatch rule
+// @Summary Create a dispatch rule
+// @Description Create a rule selecting the template, and whether a guard is dispatched automatically, for incidents matching an alarm type, severity, premise and time of day. The highest priority matching rule applies.
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.DispatchRuleDto true "Rule"
+// @Success 201 {object} middleware.SuccessResponse{data=models.DispatchRule} "Created rule"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Guidance template not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/dispatch/rules [post]
+func (h *DispatchHandler) CreateRule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var ruleDto dto.DispatchRuleDto
+		if err := c.Bind(&ruleDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(ruleDto); err != nil {
+			return err
+		}
+		rule, err := h.svc.CreateRule(c.Request().Context(), ruleDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, rule)
+	}
+}
+
+// GetRules lists dispatch rules
+// @Summary List dispatch rules
+// @Description List every dispatch rule, highest priority first
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} middleware.SuccessResponse{data=[]models.DispatchRule} "Rules"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/dispatch/rules [get]
+func (h *DispatchHandler) GetRules() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		rules, err := h.svc.GetRules(c.Request().Context())
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, rules)
+	}
+}
+
+// GetRule retrieves a dispatch rule
+// @Summary Get a dispatch rule
+// @Description Retrieve a dispatch rule
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.DispatchRule} "Rule"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Rule not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/dispatch/rules/{id} [get]
+func (h *DispatchHandler) GetRule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		rule, err := h.svc.GetRule(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, rule)
+	}
+}
+
+// UpdateRule replaces a dispatch rule
+// @Summary Update a dispatch rule
+// @Description Replace every field of a dispatch rule
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Param request body dto.DispatchRuleDto true "Rule"
+// @Success 200 {object} middleware.SuccessResponse{data=models.DispatchRule} "Updated rule"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Rule or guidance template not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/dispatch/rules/{id} [put]
+func (h *DispatchHandler) UpdateRule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var ruleDto dto.DispatchRuleDto
+		if err := c.Bind(&ruleDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(ruleDto); err != nil {
+			return err
+		}
+		rule, err := h.svc.UpdateRule(c.Request().Context(), c.Param("id"), ruleDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, rule)
+	}
+}
+
+// DeleteRule removes a dispatch rule
+// @Summary Delete a dispatch rule
+// @Description Delete a dispatch rule
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Success 200 {object} middleware.SuccessResponse{data=string} "Rule deleted"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Rule not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/dispatch/rules/{id} [delete]
+func (h *DispatchHandler) DeleteRule() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := h.svc.DeleteRule(c.Request().Context(), c.Param("id")); err != nil {
+			return err
+		}
+		return c.JSON(200, "success")
+	}
+}
+
+// GetPremiseGuards lists the guards covering a premise
+// @Summary List the guards covering a premise
+// @Description List the guards that can be dispatched to incidents of a premise. Guards covering a parent premise can be dispatched too.
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Premise ID"
+// @Success 200 {object} middleware.SuccessResponse{data=[]models.GuardPremise} "Guards covering the premise"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Premise not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/dispatch/premises/{id}/guards [get]
+func (h *DispatchHandler) GetPremiseGuards() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		guards, err := h.svc.GetPremiseGuards(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, guards)
+	}
+}
+
+// AddPremiseGuard lets a guard cover a premise
+// @Summary Add a guard to a premise
+// @Description Let a guard cover a premise and its sub-premises so they can be dispatched to their incidents
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Premise ID"
+// @Param request body dto.AddGuardPremiseDto true "Guard"
+// @Success 201 {object} middleware.SuccessResponse{data=models.GuardPremise} "Guard coverage"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error or user is not a guard"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Premise or guard not found"
+// @Failure 409 {object} errors.ErrorResponse "Guard already covers the premise"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/dispatch/premises/{id}/guards [post]
+func (h *DispatchHandler) AddPremiseGuard() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var addDto dto.AddGuardPremiseDto
+		if err := c.Bind(&addDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(addDto); err != nil {
+			return err
+		}
+		guardPremise, err := h.svc.AddPremiseGuard(c.Request().Context(), c.Param("id"), addDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, guardPremise)
+	}
+}
+
+// RemovePremiseGuard stops a guard from covering a premise
+// @Summary Remove a guard from a premise
+// @Description Stop a guard from covering a premise
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Premise ID"
+// @Param userId path string true "Guard user ID"
+// @Success 200 {object} middleware.SuccessResponse{data=string} "Guard removed"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Guard does not cover the premise"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/dispatch/premises/{id}/guards/{userId} [delete]
+func (h *DispatchHandler) RemovePremiseGuard() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := h.svc.RemovePremiseGuard(c.Request().Context(), c.Param("id"), c.Param("userId")); err != nil {
+			return err
+		}
+		return c.JSON(200, "success")
+	}
+}
@@ -0,0 +1,25 @@
+package http
+
+import (
+	middleware "scs-guard/internal/middlewares"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *DispatchHandler) RegisterRoutes(g *echo.Group, mw *middleware.MiddlewareManager) {
+	assign := mw.RequirePermission(middleware.PermissionMissionAssign)
+	manage := mw.RequirePermission(middleware.PermissionDispatchManage)
+
+	g.POST("/explain", h.Explain(), assign)
+	g.POST("/incidents/:id", h.DispatchIncident(), assign)
+
+	g.POST("/rules", h.CreateRule(), manage)
+	g.GET("/rules", h.GetRules(), manage)
+	g.GET("/rules/:id", h.GetRule(), manage)
+	g.PUT("/rules/:id", h.UpdateRule(), manage)
+	g.DELETE("/rules/:id", h.DeleteRule(), manage)
+
+	g.GET("/premises/:id/guards", h.GetPremiseGuards(), manage)
+	g.POST("/premises/:id/guards", h.AddPremiseGuard(), manage)
+	g.DELETE("/premises/:id/guards/:userId", h.RemovePremiseGuard(), manage)
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	config "scs-guard/config"
+	"scs-guard/internal/events"
+	services "scs-guard/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// EventHandler streams mission events to clients
+// @Description Event handler for real-time mission updates
+type EventHandler struct {
+	svc services.MissionService
+	cfg config.EventsConfig
+}
+
+// NewEventHandler constructor
+func NewEventHandler(svc services.MissionService, cfg config.EventsConfig) *EventHandler {
+	return &EventHandler{svc: svc, cfg: cfg}
+}
+
+// StreamEvents streams mission events as Server-Sent Events
+// @Summary Stream mission events
+// @Description Stream mission events (mission.assigned, mission.reassigned, mission.escalated, mission.status_changed, step.completed, step.skipped, step.completion_undone, media.uploaded, incident.status_changed, incident.dispatch_failed, comment.added, comment.edited) as Server-Sent Events. Guards only receive events about their own missions. Send the ID of the last event received in the Last-Event-ID header or last_event_id query parameter to resume after a reconnect.
+// @Tags events
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param Last-Event-ID header int false "ID of the last event received"
+// @Param last_event_id query int false "ID of the last event received, for clients that cannot set headers"
+// @Param types query string false "Comma separated event types to receive, all types by default"
+// @Success 200 {object} events.Event "Stream of events"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - invalid last event ID"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/events [get]
+func (h *EventHandler) StreamEvents() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		lastEventID, err := parseLastEventID(c)
+		if err != nil {
+			return err
+		}
+		types := map[string]bool{}
+		for _, eventType := range strings.Split(c.QueryParam("types"), ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				types[eventType] = true
+			}
+		}
+
+		sub := h.svc.SubscribeEvents(userID, getRole(c), lastEventID)
+		defer sub.Close()
+
+		res := c.Response()
+		// The stream outlives the server write timeout
+		_ = http.NewResponseController(res).SetWriteDeadline(time.Time{})
+		res.Header().Set(echo.HeaderContentType, "text/event-stream")
+		res.Header().Set(echo.HeaderCacheControl, "no-cache")
+		res.Header().Set(echo.HeaderConnection, "keep-alive")
+		res.Header().Set("X-Accel-Buffering", "no")
+		res.WriteHeader(http.StatusOK)
+		fmt.Fprint(res, "retry: 3000\n\n")
+		res.Flush()
+
+		heartbeat := time.NewTicker(h.cfg.HeartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-c.Request().Context().Done():
+				return nil
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+					return nil
+				}
+				res.Flush()
+			case event, ok := <-sub.Events:
+				if !ok {
+					// Dropped for falling behind, the client reconnects and resumes
+					return nil
+				}
+				if len(types) > 0 && !types[event.Type] {
+					continue
+				}
+				if err := writeEvent(res, event); err != nil {
+					return nil
+				}
+				res.Flush()
+			}
+		}
+	}
+}
+
+// parseLastEventID reads the ID of the last event a reconnecting client received
+func parseLastEventID(c echo.Context) (uint64, error) {
+	value := c.Request().Header.Get("Last-Event-ID")
+	if value == "" {
+		value = c.QueryParam("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, echo.NewHTTPError(400, "invalid last event id")
+	}
+	return id, nil
+}
+
+// writeEvent writes an event in the Server-Sent Events format
+func writeEvent(w io.Writer, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
@@ -0,0 +1,13 @@
+package http
+
+import (
+	middleware "scs-guard/internal/middlewares"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *EventHandler) RegisterRoutes(g *echo.Group, mw *middleware.MiddlewareManager) {
+	view := mw.RequirePermission(middleware.PermissionMissionView)
+
+	g.GET("", h.StreamEvents(), view)
+}
@@ -0,0 +1,300 @@
+package http
+
+import (
+	"scs-guard/internal/dto"
+	repositories "scs-guard/internal/repositories"
+	services "scs-guard/internal/services"
+	"scs-guard/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// IncidentHandler handles incident HTTP requests
+// @Description Incident handler for managing incidents and their status workflow
+type IncidentHandler struct {
+	svc services.IncidentService
+}
+
+// NewIncidentHandler constructor
+func NewIncidentHandler(svc services.IncidentService) *IncidentHandler {
+	return &IncidentHandler{svc: svc}
+}
+
+// CreateIncident opens an incident
+// @Summary Create an incident
+// @Description Open an incident by hand, optionally linked to the alarm that caused it. The incident starts in the new status.
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateIncidentDto true "Create incident request"
+// @Success 201 {object} middleware.SuccessResponse{data=models.Incident} "Created incident"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Alarm not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents [post]
+func (h *IncidentHandler) CreateIncident() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var createDto dto.CreateIncidentDto
+		if err := c.Bind(&createDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(createDto); err != nil {
+			return err
+		}
+		incident, err := h.svc.CreateIncident(c.Request().Context(), userID, createDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, incident)
+	}
+}
+
+// GetIncidents lists incidents
+// @Summary List incidents
+// @Description List incidents, newest first
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status" Enums(new, in_progress, resolved, closed)
+// @Param severity query string false "Filter by severity" Enums(low, medium, high)
+// @Param limit query int false "Page size, 1 to 200" default(50)
+// @Param offset query int false "Number of incidents to skip" default(0)
+// @Success 200 {object} middleware.SuccessResponse{data=dto.IncidentPageDto} "Page of incidents"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - invalid pagination"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents [get]
+func (h *IncidentHandler) GetIncidents() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		limit, offset, err := getPagination(c)
+		if err != nil {
+			return err
+		}
+		filter := repositories.IncidentFilter{
+			Status:   c.QueryParam("status"),
+			Severity: c.QueryParam("severity"),
+			Limit:    limit,
+			Offset:   offset,
+		}
+		page, err := h.svc.GetIncidents(c.Request().Context(), filter)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, page)
+	}
+}
+
+// GetIncident retrieves an incident
+// @Summary Get an incident
+// @Description Retrieve an incident with its alarm and mission
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.Incident} "Incident"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Incident not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id} [get]
+func (h *IncidentHandler) GetIncident() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		incident, err := h.svc.GetIncident(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, incident)
+	}
+}
+
+// GetIncidentTimeline retrieves the timeline of an incident
+// @Summary Get the timeline of an incident
+// @Description Retrieve everything that happened during an incident in one feed, oldest first: the triggering and correlated alarms, incident and mission status changes, mission assignment, reassignment and escalation, step completions, missed deadlines and media uploads. Each entry names the user or device that caused it; entries without an actor were caused by the system. Media entries carry a short-lived download URL, and returning them is recorded in the chain of custody of the media as a view.
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param limit query int false "Page size, 1 to 200" default(50)
+// @Param offset query int false "Number of entries to skip" default(0)
+// @Success 200 {object} middleware.SuccessResponse{data=dto.IncidentTimelineDto} "Page of the timeline"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - invalid pagination"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Incident not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/timeline [get]
+func (h *IncidentHandler) GetIncidentTimeline() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		limit, offset, err := getPagination(c)
+		if err != nil {
+			return err
+		}
+		timeline, err := h.svc.GetTimeline(c.Request().Context(), userID, c.Param("id"), limit, offset)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, timeline)
+	}
+}
+
+// UpdateIncident edits an incident
+// @Summary Update an incident
+// @Description Edit the name, description, severity or location of an incident that is not closed. Omitted fields are left unchanged.
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param request body dto.UpdateIncidentDto true "Update incident request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.Incident} "Updated incident"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error or incident closed"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Incident not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id} [patch]
+func (h *IncidentHandler) UpdateIncident() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var updateDto dto.UpdateIncidentDto
+		if err := c.Bind(&updateDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(updateDto); err != nil {
+			return err
+		}
+		incident, err := h.svc.UpdateIncident(c.Request().Context(), c.Param("id"), updateDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, incident)
+	}
+}
+
+// ResolveIncident resolves an incident
+// @Summary Resolve an incident
+// @Description Resolve a new or in progress incident with a resolution summary. While mandatory steps of its mission are incomplete the request is rejected with a VALIDATION_ERROR listing them, unless an override reason is given.
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param request body dto.ResolveIncidentDto true "Resolve incident request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.Incident} "Resolved incident"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - incomplete mandatory steps or invalid transition"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Incident not found"
+// @Failure 409 {object} errors.ErrorResponse "Incident changed concurrently"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/resolve [post]
+func (h *IncidentHandler) ResolveIncident() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var resolveDto dto.ResolveIncidentDto
+		if err := c.Bind(&resolveDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(resolveDto); err != nil {
+			return err
+		}
+		incident, err := h.svc.ResolveIncident(c.Request().Context(), c.Param("id"), userID, resolveDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, incident)
+	}
+}
+
+// ReopenIncident reopens a resolved incident
+// @Summary Reopen an incident
+// @Description Move a resolved incident back to in progress
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param request body dto.ReopenIncidentDto true "Reopen incident request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.Incident} "Reopened incident"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - invalid transition"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Incident not found"
+// @Failure 409 {object} errors.ErrorResponse "Incident changed concurrently"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/reopen [post]
+func (h *IncidentHandler) ReopenIncident() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var reopenDto dto.ReopenIncidentDto
+		if err := c.Bind(&reopenDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(reopenDto); err != nil {
+			return err
+		}
+		incident, err := h.svc.ReopenIncident(c.Request().Context(), c.Param("id"), userID, reopenDto.Reason)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, incident)
+	}
+}
+
+// CloseIncident closes a resolved incident
+// @Summary Close an incident
+// @Description Close a resolved incident; closed incidents can no longer be edited or reopened
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param request body dto.CloseIncidentDto false "Close incident request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.Incident} "Closed incident"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - invalid transition"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Incident not found"
+// @Failure 409 {object} errors.ErrorResponse "Incident changed concurrently"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/close [post]
+func (h *IncidentHandler) CloseIncident() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var closeDto dto.CloseIncidentDto
+		if err := c.Bind(&closeDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(closeDto); err != nil {
+			return err
+		}
+		incident, err := h.svc.CloseIncident(c.Request().Context(), c.Param("id"), userID, closeDto.Reason)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, incident)
+	}
+}
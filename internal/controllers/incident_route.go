@@ -0,0 +1,21 @@
+package http
+
+import (
+	middleware "scs-guard/internal/middlewares"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *IncidentHandler) RegisterRoutes(g *echo.Group, mw *middleware.MiddlewareManager) {
+	view := mw.RequirePermission(middleware.PermissionIncidentView)
+	manage := mw.RequirePermission(middleware.PermissionIncidentManage)
+
+	g.POST("", h.CreateIncident(), manage)
+	g.GET("", h.GetIncidents(), view)
+	g.GET("/:id", h.GetIncident(), view)
+	g.GET("/:id/timeline", h.GetIncidentTimeline(), view)
+	g.PATCH("/:id", h.UpdateIncident(), manage)
+	g.POST("/:id/resolve", h.ResolveIncident(), manage)
+	g.POST("/:id/reopen", h.ReopenIncident(), manage)
+	g.POST("/:id/close", h.CloseIncident(), manage)
+}
@@ -0,0 +1,278 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"path"
+	"scs-guard/internal/dto"
+	repositories "scs-guard/internal/repositories"
+	services "scs-guard/internal/services"
+	"scs-guard/pkg/validation"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// MediaHandler handles incident media HTTP requests
+// @Description Media handler for accessing incident media and their chain of custody
+type MediaHandler struct {
+	svc services.MediaService
+}
+
+// NewMediaHandler constructor
+func NewMediaHandler(svc services.MediaService) *MediaHandler {
+	return &MediaHandler{svc: svc}
+}
+
+// GetMedia retrieves a media of an incident
+// @Summary Get incident media
+// @Description Retrieve a media file of an incident with short-lived download URLs of the file and, once processing is done, of its thumbnail and web preview. Deleted media are returned too, without URLs once their files were purged. The processing_status is pending until the thumbnail and preview of an image were made, then done or failed; videos are skipped. The view is recorded in the chain of custody.
+// @Tags media
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param mediaId path string true "Media ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.IncidentMedia} "Media"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Media not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/media/{mediaId} [get]
+func (h *MediaHandler) GetMedia() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		media, err := h.svc.GetMedia(c.Request().Context(), userID, c.Param("id"), c.Param("mediaId"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, media)
+	}
+}
+
+// GetMediaCustody retrieves the chain of custody of a media
+// @Summary Get the chain of custody of incident media
+// @Description Retrieve every upload, view, download, export and verification of a media file of an incident, oldest first, with the user who performed it. Entries are never changed or removed.
+// @Tags media
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param mediaId path string true "Media ID"
+// @Success 200 {object} middleware.SuccessResponse{data=[]models.MediaCustodyEntry} "Chain of custody"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Media not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/media/{mediaId}/custody [get]
+func (h *MediaHandler) GetMediaCustody() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		entries, err := h.svc.GetCustody(c.Request().Context(), c.Param("id"), c.Param("mediaId"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, entries)
+	}
+}
+
+// VerifyMedia checks that a stored media file was not altered
+// @Summary Verify the integrity of incident media
+// @Description Re-hash the stored file of a media and compare it with the SHA-256 hash recorded when it was uploaded. The status is intact when they match, tampered when they differ, missing when the file is gone from storage and unhashed for files uploaded before hashes were kept. The verification is recorded in the chain of custody.
+// @Tags media
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param mediaId path string true "Media ID"
+// @Success 200 {object} middleware.SuccessResponse{data=dto.MediaVerificationDto} "Verification outcome"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Media not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/media/{mediaId}/verify [post]
+func (h *MediaHandler) VerifyMedia() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		result, err := h.svc.VerifyMedia(c.Request().Context(), userID, c.Param("id"), c.Param("mediaId"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, result)
+	}
+}
+
+// DownloadMedia streams the file of a media
+// @Summary Download incident media
+// @Description Stream a media file from object storage. Range requests are supported so that video players can seek; If-Range and If-None-Match use the SHA-256 hash of the file as its ETag. A download is recorded in the chain of custody when a request starts at the beginning of the file, so a video fetched in ranges counts once. The files of deleted media can be downloaded until they are purged.
+// @Tags media
+// @Produce application/octet-stream
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param mediaId path string true "Media ID"
+// @Param Range header string false "Byte range to download, such as bytes=0-1048575"
+// @Success 200 {file} file "Media file"
+// @Success 206 {file} file "Requested range of the media file"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Media or file not found"
+// @Failure 416 "Range not satisfiable"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/media/{mediaId}/download [get]
+func (h *MediaHandler) DownloadMedia() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		media, file, info, err := h.svc.OpenMedia(c.Request().Context(), userID, c.Param("id"), c.Param("mediaId"), c.Request().Header.Get("Range"))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		header := c.Response().Header()
+		header.Set(echo.HeaderContentType, media.FileType)
+		header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("inline", map[string]string{"filename": path.Base(media.FileName)}))
+		if media.SHA256 != "" {
+			header.Set("ETag", `"`+media.SHA256+`"`)
+		} else if info.ETag != "" {
+			header.Set("ETag", `"`+info.ETag+`"`)
+		}
+		http.ServeContent(c.Response(), c.Request(), "", info.LastModified, file)
+		return nil
+	}
+}
+
+// GetMediaPage lists the media of an incident
+// @Summary List incident media
+// @Description List the media of an incident, oldest first, with short-lived download URLs of their files, thumbnails and previews. Deleted media are left out unless include_deleted is set. The listed media are recorded as viewed in their chain of custody.
+// @Tags media
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param media_type query string false "Filter by media type" Enums(image, video)
+// @Param step_id query string false "Filter by mission step"
+// @Param include_deleted query bool false "Include deleted media" default(false)
+// @Param limit query int false "Page size, 1 to 200" default(50)
+// @Param offset query int false "Number of media to skip" default(0)
+// @Success 200 {object} middleware.SuccessResponse{data=dto.MediaPageDto} "Page of media"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - invalid filter or pagination"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Incident not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/media [get]
+func (h *MediaHandler) GetMediaPage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		limit, offset, err := getPagination(c)
+		if err != nil {
+			return err
+		}
+		filter := repositories.IncidentMediaFilter{
+			IncidentID:     c.Param("id"),
+			MediaType:      c.QueryParam("media_type"),
+			StepID:         c.QueryParam("step_id"),
+			IncludeDeleted: c.QueryParam("include_deleted") == "true",
+			Limit:          limit,
+			Offset:         offset,
+		}
+		switch filter.MediaType {
+		case "", "image", "video":
+		default:
+			return echo.NewHTTPError(400, "media_type must be one of image, video")
+		}
+		if filter.StepID != "" {
+			if _, err := uuid.Parse(filter.StepID); err != nil {
+				return echo.NewHTTPError(400, "step_id must be a uuid")
+			}
+		}
+		page, err := h.svc.GetMediaPage(c.Request().Context(), userID, filter)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, page)
+	}
+}
+
+// DeleteMedia deletes a media uploaded by mistake
+// @Summary Delete incident media
+// @Description Remove a media uploaded by mistake from its incident, with the reason. The media is kept with who deleted it and why, no longer counts as step evidence and is left out of the timeline and of media listings unless deleted media are requested. Its files stay in storage until MEDIA_DELETED_RETENTION after the deletion, when they are purged. The deletion is recorded in the chain of custody.
+// @Tags media
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param mediaId path string true "Media ID"
+// @Param request body dto.DeleteMediaDto true "Delete media request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.IncidentMedia} "Deleted media"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Media not found"
+// @Failure 409 {object} errors.ErrorResponse "Media is already deleted"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/media/{mediaId} [delete]
+func (h *MediaHandler) DeleteMedia() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var deleteDto dto.DeleteMediaDto
+		if err := c.Bind(&deleteDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(deleteDto); err != nil {
+			return err
+		}
+		media, err := h.svc.DeleteMedia(c.Request().Context(), userID, c.Param("id"), c.Param("mediaId"), deleteDto.Reason)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, media)
+	}
+}
+
+// ExportMedia hands a media file out of the system
+// @Summary Export incident media
+// @Description Hand a media file out of the system, for example for a police report or an insurance claim. The export is recorded in the chain of custody with its recipient and reason, and the media is returned with a short-lived download URL and the SHA-256 hash the recipient can check the file against. With strip_metadata, a copy of a JPEG photo without its EXIF, XMP and IPTC metadata is handed out instead, and the download URL, size and hash are those of the copy.
+// @Tags media
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param mediaId path string true "Media ID"
+// @Param request body dto.ExportMediaDto true "Export media request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.IncidentMedia} "Exported media"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Media not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/incidents/{id}/media/{mediaId}/export [post]
+func (h *MediaHandler) ExportMedia() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var exportDto dto.ExportMediaDto
+		if err := c.Bind(&exportDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(exportDto); err != nil {
+			return err
+		}
+		media, err := h.svc.ExportMedia(c.Request().Context(), userID, c.Param("id"), c.Param("mediaId"), exportDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, media)
+	}
+}
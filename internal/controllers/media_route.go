@@ -0,0 +1,21 @@
+package http
+
+import (
+	middleware "scs-guard/internal/middlewares"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes registers the media routes on the incident group
+func (h *MediaHandler) RegisterRoutes(g *echo.Group, mw *middleware.MiddlewareManager) {
+	view := mw.RequirePermission(middleware.PermissionIncidentView)
+	manage := mw.RequirePermission(middleware.PermissionIncidentManage)
+
+	g.GET("/:id/media", h.GetMediaPage(), view)
+	g.GET("/:id/media/:mediaId", h.GetMedia(), view)
+	g.DELETE("/:id/media/:mediaId", h.DeleteMedia(), manage)
+	g.GET("/:id/media/:mediaId/custody", h.GetMediaCustody(), view)
+	g.POST("/:id/media/:mediaId/verify", h.VerifyMedia(), view)
+	g.GET("/:id/media/:mediaId/download", h.DownloadMedia(), view)
+	g.POST("/:id/media/:mediaId/export", h.ExportMedia(), manage)
+}
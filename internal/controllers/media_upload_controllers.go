@@ -0,0 +1,234 @@
+package http
+
+import (
+	"scs-guard/internal/dto"
+	"scs-guard/pkg/validation"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CreateMediaUpload issues a presigned URL to upload an incident media file
+// @Summary Get a presigned upload URL for incident media
+// @Description Get a short-lived presigned URL to upload an image or video of an incident directly to object storage, then confirm the upload. Set step_id to upload evidence for a step of the incident mission, and signature to upload the signature image a step requires. Upload the file with the returned method and headers; the Content-Type must match content_type.
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateMediaUploadDto true "Create media upload request"
+// @Success 201 {object} middleware.SuccessResponse{data=dto.MediaUploadDto} "Presigned upload URL"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error, incident not found or invalid file type"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - incident is not assigned to the user"
+// @Failure 404 {object} errors.ErrorResponse "Step not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/uploads [post]
+func (h *MissionHandler) CreateMediaUpload() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var createDto dto.CreateMediaUploadDto
+		if err := c.Bind(&createDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(createDto); err != nil {
+			return err
+		}
+		upload, err := h.svc.CreateMediaUpload(c.Request().Context(), userID, createDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, upload)
+	}
+}
+
+// ConfirmMediaUpload records an uploaded file as incident media
+// @Summary Confirm an incident media upload
+// @Description Check that the file of an upload is in object storage and record it as incident media. Files larger than the maximum size are deleted. The media is returned with a short-lived presigned download URL.
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Upload ID"
+// @Success 201 {object} middleware.SuccessResponse{data=models.IncidentMedia} "Recorded media"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - file missing, too large or of the wrong type"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - upload or incident is not the user's"
+// @Failure 404 {object} errors.ErrorResponse "Upload not found"
+// @Failure 409 {object} errors.ErrorResponse "Upload already confirmed or discarded"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/uploads/{id}/confirm [post]
+func (h *MissionHandler) ConfirmMediaUpload() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		media, err := h.svc.ConfirmMediaUpload(c.Request().Context(), userID, c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, media)
+	}
+}
+
+// InitiateResumableUpload starts an upload of a large file in parts
+// @Summary Start a resumable incident media upload
+// @Description Start uploading a large image or video of an incident in parts, so that a dropped connection only loses the part being sent. The file size is checked against the maximum size of its media type. Upload the parts, then complete the upload. Uploads without activity are discarded after the upload TTL.
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.InitiateResumableUploadDto true "Initiate resumable upload request"
+// @Success 201 {object} middleware.SuccessResponse{data=dto.ResumableUploadDto} "Started upload"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error, incident not found, invalid file type or file too large"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - incident is not assigned to the user"
+// @Failure 404 {object} errors.ErrorResponse "Step not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/uploads/resumable [post]
+func (h *MissionHandler) InitiateResumableUpload() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var initDto dto.InitiateResumableUploadDto
+		if err := c.Bind(&initDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(initDto); err != nil {
+			return err
+		}
+		upload, err := h.svc.InitiateResumableUpload(c.Request().Context(), userID, initDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, upload)
+	}
+}
+
+// GetResumableUpload returns the state of a resumable upload
+// @Summary Get a resumable incident media upload
+// @Description Get the parts of a resumable upload that were already received, to resume the upload after a dropped connection.
+// @Tags missions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Upload ID"
+// @Success 200 {object} middleware.SuccessResponse{data=dto.ResumableUploadDto} "Upload state"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - upload is not resumable"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - upload is not the user's"
+// @Failure 404 {object} errors.ErrorResponse "Upload not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/uploads/{id} [get]
+func (h *MissionHandler) GetResumableUpload() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		upload, err := h.svc.GetResumableUpload(c.Request().Context(), userID, c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, upload)
+	}
+}
+
+// UploadPart stores a part of a resumable upload
+// @Summary Upload a part of a resumable incident media upload
+// @Description Send one part of a resumable upload as the raw request body with its Content-Length. Every part has the part size of the upload except the last, which holds the rest of the file. Sending a part again replaces it.
+// @Tags missions
+// @Accept application/octet-stream
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Upload ID"
+// @Param number path int true "Part number, starting at 1"
+// @Success 200 {object} middleware.SuccessResponse{data=models.MediaUploadPart} "Stored part"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - invalid part number or size"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - upload is not the user's"
+// @Failure 404 {object} errors.ErrorResponse "Upload not found"
+// @Failure 409 {object} errors.ErrorResponse "Upload already completed or discarded"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/uploads/{id}/parts/{number} [put]
+func (h *MissionHandler) UploadPart() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		partNumber, err := strconv.Atoi(c.Param("number"))
+		if err != nil {
+			return echo.NewHTTPError(400, "part number must be an integer")
+		}
+		req := c.Request()
+		if req.ContentLength < 0 {
+			return echo.NewHTTPError(411, "Content-Length is required")
+		}
+		part, err := h.svc.UploadPart(req.Context(), userID, c.Param("id"), partNumber, req.Body, req.ContentLength)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, part)
+	}
+}
+
+// CompleteResumableUpload assembles a resumable upload and records it as incident media
+// @Summary Complete a resumable incident media upload
+// @Description Assemble the parts of a resumable upload once all of them were sent and record the file as incident media. The media is returned with a short-lived presigned download URL.
+// @Tags missions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Upload ID"
+// @Success 201 {object} middleware.SuccessResponse{data=models.IncidentMedia} "Recorded media"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - parts missing or upload is not resumable"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - upload or incident is not the user's"
+// @Failure 404 {object} errors.ErrorResponse "Upload not found"
+// @Failure 409 {object} errors.ErrorResponse "Upload already completed or discarded"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/uploads/{id}/complete [post]
+func (h *MissionHandler) CompleteResumableUpload() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		media, err := h.svc.CompleteResumableUpload(c.Request().Context(), userID, c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, media)
+	}
+}
+
+// AbortMediaUpload discards an upload
+// @Summary Abort an incident media upload
+// @Description Discard an upload that was not confirmed or completed yet, along with whatever was stored of its file.
+// @Tags missions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Upload ID"
+// @Success 200 {object} middleware.SuccessResponse{data=string} "Upload aborted"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - upload is not the user's"
+// @Failure 404 {object} errors.ErrorResponse "Upload not found"
+// @Failure 409 {object} errors.ErrorResponse "Upload already completed or discarded"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/uploads/{id} [delete]
+func (h *MissionHandler) AbortMediaUpload() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		if err := h.svc.AbortMediaUpload(c.Request().Context(), userID, c.Param("id")); err != nil {
+			return err
+		}
+		return c.JSON(200, "success")
+	}
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"scs-guard/internal/dto"
+	"scs-guard/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ReassignMission moves a mission to another guard
+// @Summary Reassign a mission
+// @Description Move a mission to another guard with a mandatory reason. The mission goes back to assigned and the change is recorded in its assignment history.
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Mission ID"
+// @Param request body dto.ReassignMissionDto true "Reassign mission request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.IncidentGuidance} "Reassigned mission"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error, mission not reassignable or assignee not a guard"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - role cannot assign missions"
+// @Failure 404 {object} errors.ErrorResponse "Mission or assignee not found"
+// @Failure 409 {object} errors.ErrorResponse "Mission status changed concurrently"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/{id}/reassign [patch]
+func (h *MissionHandler) ReassignMission() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var reassignMissionDto dto.ReassignMissionDto
+		if err := c.Bind(&reassignMissionDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(reassignMissionDto); err != nil {
+			return err
+		}
+		mission, err := h.svc.ReassignMission(c.Request().Context(), c.Param("id"), userID, reassignMissionDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, mission)
+	}
+}
+
+// GetAssignmentHistory retrieves the assignment history of a mission
+// @Summary Get mission assignment history
+// @Description Retrieve who a mission was assigned, reassigned and escalated to, when and why, oldest first
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Mission ID"
+// @Success 200 {object} middleware.SuccessResponse{data=[]models.MissionAssignmentHistory} "Assignment history"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - mission is not assigned to the guard"
+// @Failure 404 {object} errors.ErrorResponse "Mission not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/{id}/history [get]
+func (h *MissionHandler) GetAssignmentHistory() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		histories, err := h.svc.GetAssignmentHistory(c.Request().Context(), c.Param("id"), userID, getRole(c))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, histories)
+	}
+}
+
+// GetOverdueMissions lists overdue work
+// @Summary Get overdue missions
+// @Description List unfinished missions that missed their deadline or have an incomplete step that missed its deadline
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} middleware.SuccessResponse{data=[]models.IncidentGuidance} "Overdue missions"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - role cannot assign missions"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/overdue [get]
+func (h *MissionHandler) GetOverdueMissions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		missions, err := h.svc.GetOverdueMissions(c.Request().Context())
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, missions)
+	}
+}
@@ -21,6 +21,43 @@ func NewMissionHandler(svc services.MissionService) *MissionHandler {
 	return &MissionHandler{svc: svc}
 }
 
+// AssignMission creates a mission from a guidance template and assigns it to a guard
+// @Summary Assign a mission
+// @Description Instantiate a guidance template for an incident and assign it to a guard. The authenticated user is recorded as the assigner.
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.AssignMissionDto true "Assign mission request"
+// @Success 201 {object} middleware.SuccessResponse{data=models.IncidentGuidance} "Assigned mission"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error, template not current or assignee not a guard"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - role cannot assign missions"
+// @Failure 404 {object} errors.ErrorResponse "Incident, template or assignee not found"
+// @Failure 409 {object} errors.ErrorResponse "The incident already has an unfinished mission, or a mission for the template"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions [post]
+func (h *MissionHandler) AssignMission() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var assignMissionDto dto.AssignMissionDto
+		if err := c.Bind(&assignMissionDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(assignMissionDto); err != nil {
+			return err
+		}
+		mission, err := h.svc.AssignMission(c.Request().Context(), userID, assignMissionDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, mission)
+	}
+}
+
 // GetAssignments retrieves mission assignments for a user
 // @Summary Get user mission assignments
 // @Description Retrieve all mission assignments for the authenticated user
@@ -32,8 +69,12 @@ func NewMissionHandler(svc services.MissionService) *MissionHandler {
 // @Failure 401 {object} errors.ErrorResponse "Unauthorized"
 // @Failure 500 {object} errors.ErrorResponse "Internal server error"
 // @Router /api/v1/missions/me [get]
-func (h *MissionHandler) GetAssignments(userID string) echo.HandlerFunc {
+func (h *MissionHandler) GetAssignments() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
 		assignments, err := h.svc.GetAssignments(c.Request().Context(), userID)
 		if err != nil {
 			return err
@@ -44,20 +85,25 @@ func (h *MissionHandler) GetAssignments(userID string) echo.HandlerFunc {
 
 // CompleteStep marks a mission step as completed
 // @Summary Complete a mission step
-// @Description Mark a specific step in a mission guidance as completed
+// @Description Mark a specific step in a mission guidance as completed. An optional note is left as a comment on the step. Steps with evidence requirements are rejected with a VALIDATION_ERROR whose details list every missing piece of evidence. Steps asking a question must be answered with one of its options; the answer activates or skips the steps depending on it, and pending or skipped steps cannot be completed. On missions with strict ordering every earlier step must be completed or skipped first.
 // @Tags missions
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body dto.CompleteMissionDto true "Complete mission request"
 // @Success 200 {object} middleware.SuccessResponse{data=string} "Step completed successfully"
-// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error, missing evidence, missing answer, step not active or out of order"
 // @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - mission is not assigned to the user"
 // @Failure 404 {object} errors.ErrorResponse "Mission or step not found"
 // @Failure 500 {object} errors.ErrorResponse "Internal server error"
 // @Router /api/v1/missions/complete [patch]
 func (h *MissionHandler) CompleteStep() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
 		var completeMissionDto dto.CompleteMissionDto
 		if err := c.Bind(&completeMissionDto); err != nil {
 			return err
@@ -66,7 +112,7 @@ func (h *MissionHandler) CompleteStep() echo.HandlerFunc {
 			return err
 		}
 
-		err := h.svc.CompleteStep(c.Request().Context(), completeMissionDto)
+		err = h.svc.CompleteStep(c.Request().Context(), userID, completeMissionDto)
 		if err != nil {
 			return err
 		}
@@ -76,20 +122,28 @@ func (h *MissionHandler) CompleteStep() echo.HandlerFunc {
 
 // UpdateIncidentInfo uploads media files for an incident
 // @Summary Upload incident media files
-// @Description Upload image or video files to document an incident
+// @Description Upload image or video files to document an incident. Set step_id to upload evidence for a step of the incident mission, and signature to upload the signature image a step requires. The files are uploaded all or none: when one cannot be stored, the files already stored are removed again.
 // @Tags missions
 // @Accept multipart/form-data
 // @Produce json
 // @Security BearerAuth
 // @Param incident_id formData string true "Incident ID"
-// @Param files formData file true "Media files (images or videos, max 10MB each)"
+// @Param step_id formData string false "Mission step the files are evidence for"
+// @Param signature formData bool false "The files are the signature of the step"
+// @Param files formData file true "Media files (images or videos, up to the configured maximum size of their media type)"
 // @Success 200 {object} middleware.SuccessResponse{data=string} "Files uploaded successfully"
 // @Failure 400 {object} errors.ErrorResponse "Bad request - invalid file type or size"
 // @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - incident is not assigned to the user"
+// @Failure 404 {object} errors.ErrorResponse "Step not found"
 // @Failure 500 {object} errors.ErrorResponse "Internal server error"
 // @Router /api/v1/missions/update [put]
 func (h *MissionHandler) UpdateIncidentInfo() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
 		form, err := c.MultipartForm()
 		if err != nil {
 			return err
@@ -97,16 +151,12 @@ func (h *MissionHandler) UpdateIncidentInfo() echo.HandlerFunc {
 		fileHeaders := form.File["files"]
 		incidentID := form.Value["incident_id"]
 
-		const maxSize = 10 * 1024 * 1024 // 10MB
 		allowedTypes := map[string]bool{
 			"image/": true,
 			"video/": true,
 		}
 		var validFiles []map[string]interface{}
 		for _, fileHeader := range fileHeaders {
-			if fileHeader.Size > maxSize {
-				return echo.NewHTTPError(400, "file size exceeds 10MB")
-			}
 			file, err := fileHeader.Open()
 			if err != nil {
 				return echo.NewHTTPError(400, "cannot open file")
@@ -132,12 +182,14 @@ func (h *MissionHandler) UpdateIncidentInfo() echo.HandlerFunc {
 			validFiles = append(validFiles, map[string]interface{}{
 				"file":      file,
 				"mime_type": mimeType,
-				"file_name": incidentID[0] + "/" + fileHeader.Filename,
+				"file_name": fileHeader.Filename,
 				"file_size": fileHeader.Size,
 			})
 
 		}
-		err = h.svc.UpdateIncidentInfo(c.Request().Context(), incidentID[0], validFiles)
+		stepID := c.FormValue("step_id")
+		signature := c.FormValue("signature") == "true"
+		err = h.svc.UpdateIncidentInfo(c.Request().Context(), userID, incidentID[0], stepID, signature, validFiles)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,230 @@
+package http
+
+import (
+	"scs-guard/internal/dto"
+	"scs-guard/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetAssignedMissions retrieves the missions assigned by the authenticated user
+// @Summary Get missions assigned by the user
+// @Description Retrieve the missions the authenticated user has assigned, including their status and decline reasons
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} middleware.SuccessResponse{data=[]models.IncidentGuidance} "List of assigned missions"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - role cannot assign missions"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/assigned [get]
+func (h *MissionHandler) GetAssignedMissions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		missions, err := h.svc.GetAssignedMissions(c.Request().Context(), userID)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, missions)
+	}
+}
+
+// AcceptMission accepts an assigned mission
+// @Summary Accept a mission
+// @Description Accept a mission assigned to the authenticated user
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Mission ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.IncidentGuidance} "Accepted mission"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - invalid status transition"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Mission is not assigned to the user"
+// @Failure 404 {object} errors.ErrorResponse "Mission not found"
+// @Failure 409 {object} errors.ErrorResponse "Mission status changed concurrently"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/{id}/accept [patch]
+func (h *MissionHandler) AcceptMission() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		mission, err := h.svc.AcceptMission(c.Request().Context(), c.Param("id"), userID)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, mission)
+	}
+}
+
+// DeclineMission declines an assigned mission
+// @Summary Decline a mission
+// @Description Decline a mission assigned to the authenticated user with a reason visible to the assigner
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Mission ID"
+// @Param request body dto.DeclineMissionDto true "Decline mission request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.IncidentGuidance} "Declined mission"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error or invalid status transition"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Mission is not assigned to the user"
+// @Failure 404 {object} errors.ErrorResponse "Mission not found"
+// @Failure 409 {object} errors.ErrorResponse "Mission status changed concurrently"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/{id}/decline [patch]
+func (h *MissionHandler) DeclineMission() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var declineMissionDto dto.DeclineMissionDto
+		if err := c.Bind(&declineMissionDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(declineMissionDto); err != nil {
+			return err
+		}
+		mission, err := h.svc.DeclineMission(c.Request().Context(), c.Param("id"), userID, declineMissionDto.Reason)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, mission)
+	}
+}
+
+// StartMission starts or resumes a mission
+// @Summary Start a mission
+// @Description Start an accepted mission or resume a paused one
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Mission ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.IncidentGuidance} "Started mission"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - invalid status transition"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Mission is not assigned to the user"
+// @Failure 404 {object} errors.ErrorResponse "Mission not found"
+// @Failure 409 {object} errors.ErrorResponse "Mission status changed concurrently"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/{id}/start [patch]
+func (h *MissionHandler) StartMission() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		mission, err := h.svc.StartMission(c.Request().Context(), c.Param("id"), userID)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, mission)
+	}
+}
+
+// PauseMission pauses a mission in progress
+// @Summary Pause a mission
+// @Description Pause a mission that is in progress
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Mission ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.IncidentGuidance} "Paused mission"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - invalid status transition"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Mission is not assigned to the user"
+// @Failure 404 {object} errors.ErrorResponse "Mission not found"
+// @Failure 409 {object} errors.ErrorResponse "Mission status changed concurrently"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/{id}/pause [patch]
+func (h *MissionHandler) PauseMission() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		mission, err := h.svc.PauseMission(c.Request().Context(), c.Param("id"), userID)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, mission)
+	}
+}
+
+// FinishMission completes a mission
+// @Summary Finish a mission
+// @Description Complete a mission in progress whose steps are all completed
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Mission ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.IncidentGuidance} "Completed mission"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - invalid status transition or incomplete steps"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Mission is not assigned to the user"
+// @Failure 404 {object} errors.ErrorResponse "Mission not found"
+// @Failure 409 {object} errors.ErrorResponse "Mission status changed concurrently"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/{id}/finish [patch]
+func (h *MissionHandler) FinishMission() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		mission, err := h.svc.FinishMission(c.Request().Context(), c.Param("id"), userID)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, mission)
+	}
+}
+
+// AbortMission aborts a mission
+// @Summary Abort a mission
+// @Description Abort a mission that has not finished yet. Any operator or admin can abort a mission, including missions dispatched automatically.
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Mission ID"
+// @Param request body dto.AbortMissionDto true "Abort mission request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.IncidentGuidance} "Aborted mission"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error or invalid status transition"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - role cannot assign missions"
+// @Failure 404 {object} errors.ErrorResponse "Mission not found"
+// @Failure 409 {object} errors.ErrorResponse "Mission status changed concurrently"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/{id}/abort [patch]
+func (h *MissionHandler) AbortMission() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var abortMissionDto dto.AbortMissionDto
+		if err := c.Bind(&abortMissionDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(abortMissionDto); err != nil {
+			return err
+		}
+		mission, err := h.svc.AbortMission(c.Request().Context(), c.Param("id"), userID, abortMissionDto.Reason)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, mission)
+	}
+}
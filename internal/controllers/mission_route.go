@@ -1,20 +1,38 @@
 package http
 
 import (
-	"fmt"
+	middleware "scs-guard/internal/middlewares"
 
 	"github.com/labstack/echo/v4"
 )
 
-func (h *MissionHandler) RegisterRoutes(g *echo.Group) {
-	g.PATCH("/complete", h.CompleteStep())
-	g.PUT("/update", h.UpdateIncidentInfo())
-	g.GET("/me", func(c echo.Context) error {
-		userID, ok := c.Get("user_id").(string)
-		fmt.Println(userID)
-		if !ok {
-			return echo.NewHTTPError(401, "user_id not found in context")
-		}
-		return h.GetAssignments(userID)(c)
-	})
+func (h *MissionHandler) RegisterRoutes(g *echo.Group, mw *middleware.MiddlewareManager) {
+	view := mw.RequirePermission(middleware.PermissionMissionView)
+	execute := mw.RequirePermission(middleware.PermissionMissionExecute)
+	assign := mw.RequirePermission(middleware.PermissionMissionAssign)
+
+	g.POST("", h.AssignMission(), assign)
+	g.PATCH("/complete", h.CompleteStep(), execute)
+	g.PUT("/update", h.UpdateIncidentInfo(), execute)
+	g.POST("/uploads", h.CreateMediaUpload(), execute)
+	g.POST("/uploads/:id/confirm", h.ConfirmMediaUpload(), execute)
+	g.POST("/uploads/resumable", h.InitiateResumableUpload(), execute)
+	g.GET("/uploads/:id", h.GetResumableUpload(), execute)
+	g.PUT("/uploads/:id/parts/:number", h.UploadPart(), execute)
+	g.POST("/uploads/:id/complete", h.CompleteResumableUpload(), execute)
+	g.DELETE("/uploads/:id", h.AbortMediaUpload(), execute)
+	g.GET("/assigned", h.GetAssignedMissions(), assign)
+	g.GET("/overdue", h.GetOverdueMissions(), assign)
+	g.PATCH("/:id/accept", h.AcceptMission(), execute)
+	g.PATCH("/:id/decline", h.DeclineMission(), execute)
+	g.PATCH("/:id/start", h.StartMission(), execute)
+	g.PATCH("/:id/pause", h.PauseMission(), execute)
+	g.PATCH("/:id/finish", h.FinishMission(), execute)
+	g.PATCH("/:id/abort", h.AbortMission(), assign)
+	g.PATCH("/:id/reassign", h.ReassignMission(), assign)
+	g.GET("/:id/history", h.GetAssignmentHistory(), view)
+	g.PATCH("/:id/steps/:stepId/skip", h.SkipStep(), execute)
+	g.PATCH("/:id/steps/:stepId/undo", h.UndoStepCompletion(), execute)
+	g.GET("/:id/steps/history", h.GetStepHistory(), view)
+	g.GET("/me", h.GetAssignments(), view)
 }
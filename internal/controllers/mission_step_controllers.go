@@ -0,0 +1,114 @@
+package http
+
+import (
+	"scs-guard/internal/dto"
+	"scs-guard/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SkipStep skips an optional mission step
+// @Summary Skip a mission step
+// @Description Skip an optional step of a mission assigned to the authenticated user, giving a reason. On missions with strict ordering every earlier step must be completed or skipped first. Skipping a question step skips the steps depending on it.
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Mission ID"
+// @Param stepId path string true "Step ID"
+// @Param request body dto.SkipStepDto true "Skip step request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.IncidentGuidance} "Mission with the step skipped"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error, mandatory step, step not active or out of order"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Mission is not assigned to the user"
+// @Failure 404 {object} errors.ErrorResponse "Mission not found"
+// @Failure 409 {object} errors.ErrorResponse "Step changed concurrently"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/{id}/steps/{stepId}/skip [patch]
+func (h *MissionHandler) SkipStep() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var skipStepDto dto.SkipStepDto
+		if err := c.Bind(&skipStepDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(skipStepDto); err != nil {
+			return err
+		}
+		mission, err := h.svc.SkipStep(c.Request().Context(), c.Param("id"), c.Param("stepId"), userID, skipStepDto.Reason)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, mission)
+	}
+}
+
+// UndoStepCompletion reverts a step completion
+// @Summary Undo a step completion
+// @Description Undo the completion of a step of a mission assigned to the authenticated user within the grace period set by STEP_UNDO_GRACE_PERIOD. The completion is kept in the step history. On missions with strict ordering later steps must be undone first, and steps depending on the answer to the step become pending again.
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Mission ID"
+// @Param stepId path string true "Step ID"
+// @Param request body dto.UndoStepCompletionDto false "Undo step completion request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.IncidentGuidance} "Mission with the step no longer completed"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - step not completed, grace period passed or later steps done"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Mission is not assigned to the user"
+// @Failure 404 {object} errors.ErrorResponse "Mission or step not found"
+// @Failure 409 {object} errors.ErrorResponse "Step changed concurrently"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/{id}/steps/{stepId}/undo [patch]
+func (h *MissionHandler) UndoStepCompletion() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var undoDto dto.UndoStepCompletionDto
+		if err := c.Bind(&undoDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(undoDto); err != nil {
+			return err
+		}
+		mission, err := h.svc.UndoStepCompletion(c.Request().Context(), c.Param("id"), c.Param("stepId"), userID, undoDto.Reason)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, mission)
+	}
+}
+
+// GetStepHistory retrieves the step history of a mission
+// @Summary Get mission step history
+// @Description Retrieve who completed and skipped the steps of a mission and which completions were undone, when and why, oldest first
+// @Tags missions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Mission ID"
+// @Success 200 {object} middleware.SuccessResponse{data=[]models.StepHistory} "Step history"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - mission is not assigned to the guard"
+// @Failure 404 {object} errors.ErrorResponse "Mission not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/missions/{id}/steps/history [get]
+func (h *MissionHandler) GetStepHistory() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		histories, err := h.svc.GetStepHistory(c.Request().Context(), c.Param("id"), userID, getRole(c))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, histories)
+	}
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"net/http"
+	"scs-guard/pkg/errors"
+	"scs-guard/pkg/storage"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// StorageHandler serves the presigned URLs of local storage, which the API handles in place of an
+// object storage service
+// @Description Storage handler for presigned URLs of the local filesystem storage backend
+type StorageHandler struct {
+	storage *storage.LocalStorage
+}
+
+// NewStorageHandler constructor
+func NewStorageHandler(storage *storage.LocalStorage) *StorageHandler {
+	return &StorageHandler{storage: storage}
+}
+
+// Download serves a stored file through a presigned URL
+// @Summary Download a file with a presigned URL
+// @Description Download a file stored by the local storage backend. The URL is handed out presigned by other endpoints; Range requests are supported.
+// @Tags storage
+// @Produce octet-stream
+// @Param key path string true "Object key"
+// @Param expires query int true "Expiry of the URL as a Unix timestamp"
+// @Param signature query string true "Signature of the URL"
+// @Success 200 {file} file "File content"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - invalid or expired signature"
+// @Failure 404 {object} errors.ErrorResponse "File not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/storage/{key} [get]
+func (h *StorageHandler) Download() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key, err := h.verify(c, http.MethodGet)
+		if err != nil {
+			return err
+		}
+		file, info, err := h.storage.Get(c.Request().Context(), key)
+		if err != nil {
+			if storage.IsNotFound(err) {
+				return errors.NewNotFoundError("file")
+			}
+			return errors.NewInternalError("failed to read the file", err)
+		}
+		defer file.Close()
+		c.Response().Header().Set(echo.HeaderContentType, info.ContentType)
+		if info.ETag != "" {
+			c.Response().Header().Set("ETag", `"`+info.ETag+`"`)
+		}
+		http.ServeContent(c.Response(), c.Request(), "", info.LastModified, file)
+		return nil
+	}
+}
+
+// Upload stores a file sent to a presigned URL
+// @Summary Upload a file with a presigned URL
+// @Description Upload a file to the local storage backend as the raw request body. The URL is handed out presigned by other endpoints; the Content-Type header is stored with the file.
+// @Tags storage
+// @Accept octet-stream
+// @Param key path string true "Object key"
+// @Param expires query int true "Expiry of the URL as a Unix timestamp"
+// @Param signature query string true "Signature of the URL"
+// @Success 200 "File stored"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden - invalid or expired signature"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/storage/{key} [put]
+func (h *StorageHandler) Upload() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key, err := h.verify(c, http.MethodPut)
+		if err != nil {
+			return err
+		}
+		req := c.Request()
+		info, err := h.storage.Put(req.Context(), key, req.Body, req.ContentLength, req.Header.Get(echo.HeaderContentType))
+		if err != nil {
+			return errors.NewInternalError("failed to store the file", err)
+		}
+		c.Response().Header().Set("ETag", `"`+info.ETag+`"`)
+		return c.NoContent(200)
+	}
+}
+
+// verify checks the signature of a presigned URL for method and returns the key it points at
+func (h *StorageHandler) verify(c echo.Context, method string) (string, error) {
+	// The decoded path is used rather than the route parameter, which may still be escaped
+	key := strings.TrimPrefix(c.Request().URL.Path, storage.LocalSignedPath)
+	if err := h.storage.VerifySignature(method, key, c.QueryParam("expires"), c.QueryParam("signature")); err != nil {
+		return "", errors.NewForbiddenError(err.Error())
+	}
+	return key, nil
+}
@@ -0,0 +1,14 @@
+package http
+
+import (
+	middleware "scs-guard/internal/middlewares"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes registers the routes of presigned URLs. The signature of the URL authorizes the
+// request, so they take no JWT.
+func (h *StorageHandler) RegisterRoutes(g *echo.Group, mw *middleware.MiddlewareManager) {
+	g.GET("/*", h.Download())
+	g.PUT("/*", h.Upload())
+}
@@ -0,0 +1,351 @@
+package http
+
+import (
+	"scs-guard/internal/dto"
+	repositories "scs-guard/internal/repositories"
+	services "scs-guard/internal/services"
+	"scs-guard/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TemplateHandler handles guidance template HTTP requests
+// @Description Template handler for managing versioned guidance templates and their steps
+type TemplateHandler struct {
+	svc services.TemplateService
+}
+
+// NewTemplateHandler constructor
+func NewTemplateHandler(svc services.TemplateService) *TemplateHandler {
+	return &TemplateHandler{svc: svc}
+}
+
+// CreateTemplate creates a guidance template
+// @Summary Create a guidance template
+// @Description Create a guidance template with its ordered steps as version 1
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateGuidanceTemplateDto true "Create template request"
+// @Success 201 {object} middleware.SuccessResponse{data=models.GuidanceTemplate} "Created template"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/templates [post]
+func (h *TemplateHandler) CreateTemplate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var createTemplateDto dto.CreateGuidanceTemplateDto
+		if err := c.Bind(&createTemplateDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(createTemplateDto); err != nil {
+			return err
+		}
+		template, err := h.svc.CreateTemplate(c.Request().Context(), createTemplateDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, template)
+	}
+}
+
+// GetTemplates lists the current version of every guidance template
+// @Summary List guidance templates
+// @Description List the current version of every guidance template
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param category query string false "Filter by category"
+// @Param include_archived query bool false "Include archived templates"
+// @Success 200 {object} middleware.SuccessResponse{data=[]models.GuidanceTemplate} "List of templates"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/templates [get]
+func (h *TemplateHandler) GetTemplates() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		filter := repositories.GuidanceTemplateFilter{
+			Category:        c.QueryParam("category"),
+			IncludeArchived: c.QueryParam("include_archived") == "true",
+		}
+		templates, err := h.svc.GetTemplates(c.Request().Context(), filter)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, templates)
+	}
+}
+
+// GetTemplate retrieves a guidance template version
+// @Summary Get a guidance template
+// @Description Retrieve a guidance template version with its ordered steps
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.GuidanceTemplate} "Template"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Template not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/templates/{id} [get]
+func (h *TemplateHandler) GetTemplate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		template, err := h.svc.GetTemplate(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, template)
+	}
+}
+
+// GetTemplateVersions lists every version of a guidance template
+// @Summary List guidance template versions
+// @Description List every version of the template the given version belongs to, newest first
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Success 200 {object} middleware.SuccessResponse{data=[]models.GuidanceTemplate} "Template versions"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Template not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/templates/{id}/versions [get]
+func (h *TemplateHandler) GetTemplateVersions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		versions, err := h.svc.GetTemplateVersions(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, versions)
+	}
+}
+
+// UpdateTemplate edits a guidance template
+// @Summary Edit a guidance template
+// @Description Replace the details and steps of a template. The edit is stored as a new version; existing missions keep the version they were created from.
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param request body dto.UpdateGuidanceTemplateDto true "Update template request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.GuidanceTemplate} "New template version"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error or archived template"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Template not found"
+// @Failure 409 {object} errors.ErrorResponse "Template version is not the latest"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/templates/{id} [put]
+func (h *TemplateHandler) UpdateTemplate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var updateTemplateDto dto.UpdateGuidanceTemplateDto
+		if err := c.Bind(&updateTemplateDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(updateTemplateDto); err != nil {
+			return err
+		}
+		template, err := h.svc.UpdateTemplate(c.Request().Context(), c.Param("id"), updateTemplateDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, template)
+	}
+}
+
+// AddStep adds a step to a guidance template
+// @Summary Add a template step
+// @Description Insert a step at the given position, creating a new template version
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param request body dto.AddGuidanceStepDto true "Add step request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.GuidanceTemplate} "New template version"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error or archived template"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Template not found"
+// @Failure 409 {object} errors.ErrorResponse "Template version is not the latest"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/templates/{id}/steps [post]
+func (h *TemplateHandler) AddStep() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var addStepDto dto.AddGuidanceStepDto
+		if err := c.Bind(&addStepDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(addStepDto); err != nil {
+			return err
+		}
+		template, err := h.svc.AddStep(c.Request().Context(), c.Param("id"), addStepDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, template)
+	}
+}
+
+// UpdateStep edits a step of a guidance template
+// @Summary Edit a template step
+// @Description Edit a step, creating a new template version
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param stepId path string true "Step ID"
+// @Param request body dto.GuidanceStepDto true "Update step request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.GuidanceTemplate} "New template version"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error or archived template"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Template or step not found"
+// @Failure 409 {object} errors.ErrorResponse "Template version is not the latest"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/templates/{id}/steps/{stepId} [put]
+func (h *TemplateHandler) UpdateStep() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var updateStepDto dto.GuidanceStepDto
+		if err := c.Bind(&updateStepDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(updateStepDto); err != nil {
+			return err
+		}
+		template, err := h.svc.UpdateStep(c.Request().Context(), c.Param("id"), c.Param("stepId"), updateStepDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, template)
+	}
+}
+
+// DeleteStep removes a step from a guidance template
+// @Summary Delete a template step
+// @Description Remove a step, creating a new template version
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param stepId path string true "Step ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.GuidanceTemplate} "New template version"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - archived template"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Template or step not found"
+// @Failure 409 {object} errors.ErrorResponse "Template version is not the latest"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/templates/{id}/steps/{stepId} [delete]
+func (h *TemplateHandler) DeleteStep() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		template, err := h.svc.DeleteStep(c.Request().Context(), c.Param("id"), c.Param("stepId"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, template)
+	}
+}
+
+// ReorderSteps changes the order of the steps of a guidance template
+// @Summary Reorder template steps
+// @Description Reorder every step of a template, creating a new template version
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param request body dto.ReorderGuidanceStepsDto true "Reorder steps request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.GuidanceTemplate} "New template version"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error or archived template"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Template or step not found"
+// @Failure 409 {object} errors.ErrorResponse "Template version is not the latest"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/templates/{id}/steps/order [put]
+func (h *TemplateHandler) ReorderSteps() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var reorderStepsDto dto.ReorderGuidanceStepsDto
+		if err := c.Bind(&reorderStepsDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(reorderStepsDto); err != nil {
+			return err
+		}
+		template, err := h.svc.ReorderSteps(c.Request().Context(), c.Param("id"), reorderStepsDto.StepIDs)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, template)
+	}
+}
+
+// DuplicateTemplate duplicates a guidance template
+// @Summary Duplicate a guidance template
+// @Description Copy a template version into a new template with its own version history
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param request body dto.DuplicateGuidanceTemplateDto false "Duplicate template request"
+// @Success 201 {object} middleware.SuccessResponse{data=models.GuidanceTemplate} "Duplicated template"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Template not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/templates/{id}/duplicate [post]
+func (h *TemplateHandler) DuplicateTemplate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var duplicateTemplateDto dto.DuplicateGuidanceTemplateDto
+		if err := c.Bind(&duplicateTemplateDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(duplicateTemplateDto); err != nil {
+			return err
+		}
+		template, err := h.svc.DuplicateTemplate(c.Request().Context(), c.Param("id"), duplicateTemplateDto.Name)
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, template)
+	}
+}
+
+// ArchiveTemplate archives a guidance template
+// @Summary Archive a guidance template
+// @Description Archive the current version of a template so it can no longer be assigned or edited
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.GuidanceTemplate} "Archived template"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - template is not the current version"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Template not found"
+// @Failure 409 {object} errors.ErrorResponse "Template changed concurrently"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/templates/{id}/archive [patch]
+func (h *TemplateHandler) ArchiveTemplate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		template, err := h.svc.ArchiveTemplate(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, template)
+	}
+}
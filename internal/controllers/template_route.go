@@ -0,0 +1,24 @@
+package http
+
+import (
+	middleware "scs-guard/internal/middlewares"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *TemplateHandler) RegisterRoutes(g *echo.Group, mw *middleware.MiddlewareManager) {
+	view := mw.RequirePermission(middleware.PermissionMissionView)
+	manage := mw.RequirePermission(middleware.PermissionTemplateManage)
+
+	g.GET("", h.GetTemplates(), view)
+	g.POST("", h.CreateTemplate(), manage)
+	g.GET("/:id", h.GetTemplate(), view)
+	g.PUT("/:id", h.UpdateTemplate(), manage)
+	g.GET("/:id/versions", h.GetTemplateVersions(), view)
+	g.POST("/:id/duplicate", h.DuplicateTemplate(), manage)
+	g.PATCH("/:id/archive", h.ArchiveTemplate(), manage)
+	g.POST("/:id/steps", h.AddStep(), manage)
+	g.PUT("/:id/steps/order", h.ReorderSteps(), manage)
+	g.PUT("/:id/steps/:stepId", h.UpdateStep(), manage)
+	g.DELETE("/:id/steps/:stepId", h.DeleteStep(), manage)
+}
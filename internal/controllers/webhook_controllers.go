@@ -0,0 +1,221 @@
+package http
+
+import (
+	"scs-guard/internal/dto"
+	repositories "scs-guard/internal/repositories"
+	services "scs-guard/internal/services"
+	"scs-guard/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WebhookHandler handles webhook subscription HTTP requests
+// @Description Webhook handler for managing subscriptions and reading their delivery log
+type WebhookHandler struct {
+	svc services.WebhookService
+}
+
+// NewWebhookHandler constructor
+func NewWebhookHandler(svc services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{svc: svc}
+}
+
+// CreateSubscription subscribes a URL to mission events
+// @Summary Create a webhook subscription
+// @Description Subscribe a URL to mission events. Deliveries are signed with the secret, which is generated when omitted and only returned by this call.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateWebhookSubscriptionDto true "Create subscription request"
+// @Success 201 {object} middleware.SuccessResponse{data=dto.WebhookSubscriptionSecretDto} "Created subscription with its secret"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/webhooks [post]
+func (h *WebhookHandler) CreateSubscription() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := getUserID(c)
+		if err != nil {
+			return err
+		}
+		var createDto dto.CreateWebhookSubscriptionDto
+		if err := c.Bind(&createDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(createDto); err != nil {
+			return err
+		}
+		subscription, err := h.svc.CreateSubscription(c.Request().Context(), userID, createDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(201, subscription)
+	}
+}
+
+// GetSubscriptions lists webhook subscriptions
+// @Summary List webhook subscriptions
+// @Description List every webhook subscription; secrets are not returned
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} middleware.SuccessResponse{data=[]models.WebhookSubscription} "Subscriptions"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/webhooks [get]
+func (h *WebhookHandler) GetSubscriptions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		subscriptions, err := h.svc.GetSubscriptions(c.Request().Context())
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, subscriptions)
+	}
+}
+
+// GetSubscription retrieves a webhook subscription
+// @Summary Get a webhook subscription
+// @Description Retrieve a webhook subscription; the secret is not returned
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.WebhookSubscription} "Subscription"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Subscription not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/webhooks/{id} [get]
+func (h *WebhookHandler) GetSubscription() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		subscription, err := h.svc.GetSubscription(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, subscription)
+	}
+}
+
+// UpdateSubscription edits a webhook subscription
+// @Summary Update a webhook subscription
+// @Description Edit the URL, event filter and active flag of a subscription, rotating its secret when one is given
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param request body dto.UpdateWebhookSubscriptionDto true "Update subscription request"
+// @Success 200 {object} middleware.SuccessResponse{data=models.WebhookSubscription} "Updated subscription"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - validation error"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Subscription not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateSubscription() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var updateDto dto.UpdateWebhookSubscriptionDto
+		if err := c.Bind(&updateDto); err != nil {
+			return err
+		}
+		if err := validation.ValidateStruct(updateDto); err != nil {
+			return err
+		}
+		subscription, err := h.svc.UpdateSubscription(c.Request().Context(), c.Param("id"), updateDto)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, subscription)
+	}
+}
+
+// DeleteSubscription removes a webhook subscription
+// @Summary Delete a webhook subscription
+// @Description Delete a webhook subscription together with its delivery log
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} middleware.SuccessResponse{data=string} "Subscription deleted"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Subscription not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteSubscription() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := h.svc.DeleteSubscription(c.Request().Context(), c.Param("id")); err != nil {
+			return err
+		}
+		return c.JSON(200, "success")
+	}
+}
+
+// GetDeliveries lists the delivery log of a webhook subscription
+// @Summary Get webhook deliveries
+// @Description List the deliveries of a subscription, newest first, with their attempts, last response status and error
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param status query string false "Filter by delivery status" Enums(pending, delivered, dead)
+// @Param limit query int false "Page size, 1 to 200" default(50)
+// @Param offset query int false "Number of deliveries to skip" default(0)
+// @Success 200 {object} middleware.SuccessResponse{data=dto.WebhookDeliveryPageDto} "Page of deliveries"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - invalid pagination"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Subscription not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetDeliveries() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		limit, offset, err := getPagination(c)
+		if err != nil {
+			return err
+		}
+		filter := repositories.WebhookDeliveryFilter{
+			Status: c.QueryParam("status"),
+			Limit:  limit,
+			Offset: offset,
+		}
+		page, err := h.svc.GetDeliveries(c.Request().Context(), c.Param("id"), filter)
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, page)
+	}
+}
+
+// RetryDelivery queues a dead-lettered delivery again
+// @Summary Retry a dead webhook delivery
+// @Description Queue a dead-lettered delivery again with a fresh set of attempts
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 200 {object} middleware.SuccessResponse{data=models.WebhookDelivery} "Queued delivery"
+// @Failure 400 {object} errors.ErrorResponse "Bad request - delivery is not dead"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Delivery not found"
+// @Failure 500 {object} errors.ErrorResponse "Internal server error"
+// @Router /api/v1/webhooks/{id}/deliveries/{deliveryId}/retry [post]
+func (h *WebhookHandler) RetryDelivery() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		delivery, err := h.svc.RetryDelivery(c.Request().Context(), c.Param("id"), c.Param("deliveryId"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(200, delivery)
+	}
+}
@@ -0,0 +1,19 @@
+package http
+
+import (
+	middleware "scs-guard/internal/middlewares"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *WebhookHandler) RegisterRoutes(g *echo.Group, mw *middleware.MiddlewareManager) {
+	manage := mw.RequirePermission(middleware.PermissionWebhookManage)
+
+	g.POST("", h.CreateSubscription(), manage)
+	g.GET("", h.GetSubscriptions(), manage)
+	g.GET("/:id", h.GetSubscription(), manage)
+	g.PUT("/:id", h.UpdateSubscription(), manage)
+	g.DELETE("/:id", h.DeleteSubscription(), manage)
+	g.GET("/:id/deliveries", h.GetDeliveries(), manage)
+	g.POST("/:id/deliveries/:deliveryId/retry", h.RetryDelivery(), manage)
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"scs-guard/internal/models"
+	"time"
+)
+
+// IngestAlarmDto represents an alarm sent by a device
+// @Description Request payload for ingesting an alarm from a device. The alarm premise is the device premise.
+type IngestAlarmDto struct {
+	Type        string `json:"type" validate:"required,max=100" example:"fire"`
+	Description string `json:"description" validate:"max=2000" example:"Smoke detector 3F-12 triggered"`
+	Severity    string `json:"severity" validate:"required,oneof=low medium high" example:"high" enums:"low,medium,high"`
+	// TriggeredAt is when the device raised the alarm; the time of receipt is used when omitted
+	TriggeredAt *time.Time `json:"triggered_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// AlarmIngestionResultDto describes what happened to an ingested alarm
+// @Description Result of ingesting an alarm: the stored alarm and the incident it belongs to
+type AlarmIngestionResultDto struct {
+	Alarm    models.Alarm     `json:"alarm"`
+	Incident *models.Incident `json:"incident,omitempty"`
+	// Deduplicated is true when the alarm was counted as a repeat of an alarm of the same burst
+	Deduplicated bool `json:"deduplicated" example:"false"`
+	// IncidentCreated is true when the alarm opened a new incident
+	IncidentCreated bool `json:"incident_created" example:"true"`
+	// Dispatch is how the new incident was dispatched
+	Dispatch *DispatchResultDto `json:"dispatch,omitempty"`
+	// DispatchError is why dispatching the new incident failed; the incident is kept and can be
+	// dispatched by an operator
+	DispatchError string `json:"dispatch_error,omitempty" example:"Database operation failed: create mission"`
+}
+
+// CreateDeviceDto represents the request to register an alarm device
+// @Description Request payload for registering a device allowed to send alarms for a premise
+type CreateDeviceDto struct {
+	Name      string `json:"name" validate:"required,max=255" example:"Main Building Fire Panel"`
+	PremiseID string `json:"premise_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// DeviceAPIKeyDto is a device together with its API key. The key is only returned when it is issued.
+// @Description Device with its newly issued API key
+type DeviceAPIKeyDto struct {
+	models.Device
+	APIKey string `json:"api_key" example:"dk_3f9a1c0e5b7d4a2f9c8e6b1d3a5f7c9e0b2d4f6a8c1e3b5d7f9a0c2e4b6d8f1a"`
+}
+
+// AlarmCorrelationRuleDto represents the request to create or edit an alarm correlation rule
+// @Description Request payload for an alarm correlation rule
+type AlarmCorrelationRuleDto struct {
+	Name                     string  `json:"name" validate:"required,max=255" example:"Fire alarms"`
+	Priority                 int     `json:"priority" example:"10"`
+	AlarmType                string  `json:"alarm_type" validate:"max=100" example:"fire"`
+	PremiseID                *string `json:"premise_id" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	DedupeWindowSeconds      int     `json:"dedupe_window_seconds" validate:"gte=0,lte=86400" example:"60"`
+	CorrelationWindowSeconds int     `json:"correlation_window_seconds" validate:"gte=0,lte=604800" example:"1800"`
+	CorrelateAcrossTypes     bool    `json:"correlate_across_types" example:"false"`
+	SeverityLow              string  `json:"severity_low" validate:"omitempty,oneof=low medium high" example:"low"`
+	SeverityMedium           string  `json:"severity_medium" validate:"omitempty,oneof=low medium high" example:"medium"`
+	SeverityHigh             string  `json:"severity_high" validate:"omitempty,oneof=low medium high" example:"high"`
+	Active                   *bool   `json:"active" example:"true"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// AssignMissionDto represents the request to assign a mission to a guard
+// @Description Request payload for instantiating a guidance template for an incident and assigning it
+type AssignMissionDto struct {
+	IncidentID         string `json:"incident_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	GuidanceTemplateID string `json:"guidance_template_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440001"`
+	AssigneeID         string `json:"assignee_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440002"`
+}
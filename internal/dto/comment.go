@@ -0,0 +1,35 @@
+package dto
+
+import "scs-guard/internal/models"
+
+// CreateCommentDto represents the request to comment on an incident, its mission or a mission step
+// @Description Request payload for adding a comment. The comment is about the step when step_id is set, about the mission when only mission_id is set, and about the incident otherwise.
+type CreateCommentDto struct {
+	Body      string `json:"body" validate:"required,max=4000" example:"Door was already forced when I arrived"`
+	MissionID string `json:"mission_id" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440001"`
+	StepID    string `json:"step_id" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440002"`
+	// MentionIDs are the users mentioned in the comment, who are notified of it
+	MentionIDs []string `json:"mention_ids" validate:"max=20,dive,uuid" example:"550e8400-e29b-41d4-a716-446655440003"`
+}
+
+// UpdateCommentDto represents the request to edit a comment
+// @Description Request payload for editing a comment. The mentions of the comment are replaced by mention_ids.
+type UpdateCommentDto struct {
+	Body       string   `json:"body" validate:"required,max=4000" example:"Door was already forced when I arrived, lock is broken"`
+	MentionIDs []string `json:"mention_ids" validate:"max=20,dive,uuid" example:"550e8400-e29b-41d4-a716-446655440003"`
+}
+
+// DeleteCommentDto represents the request to delete a comment
+// @Description Request payload for deleting a comment
+type DeleteCommentDto struct {
+	Reason string `json:"reason" validate:"max=500" example:"Posted on the wrong incident"`
+}
+
+// CommentPageDto is a page of comments
+// @Description Page of the comments of an incident, oldest first
+type CommentPageDto struct {
+	Comments []models.Comment `json:"comments"`
+	Total    int64            `json:"total" example:"12"`
+	Limit    int              `json:"limit" example:"50"`
+	Offset   int              `json:"offset" example:"0"`
+}
@@ -1,8 +1,34 @@
 package dto
 
 // CompleteMissionDto represents the request to complete a mission step
-// @Description Request payload for completing a mission step
+// @Description Request payload for completing a mission step, with the evidence the step requires
 type CompleteMissionDto struct {
 	MissionID string `json:"mission_id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
 	StepID    string `json:"step_id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440001"`
+	// Note is left as a comment on the step
+	Note string `json:"note" validate:"max=4000" example:"Door was already forced when I arrived"`
+	// CheckedItems are the checklist items of the step that were checked
+	CheckedItems []string `json:"checked_items" validate:"max=50,dive,max=255" example:"Panel checked,Doors closed"`
+	// Reading is the numeric reading taken for the step, such as a temperature
+	Reading *float64 `json:"reading" example:"21.5"`
+	// Answer answers the question of the step; required for steps that ask a question
+	Answer string `json:"answer" validate:"max=255" example:"yes"`
+}
+
+// Evidence requirements reported as missing when a step is completed
+const (
+	EvidencePhotos    = "photos"
+	EvidenceNote      = "note"
+	EvidenceSignature = "signature"
+	EvidenceChecklist = "checklist"
+	EvidenceReading   = "reading"
+)
+
+// MissingEvidenceDto is an evidence requirement a step completion does not meet
+// @Description Evidence requirement preventing a step from being completed
+type MissingEvidenceDto struct {
+	Requirement string `json:"requirement" example:"photos" enums:"photos,note,signature,checklist,reading"`
+	Message     string `json:"message" example:"2 photos are required, 1 uploaded"`
+	// Items are the unchecked checklist items
+	Items []string `json:"items,omitempty" example:"Doors closed"`
 }
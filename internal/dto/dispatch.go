@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"scs-guard/internal/models"
+	"time"
+)
+
+// DispatchRuleDto represents the request to create or edit a dispatch rule
+// @Description Request payload for a dispatch rule. Either a template category or a template lineage must be given.
+type DispatchRuleDto struct {
+	Name              string  `json:"name" validate:"required,max=255" example:"Night fire alarms"`
+	Priority          int     `json:"priority" example:"10"`
+	AlarmType         string  `json:"alarm_type" validate:"max=100" example:"fire"`
+	Severity          string  `json:"severity" validate:"omitempty,oneof=low medium high" example:"high"`
+	PremiseID         *string `json:"premise_id" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	StartTime         string  `json:"start_time" validate:"omitempty,datetime=15:04" example:"22:00"`
+	EndTime           string  `json:"end_time" validate:"omitempty,datetime=15:04" example:"06:00"`
+	TemplateCategory  string  `json:"template_category" validate:"required_without=TemplateLineageID,max=255" example:"Emergency"`
+	TemplateLineageID *string `json:"template_lineage_id" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440001"`
+	AutoAssign        *bool   `json:"auto_assign" example:"true"`
+	Active            *bool   `json:"active" example:"true"`
+}
+
+// DispatchAttributesDto holds the incident attributes dispatch rules are matched against
+// @Description Incident attributes matched by dispatch rules
+type DispatchAttributesDto struct {
+	AlarmType string `json:"alarm_type" validate:"max=100" example:"fire"`
+	Severity  string `json:"severity" validate:"omitempty,oneof=low medium high" example:"high"`
+	PremiseID string `json:"premise_id" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// At is the time the incident is dispatched at; now when omitted
+	At *time.Time `json:"at" example:"2023-01-01T23:30:00Z"`
+}
+
+// ExplainDispatchDto represents a dry-run dispatch request, for an existing incident or for attributes
+// @Description Request payload for explaining how an incident would be dispatched. The attributes are read from the incident when an incident ID is given.
+type ExplainDispatchDto struct {
+	IncidentID string `json:"incident_id" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440002"`
+	DispatchAttributesDto
+}
+
+// DispatchRuleEvaluationDto tells whether a dispatch rule matched and why not
+// @Description Evaluation of a dispatch rule against incident attributes
+type DispatchRuleEvaluationDto struct {
+	Rule    models.DispatchRule `json:"rule"`
+	Matched bool                `json:"matched" example:"false"`
+	// Mismatches lists the conditions of the rule the incident does not meet
+	Mismatches []string `json:"mismatches,omitempty" example:"severity is medium, rule requires high"`
+}
+
+// DispatchPlanDto explains which rule, template and guard dispatch picks for an incident
+// @Description Outcome of evaluating the dispatch rules for an incident
+type DispatchPlanDto struct {
+	Attributes DispatchAttributesDto `json:"attributes"`
+	// LocalTime is the time of day rules were matched at, in the dispatch time zone
+	LocalTime   string                      `json:"local_time" example:"23:30"`
+	Evaluations []DispatchRuleEvaluationDto `json:"evaluations"`
+	// Rule is the matched rule; empty when the template was picked by category fallback or not at all
+	Rule     *models.DispatchRule     `json:"rule,omitempty"`
+	Template *models.GuidanceTemplate `json:"template,omitempty"`
+	Guard    *GuardCandidateDto       `json:"guard,omitempty"`
+	// AutoAssign is true when the plan dispatches the guard without an operator
+	AutoAssign bool `json:"auto_assign" example:"true"`
+	// Notes explains each decision in order
+	Notes []string `json:"notes" example:"rule Night fire alarms matched"`
+}
+
+// GuardCandidateDto is a guard picked by dispatch
+// @Description Guard picked by dispatch with the number of unfinished missions they hold
+type GuardCandidateDto struct {
+	models.User
+	ActiveMissions int `json:"active_missions" example:"0"`
+}
+
+// DispatchResultDto is the outcome of dispatching an incident
+// @Description Dispatch plan and the mission created from it, if any
+type DispatchResultDto struct {
+	Plan    DispatchPlanDto          `json:"plan"`
+	Mission *models.IncidentGuidance `json:"mission,omitempty"`
+}
+
+// AddGuardPremiseDto represents the request to let a guard cover a premise
+// @Description Request payload for adding a guard to the guards covering a premise
+type AddGuardPremiseDto struct {
+	UserID string `json:"user_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
@@ -0,0 +1,83 @@
+package dto
+
+// GuidanceStepDto represents a step of a guidance template
+// @Description Step of a guidance template
+type GuidanceStepDto struct {
+	Title       string `json:"title" validate:"required,max=255" example:"Assess the situation"`
+	Description string `json:"description" validate:"max=2000" example:"Quickly evaluate the severity and scope of the fire"`
+	// DurationMinutes is the time allowed for the step at medium severity; 0 means no deadline
+	DurationMinutes int `json:"duration_minutes" validate:"gte=0,lte=1440" example:"5"`
+	// Optional steps do not have to be completed before the incident is resolved
+	Optional bool `json:"optional" example:"false"`
+	// Evidence is the proof required to complete the step
+	Evidence EvidenceRequirementsDto `json:"evidence"`
+	// Branching is the question the step asks and the condition activating it
+	Branching StepBranchingDto `json:"branching"`
+}
+
+// StepBranchingDto makes a procedure branch
+// @Description Question asked by a step and condition activating it. A step with a condition is only active when the earlier question step named by condition_key was answered with one of condition_answers, and is skipped otherwise.
+type StepBranchingDto struct {
+	// Key identifies the step within the template so that later steps can depend on it
+	Key          string   `json:"key" validate:"max=50" example:"smoke"`
+	QuestionType string   `json:"question_type" validate:"omitempty,oneof=yes_no choice" example:"yes_no" enums:"yes_no,choice"`
+	Options      []string `json:"options" validate:"max=20,dive,required,max=255" example:"Smoke,Flames,Nothing"`
+	ConditionKey string   `json:"condition_key" validate:"max=50" example:"smoke"`
+	// ConditionAnswers are the answers to the question that activate the step
+	ConditionAnswers []string `json:"condition_answers" validate:"max=20,dive,required,max=255" example:"yes"`
+}
+
+// EvidenceRequirementsDto lists the proof a step needs before it can be completed
+// @Description Evidence required to complete a step. Every requirement is off by default.
+type EvidenceRequirementsDto struct {
+	MinPhotos        int      `json:"min_photos" validate:"gte=0,lte=20" example:"2"`
+	RequireNote      bool     `json:"require_note" example:"true"`
+	RequireSignature bool     `json:"require_signature" example:"false"`
+	Checklist        []string `json:"checklist" validate:"max=50,dive,required,max=255" example:"Panel checked,Doors closed"`
+	// ReadingLabel names the numeric reading to take, such as a temperature; no reading is required when empty
+	ReadingLabel string `json:"reading_label" validate:"max=100" example:"Temperature"`
+	ReadingUnit  string `json:"reading_unit" validate:"max=20" example:"°C"`
+}
+
+// CreateGuidanceTemplateDto represents the request to create a guidance template
+// @Description Request payload for creating a guidance template with its ordered steps
+type CreateGuidanceTemplateDto struct {
+	Name        string            `json:"name" validate:"required,max=255" example:"Fire Emergency Response"`
+	Description string            `json:"description" validate:"max=2000" example:"Standard procedure for handling fire emergencies"`
+	Category    string            `json:"category" validate:"required,max=100" example:"Emergency"`
+	Steps       []GuidanceStepDto `json:"steps" validate:"dive"`
+	// StrictOrder requires mission steps to be completed or skipped in order
+	StrictOrder bool `json:"strict_order" example:"false"`
+}
+
+// UpdateGuidanceTemplateDto represents the request to edit a guidance template.
+// Editing creates a new version of the template.
+// @Description Request payload for editing a guidance template, replacing its details and steps
+type UpdateGuidanceTemplateDto struct {
+	Name        string            `json:"name" validate:"required,max=255" example:"Fire Emergency Response"`
+	Description string            `json:"description" validate:"max=2000" example:"Standard procedure for handling fire emergencies"`
+	Category    string            `json:"category" validate:"required,max=100" example:"Emergency"`
+	Steps       []GuidanceStepDto `json:"steps" validate:"dive"`
+	// StrictOrder requires mission steps to be completed or skipped in order
+	StrictOrder bool `json:"strict_order" example:"false"`
+}
+
+// AddGuidanceStepDto represents the request to add a step to a guidance template
+// @Description Request payload for adding a step to a guidance template
+type AddGuidanceStepDto struct {
+	GuidanceStepDto
+	// Position is the 1-based step number of the new step; the step is appended when omitted
+	Position int `json:"position" validate:"gte=0" example:"2"`
+}
+
+// ReorderGuidanceStepsDto represents the request to reorder the steps of a guidance template
+// @Description Request payload listing every step ID of the template in its new order
+type ReorderGuidanceStepsDto struct {
+	StepIDs []string `json:"step_ids" validate:"required,min=1,dive,uuid" example:"550e8400-e29b-41d4-a716-446655440001,550e8400-e29b-41d4-a716-446655440002"`
+}
+
+// DuplicateGuidanceTemplateDto represents the request to duplicate a guidance template
+// @Description Request payload for duplicating a guidance template into a new template
+type DuplicateGuidanceTemplateDto struct {
+	Name string `json:"name" validate:"max=255" example:"Fire Emergency Response (Warehouse)"`
+}
@@ -0,0 +1,59 @@
+package dto
+
+import "scs-guard/internal/models"
+
+// CreateIncidentDto represents the request to open an incident by hand
+// @Description Request payload for opening an incident
+type CreateIncidentDto struct {
+	Name        string `json:"name" validate:"required,max=255" example:"Fire in Building A"`
+	Description string `json:"description" validate:"max=2000" example:"Fire detected on the 3rd floor of Building A"`
+	Severity    string `json:"severity" validate:"required,oneof=low medium high" example:"high" enums:"low,medium,high"`
+	Location    string `json:"location" validate:"max=255" example:"Building A, Floor 3"`
+	// AlarmID links the incident to the alarm that caused it
+	AlarmID string `json:"alarm_id" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// UpdateIncidentDto represents the request to edit the details of an incident. Omitted fields are left unchanged.
+// @Description Request payload for editing an incident
+type UpdateIncidentDto struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=255" example:"Fire in Building A"`
+	Description *string `json:"description" validate:"omitempty,max=2000" example:"Fire detected on the 3rd floor of Building A"`
+	Severity    *string `json:"severity" validate:"omitempty,oneof=low medium high" example:"medium" enums:"low,medium,high"`
+	Location    *string `json:"location" validate:"omitempty,max=255" example:"Building A, Floor 3"`
+}
+
+// ResolveIncidentDto represents the request to resolve an incident
+// @Description Request payload for resolving an incident. An override reason is required when mandatory mission steps are incomplete.
+type ResolveIncidentDto struct {
+	ResolutionSummary string `json:"resolution_summary" validate:"required,max=4000" example:"Small fire in a waste bin, extinguished by the guard"`
+	OverrideReason    string `json:"override_reason" validate:"max=1000" example:"Fire brigade took over the scene"`
+}
+
+// ReopenIncidentDto represents the request to reopen a resolved incident
+// @Description Request payload for reopening an incident
+type ReopenIncidentDto struct {
+	Reason string `json:"reason" validate:"required,max=1000" example:"Alarm went off again"`
+}
+
+// CloseIncidentDto represents the request to close a resolved incident
+// @Description Request payload for closing an incident
+type CloseIncidentDto struct {
+	Reason string `json:"reason" validate:"max=1000" example:"Reviewed by the shift supervisor"`
+}
+
+// IncidentPageDto is a page of incidents
+// @Description Page of incidents, newest first
+type IncidentPageDto struct {
+	Incidents []models.Incident `json:"incidents"`
+	Total     int64             `json:"total" example:"120"`
+	Limit     int               `json:"limit" example:"50"`
+	Offset    int               `json:"offset" example:"0"`
+}
+
+// IncompleteStepDto is a mandatory step preventing an incident from being resolved
+// @Description Mandatory mission step that is not completed
+type IncompleteStepDto struct {
+	StepID     string `json:"step_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	StepNumber int64  `json:"step_number" example:"2"`
+	Title      string `json:"title" example:"Evacuate the floor"`
+}
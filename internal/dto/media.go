@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"scs-guard/internal/models"
+	"time"
+)
+
+// Outcomes of verifying the integrity of a media file
+const (
+	// MediaIntegrityIntact means the stored file matches the hash recorded at upload
+	MediaIntegrityIntact = "intact"
+	// MediaIntegrityTampered means the stored file no longer matches the hash recorded at upload
+	MediaIntegrityTampered = "tampered"
+	// MediaIntegrityMissing means the file is gone from storage
+	MediaIntegrityMissing = "missing"
+	// MediaIntegrityUnhashed means the file was uploaded before hashes were kept, so it cannot be checked
+	MediaIntegrityUnhashed = "unhashed"
+)
+
+// MediaVerificationDto reports whether a stored media file is still the file that was uploaded
+// @Description Outcome of re-hashing a stored media file and comparing it with the hash recorded at upload
+type MediaVerificationDto struct {
+	MediaID        string    `json:"media_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	Status         string    `json:"status" example:"intact" enums:"intact,tampered,missing,unhashed"`
+	ExpectedSHA256 string    `json:"expected_sha256,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	ActualSHA256   string    `json:"actual_sha256,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	ExpectedSize   int64     `json:"expected_size" example:"1024000"`
+	ActualSize     int64     `json:"actual_size" example:"1024000"`
+	VerifiedAt     time.Time `json:"verified_at" example:"2023-01-01T12:00:00Z"`
+}
+
+// ExportMediaDto represents the request to hand a media file out of the system
+// @Description Request payload for exporting incident media, for example for a police report or an insurance claim
+type ExportMediaDto struct {
+	// Recipient is who the file is handed to
+	Recipient string `json:"recipient" validate:"required,max=255" example:"City Police Department"`
+	Reason    string `json:"reason" validate:"max=1000" example:"Police report 2023-1042"`
+	// StripMetadata hands out a copy of a JPEG photo without its EXIF, XMP and IPTC metadata, which
+	// can reveal where and with what it was taken
+	StripMetadata bool `json:"strip_metadata" example:"false"`
+}
+
+// DeleteMediaDto represents the request to delete a media
+// @Description Request payload for deleting incident media uploaded by mistake
+type DeleteMediaDto struct {
+	Reason string `json:"reason" validate:"required,max=500" example:"Photo of the wrong building"`
+}
+
+// MediaPageDto is a page of the media of an incident
+// @Description Page of the media of an incident, oldest first
+type MediaPageDto struct {
+	Media  []models.IncidentMedia `json:"media"`
+	Total  int64                  `json:"total" example:"12"`
+	Limit  int                    `json:"limit" example:"50"`
+	Offset int                    `json:"offset" example:"0"`
+}
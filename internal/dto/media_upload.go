@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// CreateMediaUploadDto represents the request for a presigned URL to upload an incident media file
+// @Description Request payload for uploading an image or video of an incident directly to object storage
+type CreateMediaUploadDto struct {
+	IncidentID string `json:"incident_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// StepID is the mission step the file is evidence for
+	StepID string `json:"step_id" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440002"`
+	// Signature marks the file as the signature image the step requires
+	Signature   bool   `json:"signature" example:"false"`
+	FileName    string `json:"file_name" validate:"required,max=255" example:"incident_photo_001.jpg"`
+	ContentType string `json:"content_type" validate:"required,max=100" example:"image/jpeg"`
+}
+
+// MediaUploadDto tells a client where to upload a file
+// @Description Presigned URL to upload a file to. Send the file with the given method and headers before the URL expires, then confirm the upload.
+type MediaUploadDto struct {
+	UploadID  string            `json:"upload_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	ObjectKey string            `json:"object_key" example:"550e8400-e29b-41d4-a716-446655440000/7c9e6679-7425-40de-944b-e07fc1f90ae7/incident_photo_001.jpg"`
+	UploadURL string            `json:"upload_url" example:"https://minio.example.com/media/550e8400-e29b-41d4-a716-446655440000/7c9e6679-7425-40de-944b-e07fc1f90ae7/incident_photo_001.jpg?X-Amz-Signature=..."`
+	Method    string            `json:"method" example:"PUT"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt time.Time         `json:"expires_at" example:"2023-01-01T00:15:00Z"`
+}
+
+// InitiateResumableUploadDto represents the request to start uploading a large file in parts
+// @Description Request payload for uploading a large image or video of an incident in parts that can be resumed after a dropped connection
+type InitiateResumableUploadDto struct {
+	IncidentID string `json:"incident_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// StepID is the mission step the file is evidence for
+	StepID string `json:"step_id" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440002"`
+	// Signature marks the file as the signature image the step requires
+	Signature   bool   `json:"signature" example:"false"`
+	FileName    string `json:"file_name" validate:"required,max=255" example:"incident_video_001.mp4"`
+	ContentType string `json:"content_type" validate:"required,max=100" example:"video/mp4"`
+	FileSize    int64  `json:"file_size" validate:"gt=0" example:"52428800"`
+}
+
+// ResumableUploadDto tells a client which parts of a resumable upload to send
+// @Description State of a resumable upload. Upload every part numbered 1 to part_count that is not in uploaded_parts, each part_size bytes except the last, then complete the upload.
+type ResumableUploadDto struct {
+	UploadID      string    `json:"upload_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	ObjectKey     string    `json:"object_key" example:"550e8400-e29b-41d4-a716-446655440000/7c9e6679-7425-40de-944b-e07fc1f90ae7/incident_video_001.mp4"`
+	Status        string    `json:"status" example:"pending" enums:"pending,confirmed,aborted,expired"`
+	FileSize      int64     `json:"file_size" example:"52428800"`
+	PartSize      int64     `json:"part_size" example:"8388608"`
+	PartCount     int       `json:"part_count" example:"7"`
+	UploadedParts []int     `json:"uploaded_parts" example:"1,2,3"`
+	ExpiresAt     time.Time `json:"expires_at" example:"2023-01-02T00:00:00Z"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+// DeclineMissionDto represents the request to decline an assigned mission
+// @Description Request payload for declining a mission
+type DeclineMissionDto struct {
+	Reason string `json:"reason" validate:"required,max=500" example:"Already responding to another incident"`
+}
+
+// AbortMissionDto represents the request to abort a mission
+// @Description Request payload for aborting a mission
+type AbortMissionDto struct {
+	Reason string `json:"reason" validate:"required,max=500" example:"False alarm confirmed by CCTV"`
+}
+
+// SkipStepDto represents the request to skip a mission step
+// @Description Request payload for skipping an optional mission step
+type SkipStepDto struct {
+	Reason string `json:"reason" validate:"required,max=500" example:"Area not accessible"`
+}
+
+// UndoStepCompletionDto represents the request to undo the completion of a mission step
+// @Description Request payload for undoing a step completion within the grace period
+type UndoStepCompletionDto struct {
+	Reason string `json:"reason" validate:"max=500" example:"Completed the wrong step"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// ReassignMissionDto represents the request to move a mission to another guard
+// @Description Request payload for reassigning a mission
+type ReassignMissionDto struct {
+	AssigneeID string `json:"assignee_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440002"`
+	Reason     string `json:"reason" validate:"required,max=500" example:"Guard called in sick"`
+}
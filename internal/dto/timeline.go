@@ -0,0 +1,56 @@
+package dto
+
+import "time"
+
+// Types of incident timeline entries
+const (
+	TimelineAlarmTriggered        = "alarm.triggered"
+	TimelineAlarmCorrelated       = "alarm.correlated"
+	TimelineIncidentStatusChanged = "incident.status_changed"
+	TimelineMissionAssigned       = "mission.assigned"
+	TimelineMissionReassigned     = "mission.reassigned"
+	TimelineMissionEscalated      = "mission.escalated"
+	TimelineMissionStatusChanged  = "mission.status_changed"
+	TimelineMissionOverdue        = "mission.overdue"
+	TimelineStepCompleted         = "step.completed"
+	TimelineStepSkipped           = "step.skipped"
+	TimelineStepCompletionUndone  = "step.completion_undone"
+	TimelineStepOverdue           = "step.overdue"
+	TimelineMediaUploaded         = "media.uploaded"
+	TimelineCommentAdded          = "comment.added"
+)
+
+// Types of timeline actors
+const (
+	TimelineActorUser   = "user"
+	TimelineActorDevice = "device"
+)
+
+// TimelineActorDto identifies who caused a timeline entry
+// @Description User or alarm device that caused a timeline entry
+type TimelineActorDto struct {
+	Type string `json:"type" example:"user" enums:"user,device"`
+	ID   string `json:"id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Name string `json:"name" example:"John Doe"`
+	// Role is the role of a user actor
+	Role string `json:"role,omitempty" example:"guard" enums:"admin,guard,operator"`
+}
+
+// TimelineEntryDto is one thing that happened during an incident
+// @Description Timeline entry. Data holds the record the entry was built from, whose shape depends on the type. Entries without an actor were caused by the system.
+type TimelineEntryDto struct {
+	Type       string            `json:"type" example:"step.completed" enums:"alarm.triggered,alarm.correlated,incident.status_changed,mission.assigned,mission.reassigned,mission.escalated,mission.status_changed,mission.overdue,step.completed,step.skipped,step.completion_undone,step.overdue,media.uploaded,comment.added"`
+	OccurredAt time.Time         `json:"occurred_at" example:"2023-01-01T00:04:00Z"`
+	SourceID   string            `json:"source_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	Actor      *TimelineActorDto `json:"actor,omitempty"`
+	Data       interface{}       `json:"data" swaggertype:"object"`
+}
+
+// IncidentTimelineDto is a page of the timeline of an incident
+// @Description Page of the timeline of an incident, oldest entry first
+type IncidentTimelineDto struct {
+	Entries []TimelineEntryDto `json:"entries"`
+	Total   int64              `json:"total" example:"42"`
+	Limit   int                `json:"limit" example:"50"`
+	Offset  int                `json:"offset" example:"0"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import "scs-guard/internal/models"
+
+// CreateWebhookSubscriptionDto represents the request to subscribe a URL to mission events
+// @Description Request payload for creating a webhook subscription
+type CreateWebhookSubscriptionDto struct {
+	Name string `json:"name" validate:"required,max=255" example:"CCTV VMS"`
+	URL  string `json:"url" validate:"required,url,max=2048" example:"https://vms.example.com/hooks/missions"`
+	// Secret signs the deliveries; a random secret is generated when omitted
+	Secret string `json:"secret" validate:"omitempty,min=16,max=255" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	// EventTypes filters the events delivered; every event is delivered when empty
+	EventTypes []string `json:"event_types" validate:"dive,oneof=mission.assigned mission.reassigned mission.escalated mission.status_changed step.completed step.skipped step.completion_undone media.uploaded incident.status_changed incident.dispatch_failed comment.added comment.edited" example:"mission.status_changed,media.uploaded"`
+}
+
+// UpdateWebhookSubscriptionDto represents the request to edit a webhook subscription
+// @Description Request payload for editing a webhook subscription; the secret is rotated when given
+type UpdateWebhookSubscriptionDto struct {
+	Name       string   `json:"name" validate:"required,max=255" example:"CCTV VMS"`
+	URL        string   `json:"url" validate:"required,url,max=2048" example:"https://vms.example.com/hooks/missions"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16,max=255" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	EventTypes []string `json:"event_types" validate:"dive,oneof=mission.assigned mission.reassigned mission.escalated mission.status_changed step.completed step.skipped step.completion_undone media.uploaded incident.status_changed incident.dispatch_failed comment.added comment.edited" example:"mission.status_changed,media.uploaded"`
+	Active     bool     `json:"active" example:"true"`
+}
+
+// WebhookSubscriptionSecretDto is a webhook subscription together with its signing secret.
+// The secret is only returned when it is set.
+// @Description Webhook subscription with its signing secret
+type WebhookSubscriptionSecretDto struct {
+	models.WebhookSubscription
+	Secret string `json:"secret" example:"9f86d081884c7d659a2feaa0c55ad015"`
+}
+
+// WebhookDeliveryPageDto is a page of the delivery log of a webhook subscription
+// @Description Page of webhook deliveries, newest first
+type WebhookDeliveryPageDto struct {
+	Deliveries []models.WebhookDelivery `json:"deliveries"`
+	Total      int64                    `json:"total" example:"120"`
+	Limit      int                      `json:"limit" example:"50"`
+	Offset     int                      `json:"offset" example:"0"`
+}
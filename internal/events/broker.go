@@ -0,0 +1,113 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before it is dropped
+const subscriberBuffer = 64
+
+// Broker distributes mission events to subscribers
+type Broker interface {
+	// Publish assigns the event an ID and delivers it to every subscriber allowed to see it
+	Publish(event Event) Event
+	// Subscribe returns a subscription for a user. Retained events after lastEventID are
+	// replayed first, so a reconnecting client can resume where it left off.
+	Subscribe(userID string, role string, lastEventID uint64) *Subscription
+}
+
+// Subscription receives the events a user is allowed to see. Events is closed when the
+// subscription is closed or the subscriber falls too far behind; the client should then
+// reconnect with the ID of the last event it received.
+type Subscription struct {
+	Events <-chan Event
+	events chan Event
+	userID string
+	role   string
+	broker *MemoryBroker
+	once   sync.Once
+}
+
+// Close stops the subscription
+func (s *Subscription) Close() {
+	s.broker.unsubscribe(s)
+}
+
+// MemoryBroker is an in-process Broker that keeps the last events in memory for replay
+type MemoryBroker struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []Event
+	historySize int
+	subscribers map[*Subscription]struct{}
+}
+
+func NewMemoryBroker(historySize int) *MemoryBroker {
+	return &MemoryBroker{
+		historySize: historySize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+func (b *MemoryBroker) Publish(event Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	if b.historySize > 0 {
+		if len(b.history) >= b.historySize {
+			b.history = append(b.history[:0], b.history[len(b.history)-b.historySize+1:]...)
+		}
+		b.history = append(b.history, event)
+	}
+	for sub := range b.subscribers {
+		if !event.VisibleTo(sub.userID, sub.role) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			// The subscriber is not keeping up; drop it so it reconnects and replays
+			b.remove(sub)
+		}
+	}
+	return event
+}
+
+func (b *MemoryBroker) Subscribe(userID string, role string, lastEventID uint64) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []Event
+	if lastEventID > 0 {
+		for _, event := range b.history {
+			if event.ID > lastEventID && event.VisibleTo(userID, role) {
+				replay = append(replay, event)
+			}
+		}
+	}
+	ch := make(chan Event, len(replay)+subscriberBuffer)
+	for _, event := range replay {
+		ch <- event
+	}
+	sub := &Subscription{Events: ch, events: ch, userID: userID, role: role, broker: b}
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (b *MemoryBroker) unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(sub)
+}
+
+// remove must be called with mu held
+func (b *MemoryBroker) remove(sub *Subscription) {
+	delete(b.subscribers, sub)
+	sub.once.Do(func() { close(sub.events) })
+}
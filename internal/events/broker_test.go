@@ -0,0 +1,78 @@
+package events
+
+import (
+	"scs-guard/internal/models"
+	"testing"
+)
+
+func receive(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+	select {
+	case event := <-sub.Events:
+		return event
+	default:
+		t.Fatal("Expected an event, got none")
+		return Event{}
+	}
+}
+
+func expectNone(t *testing.T, sub *Subscription) {
+	t.Helper()
+	select {
+	case event := <-sub.Events:
+		t.Fatalf("Expected no event, got %s %d", event.Type, event.ID)
+	default:
+	}
+}
+
+func TestBrokerFiltersGuardEvents(t *testing.T) {
+	broker := NewMemoryBroker(10)
+	guard := broker.Subscribe("guard-1", models.RoleGuard, 0)
+	otherGuard := broker.Subscribe("guard-2", models.RoleGuard, 0)
+	operator := broker.Subscribe("operator-1", models.RoleOperator, 0)
+	defer guard.Close()
+	defer otherGuard.Close()
+	defer operator.Close()
+
+	broker.Publish(Event{Type: TypeMissionAssigned, UserIDs: []string{"guard-1"}})
+
+	if event := receive(t, guard); event.Type != TypeMissionAssigned || event.ID != 1 {
+		t.Errorf("Expected mission.assigned with ID 1, got %s with ID %d", event.Type, event.ID)
+	}
+	receive(t, operator)
+	expectNone(t, otherGuard)
+}
+
+func TestBrokerReplaysAfterLastEventID(t *testing.T) {
+	broker := NewMemoryBroker(3)
+	for i := 0; i < 5; i++ {
+		broker.Publish(Event{Type: TypeStepCompleted, UserIDs: []string{"guard-1"}})
+	}
+
+	sub := broker.Subscribe("guard-1", models.RoleGuard, 3)
+	defer sub.Close()
+
+	for _, id := range []uint64{4, 5} {
+		if event := receive(t, sub); event.ID != id {
+			t.Errorf("Expected replayed event %d, got %d", id, event.ID)
+		}
+	}
+	expectNone(t, sub)
+}
+
+func TestBrokerDropsSlowSubscriber(t *testing.T) {
+	broker := NewMemoryBroker(0)
+	sub := broker.Subscribe("operator-1", models.RoleOperator, 0)
+	for i := 0; i < subscriberBuffer+1; i++ {
+		broker.Publish(Event{Type: TypeStepCompleted})
+	}
+
+	received := 0
+	for range sub.Events {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("Expected %d buffered events before the subscription closed, got %d", subscriberBuffer, received)
+	}
+	sub.Close()
+}
@@ -0,0 +1,68 @@
+package events
+
+import (
+	"scs-guard/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mission event types
+const (
+	TypeMissionAssigned        = "mission.assigned"
+	TypeMissionReassigned      = "mission.reassigned"
+	TypeMissionEscalated       = "mission.escalated"
+	TypeMissionStatusChanged   = "mission.status_changed"
+	TypeStepCompleted          = "step.completed"
+	TypeStepSkipped            = "step.skipped"
+	TypeStepCompletionUndone   = "step.completion_undone"
+	TypeMediaUploaded          = "media.uploaded"
+	TypeIncidentStatusChanged  = "incident.status_changed"
+	TypeIncidentDispatchFailed = "incident.dispatch_failed"
+	TypeCommentAdded           = "comment.added"
+	TypeCommentEdited          = "comment.edited"
+)
+
+// Event is something that happened to a mission or its incident. ID orders the events of the
+// stream; Key identifies the event across redeliveries so consumers can ignore duplicates.
+// @Description Mission event streamed to clients
+type Event struct {
+	ID         uint64      `json:"id,omitempty" example:"42"`
+	Key        string      `json:"key" example:"550e8400-e29b-41d4-a716-446655440009" format:"uuid"`
+	Type       string      `json:"type" example:"mission.assigned" enums:"mission.assigned,mission.reassigned,mission.escalated,mission.status_changed,step.completed,step.skipped,step.completion_undone,media.uploaded,incident.status_changed,incident.dispatch_failed,comment.added,comment.edited"`
+	MissionID  *uuid.UUID  `json:"mission_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	IncidentID *uuid.UUID  `json:"incident_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	Data       interface{} `json:"data,omitempty"`
+	OccurredAt time.Time   `json:"occurred_at" example:"2023-01-01T00:00:00Z"`
+	// UserIDs are the guards the event concerns, usually the current and previous assignee and
+	// the users mentioned in a comment
+	UserIDs []string `json:"-"`
+}
+
+// VisibleTo reports whether a user may receive the event. Guards only receive events about
+// their own missions; operators and admins receive every event.
+func (e Event) VisibleTo(userID string, role string) bool {
+	if role != models.RoleGuard {
+		return true
+	}
+	for _, id := range e.UserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusChange is the data of mission and incident status change events
+// @Description Status change carried by mission.status_changed and incident.status_changed events
+type StatusChange struct {
+	From string `json:"from" example:"accepted"`
+	To   string `json:"to" example:"in_progress"`
+}
+
+// DispatchFailure is the data of incident.dispatch_failed events
+// @Description Why an incident opened by an alarm could not be dispatched
+type DispatchFailure struct {
+	AlarmID uuid.UUID `json:"alarm_id" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	Error   string    `json:"error" example:"Database operation failed: create mission"`
+}
@@ -0,0 +1,120 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"scs-guard/pkg/logger"
+	"time"
+)
+
+// Sink is a destination the outbox relay publishes events to. Events may be delivered more than
+// once, so sinks and their consumers must tolerate duplicates using the event key.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, event Event) error
+}
+
+// Sink names accepted in configuration
+const (
+	SinkBroker  = "broker"
+	SinkLog     = "log"
+	SinkWebhook = "webhook"
+)
+
+// NewSinks builds the sinks with the given names
+func NewSinks(names []string, broker Broker, webhookURL string, webhookTimeout time.Duration, logger logger.Logger) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case SinkBroker:
+			sinks = append(sinks, NewBrokerSink(broker))
+		case SinkLog:
+			sinks = append(sinks, NewLogSink(logger))
+		case SinkWebhook:
+			if webhookURL == "" {
+				return nil, fmt.Errorf("webhook sink requires a webhook url")
+			}
+			sinks = append(sinks, NewWebhookSink(webhookURL, &http.Client{Timeout: webhookTimeout}))
+		default:
+			return nil, fmt.Errorf("unknown event sink %q", name)
+		}
+	}
+	return sinks, nil
+}
+
+// BrokerSink publishes events to the in-process broker that feeds the event stream
+type BrokerSink struct {
+	broker Broker
+}
+
+func NewBrokerSink(broker Broker) *BrokerSink {
+	return &BrokerSink{broker: broker}
+}
+
+func (s *BrokerSink) Name() string {
+	return SinkBroker
+}
+
+func (s *BrokerSink) Send(ctx context.Context, event Event) error {
+	s.broker.Publish(event)
+	return nil
+}
+
+// LogSink writes events to the application log
+type LogSink struct {
+	logger logger.Logger
+}
+
+func NewLogSink(logger logger.Logger) *LogSink {
+	return &LogSink{logger: logger}
+}
+
+func (s *LogSink) Name() string {
+	return SinkLog
+}
+
+func (s *LogSink) Send(ctx context.Context, event Event) error {
+	s.logger.Infof("Event %s %s mission=%v incident=%v", event.Key, event.Type, event.MissionID, event.IncidentID)
+	return nil
+}
+
+// WebhookSink posts events as JSON to a fixed URL. The event key is sent in the Idempotency-Key
+// header; any response other than 2xx fails the delivery.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string, client *http.Client) *WebhookSink {
+	return &WebhookSink{url: url, client: client}
+}
+
+func (s *WebhookSink) Name() string {
+	return SinkWebhook
+}
+
+func (s *WebhookSink) Send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", event.Key)
+	req.Header.Set("X-Event-Type", event.Type)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
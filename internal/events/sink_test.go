@@ -0,0 +1,53 @@
+package events
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"scs-guard/pkg/logger"
+	"testing"
+	"time"
+)
+
+func TestWebhookSinkSendsIdempotencyKey(t *testing.T) {
+	var key string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key = r.Header.Get("Idempotency-Key")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, server.Client())
+	if err := sink.Send(context.Background(), Event{Key: "event-1", Type: TypeStepCompleted}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if key != "event-1" {
+		t.Errorf("Expected Idempotency-Key event-1, got %q", key)
+	}
+}
+
+func TestWebhookSinkFailsOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, server.Client())
+	if err := sink.Send(context.Background(), Event{Key: "event-1"}); err == nil {
+		t.Error("Expected an error for a 503 response")
+	}
+}
+
+func TestNewSinks(t *testing.T) {
+	broker := NewMemoryBroker(0)
+	sinks, err := NewSinks([]string{SinkBroker, SinkLog}, broker, "", time.Second, logger.GetLogger())
+	if err != nil || len(sinks) != 2 {
+		t.Fatalf("Expected 2 sinks, got %d (%v)", len(sinks), err)
+	}
+	if _, err := NewSinks([]string{SinkWebhook}, broker, "", time.Second, logger.GetLogger()); err == nil {
+		t.Error("Expected an error for a webhook sink without url")
+	}
+	if _, err := NewSinks([]string{"kafka"}, broker, "", time.Second, logger.GetLogger()); err == nil {
+		t.Error("Expected an error for an unknown sink")
+	}
+}
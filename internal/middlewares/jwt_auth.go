@@ -28,6 +28,7 @@ func (mw *MiddlewareManager) JWTAuth(next echo.HandlerFunc) echo.HandlerFunc {
 
 		// Store claims in context
 		c.Set("user_id", claims.UserID)
+		c.Set("role", claims.Role)
 
 		return next(c)
 	}
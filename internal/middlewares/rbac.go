@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"scs-guard/internal/models"
+	"scs-guard/pkg/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Permission is an action a role may be allowed to perform
+type Permission string
+
+const (
+	// PermissionMissionView allows reading missions
+	PermissionMissionView Permission = "mission:view"
+	// PermissionMissionExecute allows working on assigned missions: accepting, completing steps and uploading media
+	PermissionMissionExecute Permission = "mission:execute"
+	// PermissionMissionAssign allows assigning, reassigning and aborting missions
+	PermissionMissionAssign Permission = "mission:assign"
+	// PermissionIncidentView allows reading incidents
+	PermissionIncidentView Permission = "incident:view"
+	// PermissionIncidentManage allows opening, editing, resolving, reopening and closing incidents
+	PermissionIncidentManage Permission = "incident:manage"
+	// PermissionIncidentComment allows reading and writing comments; guards are limited to their own missions
+	PermissionIncidentComment Permission = "incident:comment"
+	// PermissionTemplateManage allows creating and editing guidance templates
+	PermissionTemplateManage Permission = "template:manage"
+	// PermissionWebhookManage allows managing webhook subscriptions and reading their delivery log
+	PermissionWebhookManage Permission = "webhook:manage"
+	// PermissionAlarmManage allows registering alarm devices and editing alarm correlation rules
+	PermissionAlarmManage Permission = "alarm:manage"
+	// PermissionDispatchManage allows editing dispatch rules and the premises guards cover
+	PermissionDispatchManage Permission = "dispatch:manage"
+)
+
+// rolePermissions is the permission matrix of every role
+var rolePermissions = map[string][]Permission{
+	models.RoleGuard: {
+		PermissionMissionView,
+		PermissionMissionExecute,
+		PermissionIncidentComment,
+	},
+	models.RoleOperator: {
+		PermissionMissionView,
+		PermissionMissionAssign,
+		PermissionIncidentView,
+		PermissionIncidentManage,
+		PermissionIncidentComment,
+	},
+	models.RoleAdmin: {
+		PermissionMissionView,
+		PermissionMissionAssign,
+		PermissionIncidentView,
+		PermissionIncidentManage,
+		PermissionIncidentComment,
+		PermissionTemplateManage,
+		PermissionWebhookManage,
+		PermissionAlarmManage,
+		PermissionDispatchManage,
+	},
+}
+
+// HasPermission reports whether a role is granted a permission
+func HasPermission(role string, permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RequirePermission only lets requests through when the role of the authenticated user grants the permission.
+// It must run after JWTAuth.
+func (mw *MiddlewareManager) RequirePermission(permission Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role, _ := c.Get("role").(string)
+			if !HasPermission(role, permission) {
+				return errors.NewForbiddenError("missing permission " + string(permission))
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/errors"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		permission Permission
+		expected   bool
+	}{
+		{"guard executes missions", models.RoleGuard, PermissionMissionExecute, true},
+		{"guard cannot assign", models.RoleGuard, PermissionMissionAssign, false},
+		{"operator assigns missions", models.RoleOperator, PermissionMissionAssign, true},
+		{"operator cannot execute", models.RoleOperator, PermissionMissionExecute, false},
+		{"operator cannot manage templates", models.RoleOperator, PermissionTemplateManage, false},
+		{"admin manages templates", models.RoleAdmin, PermissionTemplateManage, true},
+		{"admin manages webhooks", models.RoleAdmin, PermissionWebhookManage, true},
+		{"operator cannot manage webhooks", models.RoleOperator, PermissionWebhookManage, false},
+		{"admin manages alarms", models.RoleAdmin, PermissionAlarmManage, true},
+		{"guard cannot manage alarms", models.RoleGuard, PermissionAlarmManage, false},
+		{"operator manages incidents", models.RoleOperator, PermissionIncidentManage, true},
+		{"guard cannot view incidents", models.RoleGuard, PermissionIncidentView, false},
+		{"guard comments on incidents", models.RoleGuard, PermissionIncidentComment, true},
+		{"admin manages dispatch", models.RoleAdmin, PermissionDispatchManage, true},
+		{"operator cannot manage dispatch", models.RoleOperator, PermissionDispatchManage, false},
+		{"unknown role", "visitor", PermissionMissionView, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasPermission(tt.role, tt.permission); got != tt.expected {
+				t.Errorf("HasPermission(%s, %s) = %v, expected %v", tt.role, tt.permission, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	mw := &MiddlewareManager{}
+	handler := mw.RequirePermission(PermissionMissionAssign)(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		name      string
+		role      string
+		forbidden bool
+	}{
+		{"operator allowed", models.RoleOperator, false},
+		{"guard denied", models.RoleGuard, true},
+		{"missing role denied", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+			if tt.role != "" {
+				c.Set("role", tt.role)
+			}
+			err := handler(c)
+			if !tt.forbidden {
+				if err != nil {
+					t.Errorf("Expected request to pass, got %v", err)
+				}
+				return
+			}
+			appErr, ok := errors.IsAppError(err)
+			if !ok || appErr.Type != errors.ErrorTypeForbidden {
+				t.Errorf("Expected forbidden error, got %v", err)
+			}
+		})
+	}
+}
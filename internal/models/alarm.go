@@ -7,13 +7,18 @@ import (
 )
 
 // Alarm represents an alarm in the SCS system
-// @Description Alarm entity triggered by security events
+// @Description Alarm entity triggered by security events. Repeated alarms of a burst are counted on the first one.
 type Alarm struct {
 	Base
-	PremiseID   uuid.UUID `json:"premise_id" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
-	Premise     *Premise  `json:"premise,omitempty" gorm:"foreignKey:PremiseID"`
-	Type        string    `json:"type" example:"fire"`
-	Description string    `json:"description" example:"Fire alarm triggered in main building"`
-	Severity    string    `json:"severity" gorm:"check:severity IN ('low', 'medium', 'high')" example:"high" enums:"low,medium,high"`
-	TriggeredAt time.Time `json:"triggered_at" gorm:"type:timestamptz;default:CURRENT_TIMESTAMP" example:"2023-01-01T00:00:00Z"`
+	PremiseID       uuid.UUID  `json:"premise_id" gorm:"index:idx_alarm_burst,priority:1" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	Premise         *Premise   `json:"premise,omitempty" gorm:"foreignKey:PremiseID"`
+	DeviceID        *uuid.UUID `json:"device_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440003" swaggertype:"string" format:"uuid"`
+	Device          *Device    `json:"device,omitempty" gorm:"foreignKey:DeviceID"`
+	IncidentID      *uuid.UUID `json:"incident_id,omitempty" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440004" swaggertype:"string" format:"uuid"`
+	Type            string     `json:"type" gorm:"index:idx_alarm_burst,priority:2" example:"fire"`
+	Description     string     `json:"description" example:"Fire alarm triggered in main building"`
+	Severity        string     `json:"severity" gorm:"check:severity IN ('low', 'medium', 'high')" example:"high" enums:"low,medium,high"`
+	TriggeredAt     time.Time  `json:"triggered_at" gorm:"type:timestamptz;default:CURRENT_TIMESTAMP" example:"2023-01-01T00:00:00Z"`
+	LastTriggeredAt time.Time  `json:"last_triggered_at" gorm:"type:timestamptz;default:CURRENT_TIMESTAMP;index:idx_alarm_burst,priority:3" example:"2023-01-01T00:00:20Z"`
+	Occurrences     int        `json:"occurrences" gorm:"default:1" example:"3"`
 }
@@ -0,0 +1,26 @@
+package models
+
+import "github.com/google/uuid"
+
+// AlarmCorrelationRule decides how incoming alarms are deduplicated and grouped into incidents.
+// The active rule with the highest priority matching the alarm premise and type applies.
+// @Description Rule configuring alarm deduplication, incident correlation and severity mapping
+type AlarmCorrelationRule struct {
+	Base
+	Name     string `json:"name" example:"Fire alarms"`
+	Priority int    `json:"priority" example:"10"`
+	// AlarmType and PremiseID restrict the alarms the rule applies to; empty matches any
+	AlarmType string     `json:"alarm_type,omitempty" example:"fire"`
+	PremiseID *uuid.UUID `json:"premise_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	// DedupeWindowSeconds is how long repeats of an alarm from the same premise and type count as one burst
+	DedupeWindowSeconds int `json:"dedupe_window_seconds" example:"60"`
+	// CorrelationWindowSeconds is how long after the last related alarm an open incident still collects new alarms
+	CorrelationWindowSeconds int `json:"correlation_window_seconds" example:"1800"`
+	// CorrelateAcrossTypes groups alarms of any type at the premise into the same incident
+	CorrelateAcrossTypes bool `json:"correlate_across_types" example:"false"`
+	// Severity* are the incident severities for low, medium and high alarms
+	SeverityLow    string `json:"severity_low" gorm:"default:low" example:"low" enums:"low,medium,high"`
+	SeverityMedium string `json:"severity_medium" gorm:"default:medium" example:"medium" enums:"low,medium,high"`
+	SeverityHigh   string `json:"severity_high" gorm:"default:high" example:"high" enums:"low,medium,high"`
+	Active         bool   `json:"active" example:"true"`
+}
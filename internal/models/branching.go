@@ -0,0 +1,44 @@
+package models
+
+// Types of question a step can ask
+const (
+	QuestionTypeYesNo  = "yes_no"
+	QuestionTypeChoice = "choice"
+)
+
+// States of a mission step. Steps that depend on the answer to a question are pending until it is
+// answered, then become active or are skipped.
+const (
+	StepStateActive  = "active"
+	StepStatePending = "pending"
+	StepStateSkipped = "skipped"
+)
+
+// StepBranching makes a procedure branch. A step with a question type asks a question when it is
+// completed; a step with a condition only becomes active when the question step it names was
+// answered with one of the condition answers, and is skipped otherwise.
+// @Description Question asked by a step and condition activating it
+type StepBranching struct {
+	// Key identifies the step within its template so that other steps can depend on it
+	Key          string `json:"key,omitempty" example:"smoke"`
+	QuestionType string `json:"question_type,omitempty" example:"yes_no" enums:"yes_no,choice"`
+	// Options are the possible answers to a choice question
+	Options StringList `json:"options,omitempty" gorm:"type:jsonb" swaggertype:"array,string" example:"Smoke,Flames,Nothing"`
+	// ConditionKey is the key of the question step the step depends on
+	ConditionKey string `json:"condition_key,omitempty" example:"smoke"`
+	// ConditionAnswers are the answers to the question that activate the step
+	ConditionAnswers StringList `json:"condition_answers,omitempty" gorm:"type:jsonb" swaggertype:"array,string" example:"yes"`
+}
+
+// AnswerOptions returns the possible answers to the question of the step, or nil if the step
+// asks no question
+func (b StepBranching) AnswerOptions() []string {
+	switch b.QuestionType {
+	case QuestionTypeYesNo:
+		return []string{"yes", "no"}
+	case QuestionTypeChoice:
+		return b.Options
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Comment targets. Every comment belongs to an incident; mission and step comments also name the
+// mission or step they are about.
+const (
+	CommentTargetIncident = "incident"
+	CommentTargetMission  = "mission"
+	CommentTargetStep     = "step"
+)
+
+// Comment is a free-text note left on an incident, its mission or one of the mission steps.
+// Deleted comments are kept with their deletion time so the record of the incident stays complete.
+// @Description Comment on an incident, mission or mission step
+type Comment struct {
+	Base
+	IncidentID             uuid.UUID        `json:"incident_id" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	TargetType             string           `json:"target_type" gorm:"check:target_type IN ('incident', 'mission', 'step')" example:"step" enums:"incident,mission,step"`
+	IncidentGuidanceID     *uuid.UUID       `json:"incident_guidance_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	IncidentGuidanceStepID *uuid.UUID       `json:"incident_guidance_step_id,omitempty" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440002" swaggertype:"string" format:"uuid"`
+	AuthorID               uuid.UUID        `json:"author_id" example:"550e8400-e29b-41d4-a716-446655440003" swaggertype:"string" format:"uuid"`
+	Author                 *User            `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+	Body                   string           `json:"body" gorm:"type:text" example:"Door was already forced when I arrived"`
+	Mentions               []CommentMention `json:"mentions" gorm:"foreignKey:CommentID"`
+	EditedAt               *time.Time       `json:"edited_at,omitempty" example:"2023-01-01T00:10:00Z"`
+	DeletedAt              *time.Time       `json:"deleted_at,omitempty" example:"2023-01-01T00:20:00Z"`
+	DeletedByID            *uuid.UUID       `json:"deleted_by_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440003" swaggertype:"string" format:"uuid"`
+	DeleteReason           string           `json:"delete_reason,omitempty" example:"Posted on the wrong incident"`
+}
+
+// CommentMention is a user mentioned in a comment
+// @Description User mentioned in a comment
+type CommentMention struct {
+	Base
+	CommentID uuid.UUID `json:"comment_id" gorm:"uniqueIndex:idx_comment_mention" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	UserID    uuid.UUID `json:"user_id" gorm:"uniqueIndex:idx_comment_mention" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	User      *User     `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// CommentEdit is an append-only record of the text a comment had before it was edited
+// @Description Previous version of an edited comment
+type CommentEdit struct {
+	Base
+	CommentID    uuid.UUID `json:"comment_id" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	EditorID     uuid.UUID `json:"editor_id" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	PreviousBody string    `json:"previous_body" gorm:"type:text" example:"Door was forced"`
+}
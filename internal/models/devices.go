@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Device is an alarm panel or sensor gateway allowed to send alarms for a premise
+// @Description Device authenticated by API key to ingest alarms
+type Device struct {
+	Base
+	Name       string     `json:"name" example:"Main Building Fire Panel"`
+	PremiseID  uuid.UUID  `json:"premise_id" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	Premise    *Premise   `json:"premise,omitempty" gorm:"foreignKey:PremiseID"`
+	APIKeyHash string     `json:"-" gorm:"uniqueIndex"`
+	KeyPrefix  string     `json:"key_prefix" example:"dk_3f9a1c"`
+	Active     bool       `json:"active" gorm:"default:true" example:"true"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty" example:"2023-01-01T00:00:00Z"`
+}
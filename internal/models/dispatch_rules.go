@@ -0,0 +1,26 @@
+package models
+
+import "github.com/google/uuid"
+
+// DispatchRule picks the guidance template for a new incident and whether a guard is dispatched
+// to it automatically. The active rule with the highest priority matching the incident applies.
+// @Description Rule selecting the guidance template and dispatch behaviour for incidents
+type DispatchRule struct {
+	Base
+	Name     string `json:"name" example:"Night fire alarms"`
+	Priority int    `json:"priority" example:"10"`
+	// AlarmType, Severity and PremiseID restrict the incidents the rule applies to; empty matches any
+	AlarmType string     `json:"alarm_type,omitempty" example:"fire"`
+	Severity  string     `json:"severity,omitempty" example:"high" enums:"low,medium,high"`
+	PremiseID *uuid.UUID `json:"premise_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	// StartTime and EndTime restrict the rule to a time of day (HH:MM in the dispatch time zone).
+	// The window wraps past midnight when EndTime is before StartTime; empty matches any time.
+	StartTime string `json:"start_time,omitempty" example:"22:00"`
+	EndTime   string `json:"end_time,omitempty" example:"06:00"`
+	// TemplateCategory selects the published template of a category; TemplateLineageID pins a template instead
+	TemplateCategory  string     `json:"template_category,omitempty" example:"Emergency"`
+	TemplateLineageID *uuid.UUID `json:"template_lineage_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	// AutoAssign dispatches an available guard covering the premise; otherwise an operator assigns the mission
+	AutoAssign bool `json:"auto_assign" example:"true"`
+	Active     bool `json:"active" example:"true"`
+}
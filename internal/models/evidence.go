@@ -0,0 +1,19 @@
+package models
+
+// EvidenceRequirements lists the proof a step needs before it can be completed. The zero value
+// requires nothing.
+// @Description Evidence required to complete a step
+type EvidenceRequirements struct {
+	// MinPhotos is the number of photos that must be uploaded for the step
+	MinPhotos int `json:"min_photos" gorm:"default:0" example:"2"`
+	// RequireNote requires a note when the step is completed
+	RequireNote bool `json:"require_note" example:"true"`
+	// RequireSignature requires a signature image to be uploaded for the step
+	RequireSignature bool `json:"require_signature" example:"false"`
+	// Checklist are the sub-items that must all be checked when the step is completed
+	Checklist StringList `json:"checklist" gorm:"type:jsonb" swaggertype:"array,string" example:"Panel checked,Doors closed"`
+	// ReadingLabel names the numeric reading to take when the step is completed; no reading is
+	// required when it is empty
+	ReadingLabel string `json:"reading_label,omitempty" example:"Temperature"`
+	ReadingUnit  string `json:"reading_unit,omitempty" example:"°C"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "github.com/google/uuid"
+
+// GuardPremise records that a guard covers a premise and its sub-premises, and can be dispatched to their incidents
+// @Description Coverage of a premise by a guard
+type GuardPremise struct {
+	Base
+	UserID    uuid.UUID `json:"user_id" gorm:"uniqueIndex:idx_guard_premise" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	User      *User     `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	PremiseID uuid.UUID `json:"premise_id" gorm:"uniqueIndex:idx_guard_premise;index" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	Premise   *Premise  `json:"premise,omitempty" gorm:"foreignKey:PremiseID"`
+}
@@ -11,4 +11,9 @@ type GuidanceStep struct {
 	StepNumber         int               `json:"step_number" example:"1"`
 	Title              string            `json:"title" example:"Assess the situation"`
 	Description        string            `json:"description" example:"Quickly evaluate the severity and scope of the fire"`
+	DurationMinutes    int               `json:"duration_minutes" gorm:"default:0" example:"5"`
+	// Optional steps do not have to be completed before the incident is resolved
+	Optional  bool                 `json:"optional" example:"false"`
+	Evidence  EvidenceRequirements `json:"evidence" gorm:"embedded;embeddedPrefix:evidence_"`
+	Branching StepBranching        `json:"branching" gorm:"embedded;embeddedPrefix:branch_"`
 }
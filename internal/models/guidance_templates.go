@@ -1,5 +1,15 @@
 package models
 
+import "github.com/google/uuid"
+
+// Guidance template statuses. Every template row is an immutable version: the latest version of
+// a template is published, older versions are superseded and archived templates can no longer be used.
+const (
+	TemplateStatusPublished  = "published"
+	TemplateStatusSuperseded = "superseded"
+	TemplateStatusArchived   = "archived"
+)
+
 // GuidanceTemplate represents a template for incident guidance procedures
 // @Description Template containing step-by-step guidance for handling incidents
 type GuidanceTemplate struct {
@@ -7,5 +17,19 @@ type GuidanceTemplate struct {
 	Name          string         `json:"name" example:"Fire Emergency Response"`
 	Description   string         `json:"description" example:"Standard procedure for handling fire emergencies"`
 	Category      string         `json:"category" example:"Emergency"`
+	LineageID     *uuid.UUID     `json:"lineage_id" gorm:"type:uuid;uniqueIndex:idx_guidance_template_version" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	Version       int            `json:"version" gorm:"default:1;uniqueIndex:idx_guidance_template_version" example:"1"`
+	Status        string         `json:"status" gorm:"default:published;check:status IN ('published', 'superseded', 'archived')" example:"published" enums:"published,superseded,archived"`
 	GuidanceSteps []GuidanceStep `json:"guidance_steps" gorm:"foreignKey:GuidanceTemplateID"`
+	// StrictOrder requires the steps of missions created from the template to be completed or
+	// skipped in order
+	StrictOrder bool `json:"strict_order" example:"false"`
+}
+
+// Lineage returns the ID shared by all versions of the template
+func (t *GuidanceTemplate) Lineage() uuid.UUID {
+	if t.LineageID != nil {
+		return *t.LineageID
+	}
+	return t.ID
 }
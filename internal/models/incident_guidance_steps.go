@@ -10,11 +10,30 @@ import (
 // @Description Individual step within an incident guidance with completion tracking
 type IncidentGuidanceStep struct {
 	Base
-	IncidentGuidanceID uuid.UUID         `json:"incident_guidance_id" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
-	IncidentGuidance   *IncidentGuidance `json:"incident_guidance,omitempty" gorm:"foreignKey:IncidentGuidanceID"`
-	StepNumber         int64             `json:"step_number" example:"1"`
-	Title              string            `json:"title" example:"Assess the situation"`
-	Description        string            `json:"description" example:"Quickly evaluate the severity and scope of the incident"`
-	IsCompleted        bool              `json:"is_completed" gorm:"default:false" example:"false"`
-	CompletedAt        *time.Time        `json:"completed_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	IncidentGuidanceID uuid.UUID            `json:"incident_guidance_id" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	IncidentGuidance   *IncidentGuidance    `json:"incident_guidance,omitempty" gorm:"foreignKey:IncidentGuidanceID"`
+	StepNumber         int64                `json:"step_number" example:"1"`
+	Title              string               `json:"title" example:"Assess the situation"`
+	Description        string               `json:"description" example:"Quickly evaluate the severity and scope of the incident"`
+	IsCompleted        bool                 `json:"is_completed" gorm:"default:false" example:"false"`
+	Optional           bool                 `json:"optional" example:"false"`
+	Evidence           EvidenceRequirements `json:"evidence" gorm:"embedded;embeddedPrefix:evidence_"`
+	Branching          StepBranching        `json:"branching" gorm:"embedded;embeddedPrefix:branch_"`
+	// State tells whether the step is active, waiting for the answer to the question it depends
+	// on, or skipped because of that answer or by the guard
+	State string `json:"state" gorm:"default:active;check:state IN ('active', 'pending', 'skipped')" example:"active" enums:"active,pending,skipped"`
+	// Answer is the answer given to the question of the step when it was completed
+	Answer string `json:"answer,omitempty" example:"yes"`
+	// CheckedItems are the checklist items checked when the step was completed
+	CheckedItems StringList `json:"checked_items,omitempty" gorm:"type:jsonb" swaggertype:"array,string" example:"Panel checked,Doors closed"`
+	// Reading is the numeric reading taken when the step was completed
+	Reading       *float64   `json:"reading,omitempty" example:"21.5"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	CompletedByID *uuid.UUID `json:"completed_by_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	DueAt         *time.Time `json:"due_at,omitempty" example:"2023-01-01T00:05:00Z"`
+	OverdueAt     *time.Time `json:"overdue_at,omitempty" example:"2023-01-01T00:05:30Z"`
+	SkippedAt     *time.Time `json:"skipped_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	// SkippedByID is the guard who skipped the step; it is empty for steps skipped because of an answer
+	SkippedByID *uuid.UUID `json:"skipped_by_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	SkipReason  string     `json:"skip_reason,omitempty" example:"Area not accessible"`
 }
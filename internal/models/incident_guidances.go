@@ -1,6 +1,21 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mission statuses of an incident guidance
+const (
+	MissionStatusAssigned   = "assigned"
+	MissionStatusAccepted   = "accepted"
+	MissionStatusDeclined   = "declined"
+	MissionStatusInProgress = "in_progress"
+	MissionStatusPaused     = "paused"
+	MissionStatusCompleted  = "completed"
+	MissionStatusAborted    = "aborted"
+)
 
 // IncidentGuidance represents guidance assigned to a specific incident
 // @Description Guidance assignment linking an incident to a guidance template with assignee information
@@ -14,5 +29,23 @@ type IncidentGuidance struct {
 	Assigner              *User                  `json:"assigner,omitempty" gorm:"foreignKey:AssignerID"`
 	AssigneeID            *uuid.UUID             `json:"assignee_id" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
 	Assignee              *User                  `json:"assignee,omitempty" gorm:"foreignKey:AssigneeID"`
+	Status                string                 `json:"status" gorm:"default:assigned;check:status IN ('assigned', 'accepted', 'declined', 'in_progress', 'paused', 'completed', 'aborted')" example:"assigned" enums:"assigned,accepted,declined,in_progress,paused,completed,aborted"`
+	DeclineReason         string                 `json:"decline_reason,omitempty" example:"Already responding to another incident"`
+	AbortReason           string                 `json:"abort_reason,omitempty" example:"False alarm confirmed by CCTV"`
+	EscalatedToID         *uuid.UUID             `json:"escalated_to_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	EscalatedTo           *User                  `json:"escalated_to,omitempty" gorm:"foreignKey:EscalatedToID"`
+	AssignedAt            *time.Time             `json:"assigned_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	LastActivityAt        *time.Time             `json:"last_activity_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	EscalatedAt           *time.Time             `json:"escalated_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	DueAt                 *time.Time             `json:"due_at,omitempty" example:"2023-01-01T00:30:00Z"`
+	OverdueAt             *time.Time             `json:"overdue_at,omitempty" example:"2023-01-01T00:30:30Z"`
+	AcceptedAt            *time.Time             `json:"accepted_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	DeclinedAt            *time.Time             `json:"declined_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	StartedAt             *time.Time             `json:"started_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	PausedAt              *time.Time             `json:"paused_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	CompletedAt           *time.Time             `json:"completed_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	AbortedAt             *time.Time             `json:"aborted_at,omitempty" example:"2023-01-01T00:00:00Z"`
 	IncidentGuidanceSteps []IncidentGuidanceStep `json:"incident_guidance_steps" gorm:"foreignKey:IncidentGuidanceID"`
+	// StrictOrder is copied from the template: steps must be completed or skipped in order
+	StrictOrder bool `json:"strict_order" example:"false"`
 }
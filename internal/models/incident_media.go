@@ -1,16 +1,92 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
 
-// IncidentMedia represents media files associated with an incident
+	"github.com/google/uuid"
+)
+
+// IncidentMedia represents media files associated with an incident. FileUrl is not stored: it is
+// a short-lived presigned download URL filled in when the media is read.
 // @Description Media files (images, videos) attached to incidents for documentation
 type IncidentMedia struct {
 	Base
 	IncidentID uuid.UUID `json:"incident_id" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
 	Incident   *Incident `json:"incident,omitempty" gorm:"foreignKey:IncidentID"`
 	MediaType  string    `json:"media_type" gorm:"check:media_type IN ('image', 'video')" example:"image" enums:"image,video"`
-	FileUrl    string    `json:"file_url" example:"https://example.com/media/incident_123.jpg"`
+	FileUrl    string    `json:"file_url" gorm:"-" example:"https://minio.example.com/media/550e8400-e29b-41d4-a716-446655440000/incident_photo_001.jpg?X-Amz-Signature=..."`
 	FileSize   int64     `json:"file_size" example:"1024000"`
 	FileType   string    `json:"file_type" example:"image/jpeg"`
 	FileName   string    `json:"file_name" example:"incident_photo_001.jpg"`
+	// ObjectKey is the key of the file in object storage
+	ObjectKey string `json:"object_key" example:"550e8400-e29b-41d4-a716-446655440000/7c9e6679-7425-40de-944b-e07fc1f90ae7/incident_photo_001.jpg"`
+	// IncidentGuidanceStepID is the mission step the file is evidence for
+	IncidentGuidanceStepID *uuid.UUID `json:"incident_guidance_step_id,omitempty" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440002" swaggertype:"string" format:"uuid"`
+	// Signature marks an image as the signature required by its step
+	Signature bool `json:"signature" example:"false"`
+	// UploadedByID is the guard who uploaded the file
+	UploadedByID *uuid.UUID `json:"uploaded_by_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	// SHA256 is the hex SHA-256 hash of the file computed when it was uploaded, which proves that the
+	// stored file was not altered since. Media uploaded before hashes were kept have none.
+	SHA256 string `json:"sha256,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	// ProcessingStatus tells whether the thumbnail and preview of an image were made. Media waits
+	// as pending until the media processor picks it up; videos are skipped.
+	ProcessingStatus string `json:"processing_status" gorm:"default:pending;index" example:"done" enums:"pending,done,failed,skipped"`
+	// ProcessingError is why the thumbnail and preview could not be made
+	ProcessingError string `json:"processing_error,omitempty" example:"image: unknown format"`
+	// ThumbnailKey and PreviewKey are the keys in object storage of the resized copies of an image
+	ThumbnailKey string `json:"thumbnail_key,omitempty" example:"550e8400-e29b-41d4-a716-446655440000/7c9e6679-7425-40de-944b-e07fc1f90ae7/incident_photo_001.jpg.thumbnail.jpg"`
+	PreviewKey   string `json:"preview_key,omitempty" example:"550e8400-e29b-41d4-a716-446655440000/7c9e6679-7425-40de-944b-e07fc1f90ae7/incident_photo_001.jpg.preview.jpg"`
+	// ThumbnailUrl and PreviewUrl are presigned download URLs of the resized copies, filled in like
+	// FileUrl once processing is done
+	ThumbnailUrl string `json:"thumbnail_url,omitempty" gorm:"-" example:"https://minio.example.com/media/550e8400-e29b-41d4-a716-446655440000/incident_photo_001.jpg.thumbnail.jpg?X-Amz-Signature=..."`
+	PreviewUrl   string `json:"preview_url,omitempty" gorm:"-" example:"https://minio.example.com/media/550e8400-e29b-41d4-a716-446655440000/incident_photo_001.jpg.preview.jpg?X-Amz-Signature=..."`
+	// ProcessingAttempts counts the failed attempts to read or store the files while processing
+	ProcessingAttempts int `json:"-"`
+	// ProcessingLeaseUntil hides media that is being processed from other processors
+	ProcessingLeaseUntil *time.Time `json:"-"`
+	// CapturedAt, Latitude, Longitude, DeviceMake, DeviceModel and Orientation are read from the
+	// EXIF metadata of JPEG photos when they are uploaded
+	CapturedAt  *time.Time `json:"captured_at,omitempty" example:"2023-01-01T00:05:00Z"`
+	Latitude    *float64   `json:"latitude,omitempty" example:"52.520008"`
+	Longitude   *float64   `json:"longitude,omitempty" example:"13.404954"`
+	DeviceMake  string     `json:"device_make,omitempty" example:"Apple"`
+	DeviceModel string     `json:"device_model,omitempty" example:"iPhone 15"`
+	Orientation int        `json:"orientation,omitempty" example:"6"`
+	// DistanceFromPremise is how far in meters from the premise of the incident the photo was taken
+	DistanceFromPremise *float64 `json:"distance_from_premise,omitempty" example:"42.5"`
+	// MetadataFlags are reasons to doubt that the photo was taken at the incident
+	MetadataFlags StringList `json:"metadata_flags,omitempty" gorm:"type:jsonb" swaggertype:"array,string" enums:"captured_before_incident,far_from_premise" example:"far_from_premise"`
+	// DeletedAt, DeletedByID and DeleteReason record who removed a media and why. Deleted media are
+	// kept, and their files stay in storage until the retention period after deletion expires.
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" gorm:"index" example:"2023-01-01T00:20:00Z"`
+	DeletedByID  *uuid.UUID `json:"deleted_by_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440003" swaggertype:"string" format:"uuid"`
+	DeleteReason string     `json:"delete_reason,omitempty" example:"Photo of the wrong building"`
+	// PurgedAt is when the files of a deleted media were removed from storage
+	PurgedAt *time.Time `json:"purged_at,omitempty" example:"2023-01-31T00:20:00Z"`
+}
+
+// Reasons to doubt that a photo was taken at its incident
+const (
+	// MediaFlagCapturedBeforeIncident marks photos taken before the incident was reported
+	MediaFlagCapturedBeforeIncident = "captured_before_incident"
+	// MediaFlagFarFromPremise marks photos taken too far from the premise of the incident
+	MediaFlagFarFromPremise = "far_from_premise"
+)
+
+// Media processing statuses
+const (
+	MediaProcessingPending = "pending"
+	MediaProcessingDone    = "done"
+	MediaProcessingFailed  = "failed"
+	MediaProcessingSkipped = "skipped"
+)
+
+// Key returns the key of the file in object storage. Media uploaded before keys were stored were
+// kept under their file name.
+func (m *IncidentMedia) Key() string {
+	if m.ObjectKey != "" {
+		return m.ObjectKey
+	}
+	return m.FileName
 }
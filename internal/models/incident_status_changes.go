@@ -0,0 +1,16 @@
+package models
+
+import "github.com/google/uuid"
+
+// IncidentStatusChange is an append-only record of a change of the status of an incident.
+// Actor is empty for changes made by the system.
+// @Description Entry of the status history of an incident
+type IncidentStatusChange struct {
+	Base
+	IncidentID uuid.UUID  `json:"incident_id" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	FromStatus string     `json:"from_status,omitempty" example:"in_progress" enums:"new,in_progress,resolved,closed"`
+	ToStatus   string     `json:"to_status" example:"resolved" enums:"new,in_progress,resolved,closed"`
+	ActorID    *uuid.UUID `json:"actor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	Actor      *User      `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+	Reason     string     `json:"reason,omitempty" example:"Alarm went off again"`
+}
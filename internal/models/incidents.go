@@ -1,19 +1,39 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
+// Incident statuses. Resolved incidents can be reopened until they are closed; closed incidents are final.
+const (
+	IncidentStatusNew        = "new"
+	IncidentStatusInProgress = "in_progress"
+	IncidentStatusResolved   = "resolved"
+	IncidentStatusClosed     = "closed"
+)
+
 // Incident represents a security incident in the system
 // @Description Security incident entity with status tracking and guidance
 type Incident struct {
 	Base
 	Name             string            `json:"name" example:"Fire in Building A"`
 	Description      string            `json:"description" example:"Fire detected on the 3rd floor of Building A"`
-	AlarmID          uuid.UUID         `json:"alarm_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	AlarmID          *uuid.UUID        `json:"alarm_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
 	Alarm            *Alarm            `json:"alarm,omitempty" gorm:"foreignKey:AlarmID"`
-	Status           string            `json:"status" gorm:"check:status IN ('new', 'in_progress', 'resolved')" example:"new" enums:"new,in_progress,resolved"`
+	Status           string            `json:"status" gorm:"check:status IN ('new', 'in_progress', 'resolved', 'closed')" example:"new" enums:"new,in_progress,resolved,closed"`
 	Severity         string            `json:"severity" gorm:"check:severity IN ('low', 'medium', 'high')" example:"high" enums:"low,medium,high"`
 	Location         string            `json:"location" example:"Building A, Floor 3"`
+	CreatedByID      *uuid.UUID        `json:"created_by_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	CreatedBy        *User             `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID"`
 	IncidentGuidance *IncidentGuidance `json:"incident_guidance,omitempty" gorm:"foreignKey:IncidentID"`
+	// ResolutionSummary describes how the incident was handled
+	ResolutionSummary string `json:"resolution_summary,omitempty" example:"Small fire in a waste bin, extinguished by the guard"`
+	// ResolutionOverrideReason is why the incident was resolved with incomplete mandatory steps
+	ResolutionOverrideReason string     `json:"resolution_override_reason,omitempty" example:"Fire brigade took over the scene"`
+	ResolvedAt               *time.Time `json:"resolved_at,omitempty" example:"2023-01-01T01:00:00Z"`
+	ResolvedByID             *uuid.UUID `json:"resolved_by_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440002" swaggertype:"string" format:"uuid"`
+	ResolvedBy               *User      `json:"resolved_by,omitempty" gorm:"foreignKey:ResolvedByID"`
+	ClosedAt                 *time.Time `json:"closed_at,omitempty" example:"2023-01-02T00:00:00Z"`
 }
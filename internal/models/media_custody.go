@@ -0,0 +1,30 @@
+package models
+
+import "github.com/google/uuid"
+
+// Actions recorded in the chain of custody of incident media
+const (
+	CustodyActionUploaded   = "uploaded"
+	CustodyActionViewed     = "viewed"
+	CustodyActionDownloaded = "downloaded"
+	CustodyActionExported   = "exported"
+	CustodyActionDeleted    = "deleted"
+	CustodyActionVerified   = "verified"
+)
+
+// MediaCustodyEntry is an append-only record of who handled a media file of an incident and how:
+// uploading it, viewing or downloading it, exporting it out of the system, deleting it or
+// verifying its integrity. Together the entries of a media make up its chain of custody.
+// @Description Entry of the chain of custody of an incident media file
+type MediaCustodyEntry struct {
+	Base
+	IncidentMediaID uuid.UUID  `json:"incident_media_id" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440003" swaggertype:"string" format:"uuid"`
+	IncidentID      uuid.UUID  `json:"incident_id" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	Action          string     `json:"action" gorm:"check:action IN ('uploaded', 'viewed', 'downloaded', 'exported', 'deleted', 'verified')" example:"uploaded" enums:"uploaded,viewed,downloaded,exported,deleted,verified"`
+	ActorID         *uuid.UUID `json:"actor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	Actor           *User      `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+	// SHA256 is the hash recorded for the file when the action took place
+	SHA256 string `json:"sha256,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	// Details describes the action, such as the recipient of an export or the outcome of a verification
+	Details string `json:"details,omitempty" example:"Police report 2023-1042"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of a media upload
+const (
+	MediaUploadStatusPending   = "pending"
+	MediaUploadStatusConfirmed = "confirmed"
+	MediaUploadStatusAborted   = "aborted"
+	MediaUploadStatusExpired   = "expired"
+)
+
+// MediaUpload is a file a guard uploads to object storage, either directly through a presigned
+// URL or in parts through a resumable upload. It stays pending until the guard confirms or
+// completes the upload, which records the file as incident media. Pending uploads without
+// activity are discarded after a while.
+// @Description Direct upload of an incident media file to object storage
+type MediaUpload struct {
+	Base
+	IncidentID uuid.UUID `json:"incident_id" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	// IncidentGuidanceStepID is the mission step the file is evidence for
+	IncidentGuidanceStepID *uuid.UUID `json:"incident_guidance_step_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440002" swaggertype:"string" format:"uuid"`
+	Signature              bool       `json:"signature" example:"false"`
+	UploadedByID           uuid.UUID  `json:"uploaded_by_id" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	ObjectKey              string     `json:"object_key" gorm:"uniqueIndex" example:"550e8400-e29b-41d4-a716-446655440000/7c9e6679-7425-40de-944b-e07fc1f90ae7/incident_photo_001.jpg"`
+	FileName               string     `json:"file_name" example:"incident_photo_001.jpg"`
+	ContentType            string     `json:"content_type" example:"image/jpeg"`
+	Status                 string     `json:"status" gorm:"default:pending;check:status IN ('pending', 'confirmed', 'aborted', 'expired')" example:"pending" enums:"pending,confirmed,aborted,expired"`
+	// ExpiresAt is when the upload URL stops being valid, or for a resumable upload when it is
+	// discarded unless more parts are uploaded
+	ExpiresAt time.Time `json:"expires_at" example:"2023-01-01T00:15:00Z"`
+	// MultipartUploadID identifies the multipart upload in object storage of a resumable upload
+	MultipartUploadID string `json:"-"`
+	// FileSize and PartSize are the announced size of the file and the size of its parts for a
+	// resumable upload
+	FileSize int64             `json:"file_size,omitempty" example:"52428800"`
+	PartSize int64             `json:"part_size,omitempty" example:"8388608"`
+	Parts    []MediaUploadPart `json:"parts,omitempty" gorm:"foreignKey:MediaUploadID"`
+	// IncidentMediaID is the media recorded when the upload was confirmed
+	IncidentMediaID *uuid.UUID `json:"incident_media_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440003" swaggertype:"string" format:"uuid"`
+}
+
+// Resumable reports whether the file is uploaded in parts
+func (u *MediaUpload) Resumable() bool {
+	return u.MultipartUploadID != ""
+}
+
+// PartCount returns the number of parts of a resumable upload
+func (u *MediaUpload) PartCount() int {
+	if u.PartSize <= 0 {
+		return 0
+	}
+	return int((u.FileSize + u.PartSize - 1) / u.PartSize)
+}
+
+// ExpectedPartSize returns the size of a part of a resumable upload: every part has the part size
+// except the last, which holds the rest of the file
+func (u *MediaUpload) ExpectedPartSize(partNumber int) int64 {
+	if partNumber < u.PartCount() {
+		return u.PartSize
+	}
+	return u.FileSize - int64(u.PartCount()-1)*u.PartSize
+}
+
+// MediaUploadPart is a part of a resumable upload stored in object storage
+// @Description Uploaded part of a resumable upload
+type MediaUploadPart struct {
+	Base
+	MediaUploadID uuid.UUID `json:"media_upload_id" gorm:"uniqueIndex:idx_media_upload_part" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7" swaggertype:"string" format:"uuid"`
+	PartNumber    int       `json:"part_number" gorm:"uniqueIndex:idx_media_upload_part" example:"1"`
+	ETag          string    `json:"etag" example:"d41d8cd98f00b204e9800998ecf8427e"`
+	Size          int64     `json:"size" example:"8388608"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "gorm.io/gorm"
+
+// AutoMigrate creates or updates the tables used by the mission service
+func AutoMigrate(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&User{},
+		&Premise{},
+		&Device{},
+		&Alarm{},
+		&AlarmCorrelationRule{},
+		&Incident{},
+		&IncidentStatusChange{},
+		&GuidanceTemplate{},
+		&GuidanceStep{},
+		&DispatchRule{},
+		&GuardPremise{},
+		&IncidentGuidance{},
+		&IncidentGuidanceStep{},
+		&IncidentMedia{},
+		&MediaUpload{},
+		&MediaUploadPart{},
+		&MediaCustodyEntry{},
+		&MissionAssignmentHistory{},
+		&MissionStatusChange{},
+		&StepHistory{},
+		&Comment{},
+		&CommentMention{},
+		&CommentEdit{},
+		&OverdueEvent{},
+		&OutboxEvent{},
+		&WebhookSubscription{},
+		&WebhookDelivery{},
+	)
+}
@@ -0,0 +1,26 @@
+package models
+
+import "github.com/google/uuid"
+
+// Assignment history actions
+const (
+	AssignmentActionAssigned   = "assigned"
+	AssignmentActionReassigned = "reassigned"
+	AssignmentActionEscalated  = "escalated"
+)
+
+// MissionAssignmentHistory is an append-only record of a change in who is responsible for a mission.
+// For escalations, ToUser is the supervisor the mission was escalated to and Actor is empty.
+// @Description Entry of the assignment history of a mission
+type MissionAssignmentHistory struct {
+	Base
+	IncidentGuidanceID uuid.UUID  `json:"incident_guidance_id" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	Action             string     `json:"action" gorm:"check:action IN ('assigned', 'reassigned', 'escalated')" example:"reassigned" enums:"assigned,reassigned,escalated"`
+	ActorID            *uuid.UUID `json:"actor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	Actor              *User      `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+	FromUserID         *uuid.UUID `json:"from_user_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	FromUser           *User      `json:"from_user,omitempty" gorm:"foreignKey:FromUserID"`
+	ToUserID           *uuid.UUID `json:"to_user_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440002" swaggertype:"string" format:"uuid"`
+	ToUser             *User      `json:"to_user,omitempty" gorm:"foreignKey:ToUserID"`
+	Reason             string     `json:"reason,omitempty" example:"Guard called in sick"`
+}
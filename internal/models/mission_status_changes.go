@@ -0,0 +1,16 @@
+package models
+
+import "github.com/google/uuid"
+
+// MissionStatusChange is an append-only record of a change of the status of a mission.
+// Actor is empty for changes made by the system.
+// @Description Entry of the status history of a mission
+type MissionStatusChange struct {
+	Base
+	IncidentGuidanceID uuid.UUID  `json:"incident_guidance_id" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	FromStatus         string     `json:"from_status" example:"accepted" enums:"assigned,accepted,declined,in_progress,paused,completed,aborted"`
+	ToStatus           string     `json:"to_status" example:"in_progress" enums:"assigned,accepted,declined,in_progress,paused,completed,aborted"`
+	ActorID            *uuid.UUID `json:"actor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	Actor              *User      `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+	Reason             string     `json:"reason,omitempty" example:"False alarm confirmed by CCTV"`
+}
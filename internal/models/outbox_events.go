@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Delivery statuses of an outbox event
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusPublished = "published"
+	OutboxStatusFailed    = "failed"
+)
+
+// OutboxEvent is a domain event stored in the same transaction as the change it describes and
+// relayed to the configured sinks afterwards. Its ID identifies the event across redeliveries.
+// @Description Domain event waiting to be, or already, relayed to integrations
+type OutboxEvent struct {
+	Base
+	Type          string     `json:"type" gorm:"index" example:"step.completed"`
+	MissionID     *uuid.UUID `json:"mission_id,omitempty" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	IncidentID    *uuid.UUID `json:"incident_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	UserIDs       string     `json:"user_ids,omitempty" example:"550e8400-e29b-41d4-a716-446655440002"`
+	Payload       string     `json:"payload" gorm:"type:jsonb" swaggertype:"object"`
+	OccurredAt    time.Time  `json:"occurred_at" example:"2023-01-01T00:00:00Z"`
+	Status        string     `json:"status" gorm:"default:pending;index:idx_outbox_pending,priority:1;check:status IN ('pending', 'published', 'failed')" example:"pending" enums:"pending,published,failed"`
+	Attempts      int        `json:"attempts" gorm:"default:0" example:"0"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"index:idx_outbox_pending,priority:2" example:"2023-01-01T00:00:00Z"`
+	LastError     string     `json:"last_error,omitempty" example:"webhook returned 503"`
+	PublishedAt   *time.Time `json:"published_at,omitempty" example:"2023-01-01T00:00:01Z"`
+}
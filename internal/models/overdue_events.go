@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of overdue events
+const (
+	OverdueKindMission = "mission"
+	OverdueKindStep    = "step"
+)
+
+// OverdueEvent records that a mission or one of its steps missed its deadline
+// @Description Persisted record of a missed mission or step deadline
+type OverdueEvent struct {
+	Base
+	Kind                   string     `json:"kind" gorm:"check:kind IN ('mission', 'step')" example:"step" enums:"mission,step"`
+	IncidentID             *uuid.UUID `json:"incident_id" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	IncidentGuidanceID     uuid.UUID  `json:"incident_guidance_id" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	IncidentGuidanceStepID *uuid.UUID `json:"incident_guidance_step_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440002" swaggertype:"string" format:"uuid"`
+	AssigneeID             *uuid.UUID `json:"assignee_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440003" swaggertype:"string" format:"uuid"`
+	DueAt                  time.Time  `json:"due_at" example:"2023-01-01T00:05:00Z"`
+}
@@ -10,4 +10,7 @@ type Premise struct {
 	Address         string     `json:"address" example:"123 Main Street, City, Country"`
 	ParentPremiseID *uuid.UUID `json:"parent_premise_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
 	ParentPremise   *Premise   `json:"parent_premise,omitempty" gorm:"foreignKey:ParentPremiseID"`
+	// Latitude and Longitude locate the premise, to check that evidence photos were taken there
+	Latitude  *float64 `json:"latitude,omitempty" example:"52.520008"`
+	Longitude *float64 `json:"longitude,omitempty" example:"13.404954"`
 }
@@ -0,0 +1,26 @@
+package models
+
+import "github.com/google/uuid"
+
+// Actions recorded in the history of a mission step
+const (
+	StepActionCompleted        = "completed"
+	StepActionSkipped          = "skipped"
+	StepActionCompletionUndone = "completion_undone"
+)
+
+// StepHistory is an append-only record of a step of a mission being completed, skipped or having
+// its completion undone
+// @Description Entry of the history of the steps of a mission
+type StepHistory struct {
+	Base
+	IncidentGuidanceID     uuid.UUID  `json:"incident_guidance_id" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	IncidentGuidanceStepID uuid.UUID  `json:"incident_guidance_step_id" gorm:"index" example:"550e8400-e29b-41d4-a716-446655440002" swaggertype:"string" format:"uuid"`
+	StepNumber             int64      `json:"step_number" example:"2"`
+	Action                 string     `json:"action" gorm:"check:action IN ('completed', 'skipped', 'completion_undone')" example:"completed" enums:"completed,skipped,completion_undone"`
+	ActorID                *uuid.UUID `json:"actor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001" swaggertype:"string" format:"uuid"`
+	Actor                  *User      `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+	// Answer is the answer given to the question of the step when it was completed
+	Answer string `json:"answer,omitempty" example:"yes"`
+	Reason string `json:"reason,omitempty" example:"Area not accessible"`
+}
@@ -1,11 +1,21 @@
 package models
 
+import "github.com/google/uuid"
+
+// User roles
+const (
+	RoleAdmin    = "admin"
+	RoleGuard    = "guard"
+	RoleOperator = "operator"
+)
+
 // User represents a user in the system
 // @Description User entity with authentication and role information
 type User struct {
 	Base
-	Name     string `json:"name" example:"John Doe"`
-	Email    string `json:"email" gorm:"unique" example:"john.doe@example.com"`
-	Password string `json:"-"`
-	Role     string `json:"role" example:"admin"`
+	Name         string     `json:"name" example:"John Doe"`
+	Email        string     `json:"email" gorm:"unique" example:"john.doe@example.com"`
+	Password     string     `json:"-"`
+	Role         string     `json:"role" example:"admin" enums:"admin,guard,operator"`
+	SupervisorID *uuid.UUID `json:"supervisor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
 }
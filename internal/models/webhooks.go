@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Delivery statuses of a webhook delivery
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+)
+
+// WebhookSubscription is an external endpoint that receives signed mission events
+// @Description Webhook subscription receiving mission events matching its event filter
+type WebhookSubscription struct {
+	Base
+	Name   string `json:"name" example:"CCTV VMS"`
+	URL    string `json:"url" example:"https://vms.example.com/hooks/missions"`
+	Secret string `json:"-"`
+	// EventTypes is a comma separated list of event types; empty means every event
+	EventTypes  string     `json:"event_types" example:"mission.status_changed,media.uploaded"`
+	Active      bool       `json:"active" gorm:"default:true" example:"true"`
+	CreatedByID *uuid.UUID `json:"created_by_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+}
+
+// WebhookDelivery is one event sent, or to be sent, to a webhook subscription
+// @Description Delivery attempt log of an event to a webhook subscription
+type WebhookDelivery struct {
+	Base
+	SubscriptionID uuid.UUID            `json:"subscription_id" gorm:"uniqueIndex:idx_webhook_delivery_event" example:"550e8400-e29b-41d4-a716-446655440000" swaggertype:"string" format:"uuid"`
+	Subscription   *WebhookSubscription `json:"subscription,omitempty" gorm:"foreignKey:SubscriptionID"`
+	EventKey       string               `json:"event_key" gorm:"uniqueIndex:idx_webhook_delivery_event" example:"550e8400-e29b-41d4-a716-446655440009"`
+	EventType      string               `json:"event_type" example:"media.uploaded"`
+	Payload        string               `json:"payload" gorm:"type:jsonb" swaggertype:"object"`
+	Status         string               `json:"status" gorm:"default:pending;index:idx_webhook_delivery_pending,priority:1;check:status IN ('pending', 'delivered', 'dead')" example:"pending" enums:"pending,delivered,dead"`
+	Attempts       int                  `json:"attempts" gorm:"default:0" example:"1"`
+	NextAttemptAt  time.Time            `json:"next_attempt_at" gorm:"index:idx_webhook_delivery_pending,priority:2" example:"2023-01-01T00:00:30Z"`
+	LastStatusCode int                  `json:"last_status_code,omitempty" example:"503"`
+	LastError      string               `json:"last_error,omitempty" example:"webhook returned 503"`
+	DeliveredAt    *time.Time           `json:"delivered_at,omitempty" example:"2023-01-01T00:00:01Z"`
+}
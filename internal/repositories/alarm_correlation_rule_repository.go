@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AlarmCorrelationRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewAlarmCorrelationRuleRepository(db *gorm.DB) *AlarmCorrelationRuleRepository {
+	return &AlarmCorrelationRuleRepository{db: db}
+}
+
+func (r *AlarmCorrelationRuleRepository) Create(ctx context.Context, rule *models.AlarmCorrelationRule) error {
+	if err := getDB(ctx, r.db).Create(rule).Error; err != nil {
+		return fmt.Errorf("failed to create alarm correlation rule: %w", err)
+	}
+	return nil
+}
+
+func (r *AlarmCorrelationRuleRepository) GetByID(ctx context.Context, id string) (*models.AlarmCorrelationRule, error) {
+	var rule models.AlarmCorrelationRule
+	if err := getDB(ctx, r.db).First(&rule, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get alarm correlation rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// GetAll returns every rule, highest priority first
+func (r *AlarmCorrelationRuleRepository) GetAll(ctx context.Context) ([]models.AlarmCorrelationRule, error) {
+	var rules []models.AlarmCorrelationRule
+	if err := getDB(ctx, r.db).Order("priority DESC, created_at").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get alarm correlation rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetActive returns the active rules, highest priority first
+func (r *AlarmCorrelationRuleRepository) GetActive(ctx context.Context) ([]models.AlarmCorrelationRule, error) {
+	var rules []models.AlarmCorrelationRule
+	if err := getDB(ctx, r.db).Where("active = ?", true).Order("priority DESC, created_at").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get active alarm correlation rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *AlarmCorrelationRuleRepository) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	if err := getDB(ctx, r.db).Model(&models.AlarmCorrelationRule{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update alarm correlation rule: %w", err)
+	}
+	return nil
+}
+
+func (r *AlarmCorrelationRuleRepository) Delete(ctx context.Context, id string) error {
+	if err := getDB(ctx, r.db).Where("id = ?", id).Delete(&models.AlarmCorrelationRule{}).Error; err != nil {
+		return fmt.Errorf("failed to delete alarm correlation rule: %w", err)
+	}
+	return nil
+}
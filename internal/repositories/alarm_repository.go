@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AlarmRepository struct {
+	db *gorm.DB
+}
+
+func NewAlarmRepository(db *gorm.DB) *AlarmRepository {
+	return &AlarmRepository{db: db}
+}
+
+// LockBurst serializes ingestion of alarms from the same premise and type until the surrounding
+// transaction ends, so concurrent alarms of a burst are deduplicated. It must run inside a transaction.
+func (r *AlarmRepository) LockBurst(ctx context.Context, premiseID string, alarmType string) error {
+	if err := getDB(ctx, r.db).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "alarm:"+premiseID+":"+alarmType).Error; err != nil {
+		return fmt.Errorf("failed to lock alarm burst: %w", err)
+	}
+	return nil
+}
+
+func (r *AlarmRepository) Create(ctx context.Context, alarm *models.Alarm) error {
+	if err := getDB(ctx, r.db).Create(alarm).Error; err != nil {
+		return fmt.Errorf("failed to create alarm: %w", err)
+	}
+	return nil
+}
+
+// GetLatestInBurst returns the latest alarm from a premise and type last triggered at or after since
+func (r *AlarmRepository) GetLatestInBurst(ctx context.Context, premiseID string, alarmType string, since time.Time) (*models.Alarm, error) {
+	var alarm models.Alarm
+	if err := getDB(ctx, r.db).Where("premise_id = ? AND type = ? AND last_triggered_at >= ?", premiseID, alarmType, since).
+		Order("last_triggered_at DESC").First(&alarm).Error; err != nil {
+		return nil, fmt.Errorf("failed to get alarm burst: %w", err)
+	}
+	return &alarm, nil
+}
+
+func (r *AlarmRepository) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	if err := getDB(ctx, r.db).Model(&models.Alarm{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update alarm: %w", err)
+	}
+	return nil
+}
+
+// GetOpenIncident returns the unresolved incident of the latest alarm from a premise triggered at
+// or after since, restricted to an alarm type unless alarmType is empty
+func (r *AlarmRepository) GetOpenIncident(ctx context.Context, premiseID string, alarmType string, since time.Time) (*models.Incident, error) {
+	var incident models.Incident
+	query := getDB(ctx, r.db).Joins("JOIN alarms ON alarms.incident_id = incidents.id").
+		Where("alarms.premise_id = ? AND alarms.last_triggered_at >= ? AND incidents.status NOT IN ?", premiseID, since,
+			[]string{models.IncidentStatusResolved, models.IncidentStatusClosed})
+	if alarmType != "" {
+		query = query.Where("alarms.type = ?", alarmType)
+	}
+	if err := query.Order("alarms.last_triggered_at DESC").First(&incident).Error; err != nil {
+		return nil, fmt.Errorf("failed to get open incident: %w", err)
+	}
+	return &incident, nil
+}
+
+func (r *AlarmRepository) GetByID(ctx context.Context, id string) (*models.Alarm, error) {
+	var alarm models.Alarm
+	if err := getDB(ctx, r.db).First(&alarm, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get alarm: %w", err)
+	}
+	return &alarm, nil
+}
+
+// GetByIncidentID returns the alarms correlated into an incident, oldest first
+func (r *AlarmRepository) GetByIncidentID(ctx context.Context, incidentID string) ([]models.Alarm, error) {
+	var alarms []models.Alarm
+	if err := getDB(ctx, r.db).Preload("Device").Where("incident_id = ?", incidentID).Order("triggered_at").Find(&alarms).Error; err != nil {
+		return nil, fmt.Errorf("failed to get incident alarms: %w", err)
+	}
+	return alarms, nil
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// Create stores a comment together with its mentions
+func (r *CommentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	if err := getDB(ctx, r.db).Create(comment).Error; err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+	return nil
+}
+
+func (r *CommentRepository) GetByID(ctx context.Context, id string) (*models.Comment, error) {
+	var comment models.Comment
+	if err := getDB(ctx, r.db).Preload("Author").Preload("Mentions.User").First(&comment, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	return &comment, nil
+}
+
+// CommentFilter narrows down and pages the comments returned by GetPage
+type CommentFilter struct {
+	IncidentID     string
+	TargetType     string
+	StepID         string
+	IncludeDeleted bool
+	Limit          int
+	Offset         int
+}
+
+// GetPage returns a page of the comments of an incident, oldest first, with the number of comments
+// matching the filter
+func (r *CommentRepository) GetPage(ctx context.Context, filter CommentFilter) ([]models.Comment, int64, error) {
+	var comments []models.Comment
+	var total int64
+	query := getDB(ctx, r.db).Model(&models.Comment{}).Where("incident_id = ?", filter.IncidentID)
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.StepID != "" {
+		query = query.Where("incident_guidance_step_id = ?", filter.StepID)
+	}
+	if !filter.IncludeDeleted {
+		query = query.Where("deleted_at IS NULL")
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+	if err := query.Preload("Author").Preload("Mentions.User").Order("created_at").Limit(filter.Limit).Offset(filter.Offset).Find(&comments).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get comments: %w", err)
+	}
+	return comments, total, nil
+}
+
+// GetByIncidentID returns the comments of an incident that are not deleted, oldest first
+func (r *CommentRepository) GetByIncidentID(ctx context.Context, incidentID string) ([]models.Comment, error) {
+	var comments []models.Comment
+	if err := getDB(ctx, r.db).Preload("Mentions").Where("incident_id = ? AND deleted_at IS NULL", incidentID).Order("created_at").Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	return comments, nil
+}
+
+// Update changes a comment unless it is deleted. It reports false when the comment was deleted.
+func (r *CommentRepository) Update(ctx context.Context, id string, updates map[string]interface{}) (bool, error) {
+	result := getDB(ctx, r.db).Model(&models.Comment{}).Where("id = ? AND deleted_at IS NULL", id).Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update comment: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReplaceMentions replaces the users mentioned in a comment
+func (r *CommentRepository) ReplaceMentions(ctx context.Context, commentID uuid.UUID, userIDs []uuid.UUID) error {
+	db := getDB(ctx, r.db)
+	if err := db.Where("comment_id = ?", commentID).Delete(&models.CommentMention{}).Error; err != nil {
+		return fmt.Errorf("failed to delete comment mentions: %w", err)
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	mentions := make([]models.CommentMention, 0, len(userIDs))
+	for _, userID := range userIDs {
+		mentions = append(mentions, models.CommentMention{CommentID: commentID, UserID: userID})
+	}
+	if err := db.Create(&mentions).Error; err != nil {
+		return fmt.Errorf("failed to create comment mentions: %w", err)
+	}
+	return nil
+}
+
+func (r *CommentRepository) CreateEdit(ctx context.Context, edit *models.CommentEdit) error {
+	if err := getDB(ctx, r.db).Create(edit).Error; err != nil {
+		return fmt.Errorf("failed to create comment edit: %w", err)
+	}
+	return nil
+}
+
+// GetEdits returns the previous versions of a comment, oldest first
+func (r *CommentRepository) GetEdits(ctx context.Context, commentID string) ([]models.CommentEdit, error) {
+	var edits []models.CommentEdit
+	if err := getDB(ctx, r.db).Where("comment_id = ?", commentID).Order("created_at").Find(&edits).Error; err != nil {
+		return nil, fmt.Errorf("failed to get comment edits: %w", err)
+	}
+	return edits, nil
+}
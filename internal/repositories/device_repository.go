@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type DeviceRepository struct {
+	db *gorm.DB
+}
+
+func NewDeviceRepository(db *gorm.DB) *DeviceRepository {
+	return &DeviceRepository{db: db}
+}
+
+func (r *DeviceRepository) Create(ctx context.Context, device *models.Device) error {
+	if err := getDB(ctx, r.db).Create(device).Error; err != nil {
+		return fmt.Errorf("failed to create device: %w", err)
+	}
+	return nil
+}
+
+func (r *DeviceRepository) GetByID(ctx context.Context, id string) (*models.Device, error) {
+	var device models.Device
+	if err := getDB(ctx, r.db).First(&device, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get device: %w", err)
+	}
+	return &device, nil
+}
+
+// GetByAPIKeyHash returns the device owning an API key, with its premise
+func (r *DeviceRepository) GetByAPIKeyHash(ctx context.Context, apiKeyHash string) (*models.Device, error) {
+	var device models.Device
+	if err := getDB(ctx, r.db).Preload("Premise").First(&device, "api_key_hash = ?", apiKeyHash).Error; err != nil {
+		return nil, fmt.Errorf("failed to get device: %w", err)
+	}
+	return &device, nil
+}
+
+func (r *DeviceRepository) GetAll(ctx context.Context) ([]models.Device, error) {
+	var devices []models.Device
+	if err := getDB(ctx, r.db).Order("name").Find(&devices).Error; err != nil {
+		return nil, fmt.Errorf("failed to get devices: %w", err)
+	}
+	return devices, nil
+}
+
+func (r *DeviceRepository) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	if err := getDB(ctx, r.db).Model(&models.Device{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update device: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type DispatchRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewDispatchRuleRepository(db *gorm.DB) *DispatchRuleRepository {
+	return &DispatchRuleRepository{db: db}
+}
+
+func (r *DispatchRuleRepository) Create(ctx context.Context, rule *models.DispatchRule) error {
+	if err := getDB(ctx, r.db).Create(rule).Error; err != nil {
+		return fmt.Errorf("failed to create dispatch rule: %w", err)
+	}
+	return nil
+}
+
+func (r *DispatchRuleRepository) GetByID(ctx context.Context, id string) (*models.DispatchRule, error) {
+	var rule models.DispatchRule
+	if err := getDB(ctx, r.db).First(&rule, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get dispatch rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// GetAll returns every rule, highest priority first
+func (r *DispatchRuleRepository) GetAll(ctx context.Context) ([]models.DispatchRule, error) {
+	var rules []models.DispatchRule
+	if err := getDB(ctx, r.db).Order("priority DESC, created_at").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get dispatch rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetActive returns the active rules, highest priority first
+func (r *DispatchRuleRepository) GetActive(ctx context.Context) ([]models.DispatchRule, error) {
+	var rules []models.DispatchRule
+	if err := getDB(ctx, r.db).Where("active = ?", true).Order("priority DESC, created_at").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get active dispatch rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *DispatchRuleRepository) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	if err := getDB(ctx, r.db).Model(&models.DispatchRule{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update dispatch rule: %w", err)
+	}
+	return nil
+}
+
+func (r *DispatchRuleRepository) Delete(ctx context.Context, id string) error {
+	if err := getDB(ctx, r.db).Where("id = ?", id).Delete(&models.DispatchRule{}).Error; err != nil {
+		return fmt.Errorf("failed to delete dispatch rule: %w", err)
+	}
+	return nil
+}
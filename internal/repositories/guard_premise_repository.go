@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GuardPremiseRepository struct {
+	db *gorm.DB
+}
+
+func NewGuardPremiseRepository(db *gorm.DB) *GuardPremiseRepository {
+	return &GuardPremiseRepository{db: db}
+}
+
+// GuardCandidate is a guard that may be dispatched, with the number of unfinished missions they hold
+type GuardCandidate struct {
+	models.User
+	ActiveMissions int `json:"active_missions"`
+}
+
+func (r *GuardPremiseRepository) Create(ctx context.Context, guardPremise *models.GuardPremise) error {
+	if err := getDB(ctx, r.db).Create(guardPremise).Error; err != nil {
+		return fmt.Errorf("failed to create guard premise: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the coverage of a premise by a guard. It reports false when the guard did not cover the premise.
+func (r *GuardPremiseRepository) Delete(ctx context.Context, premiseID string, userID string) (bool, error) {
+	result := getDB(ctx, r.db).Where("premise_id = ? AND user_id = ?", premiseID, userID).Delete(&models.GuardPremise{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to delete guard premise: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetByPremise returns the guards covering a premise
+func (r *GuardPremiseRepository) GetByPremise(ctx context.Context, premiseID string) ([]models.GuardPremise, error) {
+	var guardPremises []models.GuardPremise
+	if err := getDB(ctx, r.db).Preload("User").Where("premise_id = ?", premiseID).Order("created_at").Find(&guardPremises).Error; err != nil {
+		return nil, fmt.Errorf("failed to get guard premises: %w", err)
+	}
+	return guardPremises, nil
+}
+
+// GetCandidates returns the guards covering any of the premises together with their number of
+// unfinished missions, least busy first
+func (r *GuardPremiseRepository) GetCandidates(ctx context.Context, premiseIDs []uuid.UUID) ([]GuardCandidate, error) {
+	var candidates []GuardCandidate
+	active := []string{models.MissionStatusAssigned, models.MissionStatusAccepted, models.MissionStatusInProgress, models.MissionStatusPaused}
+	if err := getDB(ctx, r.db).Table("users").
+		Select("users.*, COUNT(DISTINCT incident_guidances.id) AS active_missions").
+		Joins("JOIN guard_premises ON guard_premises.user_id = users.id AND guard_premises.premise_id IN ?", premiseIDs).
+		Joins("LEFT JOIN incident_guidances ON incident_guidances.assignee_id = users.id AND incident_guidances.status IN ?", active).
+		Where("users.role = ?", models.RoleGuard).
+		Group("users.id").
+		Order("active_missions, users.name").
+		Scan(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get guard candidates: %w", err)
+	}
+	return candidates, nil
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type GuidanceTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewGuidanceTemplateRepository(db *gorm.DB) *GuidanceTemplateRepository {
+	return &GuidanceTemplateRepository{db: db}
+}
+
+// GuidanceTemplateFilter narrows down the templates returned by GetGuidanceTemplates
+type GuidanceTemplateFilter struct {
+	Category        string
+	IncludeArchived bool
+}
+
+func orderedSteps(db *gorm.DB) *gorm.DB {
+	return db.Order("step_number")
+}
+
+// CreateGuidanceTemplate creates a template together with its steps
+func (r *GuidanceTemplateRepository) CreateGuidanceTemplate(ctx context.Context, template *models.GuidanceTemplate) (*models.GuidanceTemplate, error) {
+	if err := getDB(ctx, r.db).Create(template).Error; err != nil {
+		return nil, fmt.Errorf("failed to create guidance template: %w", err)
+	}
+	return template, nil
+}
+
+func (r *GuidanceTemplateRepository) GetGuidanceTemplateByID(ctx context.Context, id string) (*models.GuidanceTemplate, error) {
+	var template models.GuidanceTemplate
+	if err := getDB(ctx, r.db).Preload("GuidanceSteps", orderedSteps).First(&template, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get guidance template: %w", err)
+	}
+	return &template, nil
+}
+
+// GetGuidanceTemplates returns the current version of every template
+func (r *GuidanceTemplateRepository) GetGuidanceTemplates(ctx context.Context, filter GuidanceTemplateFilter) ([]models.GuidanceTemplate, error) {
+	var templates []models.GuidanceTemplate
+	statuses := []string{models.TemplateStatusPublished}
+	if filter.IncludeArchived {
+		statuses = append(statuses, models.TemplateStatusArchived)
+	}
+	query := getDB(ctx, r.db).Preload("GuidanceSteps", orderedSteps).Where("status IN ?", statuses)
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if err := query.Order("name").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get guidance templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetGuidanceTemplateVersions returns every version of a template, newest first
+func (r *GuidanceTemplateRepository) GetGuidanceTemplateVersions(ctx context.Context, lineageID string) ([]models.GuidanceTemplate, error) {
+	var templates []models.GuidanceTemplate
+	if err := getDB(ctx, r.db).Preload("GuidanceSteps", orderedSteps).Where("lineage_id = ? OR id = ?", lineageID, lineageID).Order("version DESC").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get guidance template versions: %w", err)
+	}
+	return templates, nil
+}
+
+// UpdateStatus changes the status of a template version only if it currently has fromStatus.
+// It reports false when the version did not have the expected status.
+func (r *GuidanceTemplateRepository) UpdateStatus(ctx context.Context, id string, fromStatus string, status string) (bool, error) {
+	result := getDB(ctx, r.db).Model(&models.GuidanceTemplate{}).Where("id = ? AND status = ?", id, fromStatus).Update("status", status)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update guidance template status: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
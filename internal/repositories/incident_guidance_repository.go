@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"scs-guard/internal/models"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -16,7 +17,7 @@ func NewIncidentGuidanceRepository(db *gorm.DB) *IncidentGuidanceRepository {
 	return &IncidentGuidanceRepository{db: db}
 }
 func (r *IncidentGuidanceRepository) CreateIncidentGuidance(ctx context.Context, guidance *models.IncidentGuidance) (*models.IncidentGuidance, error) {
-	result := r.db.WithContext(ctx).Create(guidance)
+	result := getDB(ctx, r.db).Create(guidance)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to assign guidance: %w", result.Error)
 	}
@@ -24,16 +25,110 @@ func (r *IncidentGuidanceRepository) CreateIncidentGuidance(ctx context.Context,
 }
 func (r *IncidentGuidanceRepository) GetIncidentGuidanceByIncidentID(ctx context.Context, incidentID string) (*models.IncidentGuidance, error) {
 	var incidentGuidance models.IncidentGuidance
-	if err := r.db.WithContext(ctx).Preload("Assignee").Preload("Assigner").Preload("Incident").Preload("IncidentGuidanceSteps").First(&incidentGuidance, "incident_id = ?", incidentID).Error; err != nil {
+	if err := getDB(ctx, r.db).Preload("Assignee").Preload("Assigner").Preload("Incident").Preload("IncidentGuidanceSteps").First(&incidentGuidance, "incident_id = ?", incidentID).Error; err != nil {
 		return nil, fmt.Errorf("failed to get incident guidance: %w", err)
 	}
 	return &incidentGuidance, nil
 }
 
+// HasUnfinishedMission reports whether the incident has a mission that is neither completed nor aborted
+func (r *IncidentGuidanceRepository) HasUnfinishedMission(ctx context.Context, incidentID string) (bool, error) {
+	var count int64
+	if err := getDB(ctx, r.db).Model(&models.IncidentGuidance{}).
+		Where("incident_id = ? AND status NOT IN ?", incidentID, []string{models.MissionStatusCompleted, models.MissionStatusAborted}).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check the missions of the incident: %w", err)
+	}
+	return count > 0, nil
+}
+
 func (r *IncidentGuidanceRepository) GetIncidentGuidanceByAssigneeID(ctx context.Context, assigneeID string) ([]models.IncidentGuidance, error) {
 	var incidentGuidance []models.IncidentGuidance
-	if err := r.db.WithContext(ctx).Preload("Assignee").Preload("Assigner").Preload("Incident").Preload("IncidentGuidanceSteps").Find(&incidentGuidance, "assignee_id = ?", assigneeID).Error; err != nil {
+	if err := getDB(ctx, r.db).Preload("Assignee").Preload("Assigner").Preload("Incident").Preload("IncidentGuidanceSteps").Find(&incidentGuidance, "assignee_id = ?", assigneeID).Error; err != nil {
 		return nil, fmt.Errorf("failed to get incident guidance: %w", err)
 	}
 	return incidentGuidance, nil
 }
+
+func (r *IncidentGuidanceRepository) GetIncidentGuidanceByID(ctx context.Context, id string) (*models.IncidentGuidance, error) {
+	var incidentGuidance models.IncidentGuidance
+	if err := getDB(ctx, r.db).Preload("Assignee").Preload("Assigner").Preload("EscalatedTo").Preload("Incident").Preload("IncidentGuidanceSteps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("step_number")
+	}).First(&incidentGuidance, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get incident guidance: %w", err)
+	}
+	return &incidentGuidance, nil
+}
+
+func (r *IncidentGuidanceRepository) GetIncidentGuidanceByAssignerID(ctx context.Context, assignerID string) ([]models.IncidentGuidance, error) {
+	var incidentGuidance []models.IncidentGuidance
+	if err := getDB(ctx, r.db).Preload("Assignee").Preload("Assigner").Preload("Incident").Preload("IncidentGuidanceSteps").Order("updated_at DESC").Find(&incidentGuidance, "assigner_id = ?", assignerID).Error; err != nil {
+		return nil, fmt.Errorf("failed to get incident guidance: %w", err)
+	}
+	return incidentGuidance, nil
+}
+
+// UpdateStatus moves a guidance to a new status only if its current status is one of fromStatuses.
+// It reports false when the guidance was not in an expected status.
+func (r *IncidentGuidanceRepository) UpdateStatus(ctx context.Context, id string, fromStatuses []string, updates map[string]interface{}) (bool, error) {
+	result := getDB(ctx, r.db).Model(&models.IncidentGuidance{}).Where("id = ? AND status IN ?", id, fromStatuses).Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update incident guidance status: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *IncidentGuidanceRepository) UpdateIncidentGuidance(ctx context.Context, id string, updates map[string]interface{}) error {
+	if err := getDB(ctx, r.db).Model(&models.IncidentGuidance{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update incident guidance: %w", err)
+	}
+	return nil
+}
+
+// GetMissionsToEscalate returns the missions that have not been escalated yet and either were not
+// accepted since acceptDeadline, were declined before acceptDeadline and not reassigned, or have had
+// no activity since progressDeadline
+func (r *IncidentGuidanceRepository) GetMissionsToEscalate(ctx context.Context, acceptDeadline time.Time, progressDeadline time.Time) ([]models.IncidentGuidance, error) {
+	var incidentGuidance []models.IncidentGuidance
+	if err := getDB(ctx, r.db).Preload("Assignee").Where("escalated_at IS NULL").
+		Where("(status = ? AND COALESCE(assigned_at, created_at) < ?) OR (status = ? AND COALESCE(declined_at, updated_at) < ?) OR (status IN ? AND COALESCE(last_activity_at, updated_at) < ?)",
+			models.MissionStatusAssigned, acceptDeadline,
+			models.MissionStatusDeclined, acceptDeadline,
+			[]string{models.MissionStatusAccepted, models.MissionStatusInProgress, models.MissionStatusPaused}, progressDeadline).
+		Find(&incidentGuidance).Error; err != nil {
+		return nil, fmt.Errorf("failed to get missions to escalate: %w", err)
+	}
+	return incidentGuidance, nil
+}
+
+// GetNewlyOverdueMissions returns the unfinished missions whose deadline passed before now and
+// that have not been flagged overdue yet
+func (r *IncidentGuidanceRepository) GetNewlyOverdueMissions(ctx context.Context, now time.Time) ([]models.IncidentGuidance, error) {
+	var incidentGuidance []models.IncidentGuidance
+	if err := getDB(ctx, r.db).Where("due_at < ? AND overdue_at IS NULL AND status NOT IN ?", now,
+		[]string{models.MissionStatusCompleted, models.MissionStatusAborted}).Find(&incidentGuidance).Error; err != nil {
+		return nil, fmt.Errorf("failed to get overdue incident guidance: %w", err)
+	}
+	return incidentGuidance, nil
+}
+
+// MarkOverdue flags a mission as overdue. It reports false when the mission was already flagged.
+func (r *IncidentGuidanceRepository) MarkOverdue(ctx context.Context, id string, at time.Time) (bool, error) {
+	result := getDB(ctx, r.db).Model(&models.IncidentGuidance{}).Where("id = ? AND overdue_at IS NULL", id).Update("overdue_at", at)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to mark incident guidance overdue: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetOverdueMissions returns the unfinished missions that are overdue or have an incomplete overdue step
+func (r *IncidentGuidanceRepository) GetOverdueMissions(ctx context.Context) ([]models.IncidentGuidance, error) {
+	var incidentGuidance []models.IncidentGuidance
+	if err := getDB(ctx, r.db).Preload("Assignee").Preload("Assigner").Preload("Incident").Preload("IncidentGuidanceSteps", orderedSteps).
+		Where("status NOT IN ?", []string{models.MissionStatusCompleted, models.MissionStatusAborted}).
+		Where("overdue_at IS NOT NULL OR EXISTS (SELECT 1 FROM incident_guidance_steps WHERE incident_guidance_steps.incident_guidance_id = incident_guidances.id AND incident_guidance_steps.overdue_at IS NOT NULL AND incident_guidance_steps.is_completed = ?)", false).
+		Order("COALESCE(due_at, updated_at)").Find(&incidentGuidance).Error; err != nil {
+		return nil, fmt.Errorf("failed to get overdue incident guidance: %w", err)
+	}
+	return incidentGuidance, nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"scs-guard/internal/models"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -16,30 +18,106 @@ func NewIncidentGuidanceStepRepository(db *gorm.DB) *IncidentGuidanceStepReposit
 	return &IncidentGuidanceStepRepository{db: db}
 }
 func (r *IncidentGuidanceStepRepository) CreateIncidentGuidanceStep(ctx context.Context, guidance *models.IncidentGuidanceStep) (*models.IncidentGuidanceStep, error) {
-	if err := r.db.WithContext(ctx).Create(guidance).Error; err != nil {
+	if err := getDB(ctx, r.db).Create(guidance).Error; err != nil {
 		return nil, fmt.Errorf("failed to assign guidance: %w", err)
 	}
 	return guidance, nil
 }
 
 func (r *IncidentGuidanceStepRepository) CreateIncidentGuidanceSteps(ctx context.Context, incidentGuidanceSteps []models.IncidentGuidanceStep) ([]models.IncidentGuidanceStep, error) {
-	if err := r.db.WithContext(ctx).Create(incidentGuidanceSteps).Error; err != nil {
+	if err := getDB(ctx, r.db).Create(incidentGuidanceSteps).Error; err != nil {
 		return nil, fmt.Errorf("failed to assign guidance: %w", err)
 	}
 	return incidentGuidanceSteps, nil
 }
 
-func (r *IncidentGuidanceStepRepository) UpdateIncidentGuidanceStep(ctx context.Context, id string, isCompleted bool) error {
-	result := r.db.WithContext(ctx).Model(&models.IncidentGuidanceStep{}).Where("id = ?", id).Update("is_completed", isCompleted)
+// CompleteIncidentGuidanceStep marks a step completed, applying updates such as who completed it
+// and the evidence given. It returns false when the step was already completed.
+func (r *IncidentGuidanceStepRepository) CompleteIncidentGuidanceStep(ctx context.Context, id string, updates map[string]interface{}) (bool, error) {
+	updates["is_completed"] = true
+	result := getDB(ctx, r.db).Model(&models.IncidentGuidanceStep{}).Where("id = ? AND is_completed = ?", id, false).Updates(updates)
 	if result.Error != nil {
-		return fmt.Errorf("failed to update guidance step: %w", result.Error)
+		return false, fmt.Errorf("failed to complete guidance step: %w", result.Error)
 	}
-	return nil
+	return result.RowsAffected > 0, nil
 }
+
+// SkipIncidentGuidanceStep marks an active step skipped, applying updates such as who skipped it
+// and why. It returns false when the step was completed or is no longer active.
+func (r *IncidentGuidanceStepRepository) SkipIncidentGuidanceStep(ctx context.Context, id string, updates map[string]interface{}) (bool, error) {
+	updates["state"] = models.StepStateSkipped
+	result := getDB(ctx, r.db).Model(&models.IncidentGuidanceStep{}).Where("id = ? AND is_completed = ? AND state = ?", id, false, models.StepStateActive).Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to skip guidance step: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UndoCompletion marks a step completed at or after since as not completed again and clears what
+// was given when completing it. It returns false when the step is not completed or was completed
+// before since.
+func (r *IncidentGuidanceStepRepository) UndoCompletion(ctx context.Context, id string, since time.Time) (bool, error) {
+	result := getDB(ctx, r.db).Model(&models.IncidentGuidanceStep{}).Where("id = ? AND is_completed = ? AND completed_at >= ?", id, true, since).Updates(map[string]interface{}{
+		"is_completed":    false,
+		"completed_at":    nil,
+		"completed_by_id": nil,
+		"answer":          "",
+		"reading":         nil,
+		"checked_items":   nil,
+	})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to undo guidance step completion: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (r *IncidentGuidanceStepRepository) GetIncidentGuidanceStepByID(ctx context.Context, id string) (*models.IncidentGuidanceStep, error) {
 	var incidentGuidanceStep models.IncidentGuidanceStep
-	if err := r.db.WithContext(ctx).First(&incidentGuidanceStep, "id = ?", id).Error; err != nil {
+	if err := getDB(ctx, r.db).First(&incidentGuidanceStep, "id = ?", id).Error; err != nil {
 		return nil, fmt.Errorf("failed to get incident guidance step: %w", err)
 	}
 	return &incidentGuidanceStep, nil
 }
+
+// CountIncompleteSteps counts the steps of a mission that are neither completed nor skipped
+func (r *IncidentGuidanceStepRepository) CountIncompleteSteps(ctx context.Context, incidentGuidanceID string) (int64, error) {
+	var count int64
+	if err := getDB(ctx, r.db).Model(&models.IncidentGuidanceStep{}).Where("incident_guidance_id = ? AND is_completed = ? AND state <> ?", incidentGuidanceID, false, models.StepStateSkipped).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count incomplete guidance steps: %w", err)
+	}
+	return count, nil
+}
+
+// UpdateStates sets the state of the given steps
+func (r *IncidentGuidanceStepRepository) UpdateStates(ctx context.Context, ids []uuid.UUID, state string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := getDB(ctx, r.db).Model(&models.IncidentGuidanceStep{}).Where("id IN ?", ids).Update("state", state).Error; err != nil {
+		return fmt.Errorf("failed to update guidance step states: %w", err)
+	}
+	return nil
+}
+
+// GetNewlyOverdueSteps returns the active incomplete steps of unfinished missions whose deadline passed
+// before now and that have not been flagged overdue yet
+func (r *IncidentGuidanceStepRepository) GetNewlyOverdueSteps(ctx context.Context, now time.Time) ([]models.IncidentGuidanceStep, error) {
+	var steps []models.IncidentGuidanceStep
+	if err := getDB(ctx, r.db).Preload("IncidentGuidance").
+		Joins("JOIN incident_guidances ON incident_guidances.id = incident_guidance_steps.incident_guidance_id").
+		Where("incident_guidance_steps.due_at < ? AND incident_guidance_steps.overdue_at IS NULL AND incident_guidance_steps.is_completed = ? AND incident_guidance_steps.state = ?", now, false, models.StepStateActive).
+		Where("incident_guidances.status NOT IN ?", []string{models.MissionStatusCompleted, models.MissionStatusAborted}).
+		Find(&steps).Error; err != nil {
+		return nil, fmt.Errorf("failed to get overdue guidance steps: %w", err)
+	}
+	return steps, nil
+}
+
+// MarkOverdue flags a step as overdue. It reports false when the step was already flagged.
+func (r *IncidentGuidanceStepRepository) MarkOverdue(ctx context.Context, id string, at time.Time) (bool, error) {
+	result := getDB(ctx, r.db).Model(&models.IncidentGuidanceStep{}).Where("id = ? AND overdue_at IS NULL", id).Update("overdue_at", at)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to mark guidance step overdue: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"scs-guard/internal/models"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // IncidentMediaRepository is a repository for incident media
@@ -19,8 +21,146 @@ func NewIncidentMediaRepository(db *gorm.DB) *IncidentMediaRepository {
 
 // Create creates a new incident media
 func (r *IncidentMediaRepository) BatchCreate(ctx context.Context, incidentMedias []models.IncidentMedia) error {
-	if err := r.db.WithContext(ctx).Create(incidentMedias).Error; err != nil {
+	if err := getDB(ctx, r.db).Create(incidentMedias).Error; err != nil {
 		return fmt.Errorf("failed to create incident medias: %w", err)
 	}
 	return nil
 }
+
+// GetByIncidentID returns the media of an incident that are not deleted, oldest first
+func (r *IncidentMediaRepository) GetByIncidentID(ctx context.Context, incidentID string) ([]models.IncidentMedia, error) {
+	var incidentMedias []models.IncidentMedia
+	if err := getDB(ctx, r.db).Where("incident_id = ? AND deleted_at IS NULL", incidentID).Order("created_at").Find(&incidentMedias).Error; err != nil {
+		return nil, fmt.Errorf("failed to get incident medias: %w", err)
+	}
+	return incidentMedias, nil
+}
+
+// CountStepEvidence returns the number of photos and signatures uploaded for a mission step that
+// are not deleted
+func (r *IncidentMediaRepository) CountStepEvidence(ctx context.Context, stepID string) (int64, int64, error) {
+	var counts struct {
+		Photos     int64
+		Signatures int64
+	}
+	err := getDB(ctx, r.db).Model(&models.IncidentMedia{}).
+		Select("COUNT(*) FILTER (WHERE media_type = 'image' AND NOT signature) AS photos, COUNT(*) FILTER (WHERE signature) AS signatures").
+		Where("incident_guidance_step_id = ? AND deleted_at IS NULL", stepID).
+		Scan(&counts).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count step evidence: %w", err)
+	}
+	return counts.Photos, counts.Signatures, nil
+}
+
+// GetByID returns a media of an incident
+func (r *IncidentMediaRepository) GetByID(ctx context.Context, incidentID string, id string) (*models.IncidentMedia, error) {
+	var media models.IncidentMedia
+	if err := getDB(ctx, r.db).Where("incident_id = ?", incidentID).First(&media, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get incident media: %w", err)
+	}
+	return &media, nil
+}
+
+// ClaimPendingProcessing returns media waiting to be processed that are not deleted, oldest first, and hides it from
+// other processors until leaseUntil
+func (r *IncidentMediaRepository) ClaimPendingProcessing(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]models.IncidentMedia, error) {
+	var medias []models.IncidentMedia
+	err := getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("processing_status = ? AND deleted_at IS NULL AND (processing_lease_until IS NULL OR processing_lease_until <= ?)", models.MediaProcessingPending, now).
+			Order("created_at, id").Limit(limit).Find(&medias).Error; err != nil {
+			return err
+		}
+		if len(medias) == 0 {
+			return nil
+		}
+		ids := make([]string, 0, len(medias))
+		for _, media := range medias {
+			ids = append(ids, media.ID.String())
+		}
+		return tx.Model(&models.IncidentMedia{}).Where("id IN ?", ids).Update("processing_lease_until", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim incident medias: %w", err)
+	}
+	return medias, nil
+}
+
+// Update updates the given columns of a media
+func (r *IncidentMediaRepository) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	if err := getDB(ctx, r.db).Model(&models.IncidentMedia{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update incident media: %w", err)
+	}
+	return nil
+}
+
+// IncidentMediaFilter narrows down and pages the media returned by GetPage
+type IncidentMediaFilter struct {
+	IncidentID     string
+	MediaType      string
+	StepID         string
+	IncludeDeleted bool
+	Limit          int
+	Offset         int
+}
+
+// GetPage returns a page of the media of an incident, oldest first, with the number of media
+// matching the filter
+func (r *IncidentMediaRepository) GetPage(ctx context.Context, filter IncidentMediaFilter) ([]models.IncidentMedia, int64, error) {
+	var medias []models.IncidentMedia
+	var total int64
+	query := getDB(ctx, r.db).Model(&models.IncidentMedia{}).Where("incident_id = ?", filter.IncidentID)
+	if filter.MediaType != "" {
+		query = query.Where("media_type = ?", filter.MediaType)
+	}
+	if filter.StepID != "" {
+		query = query.Where("incident_guidance_step_id = ?", filter.StepID)
+	}
+	if !filter.IncludeDeleted {
+		query = query.Where("deleted_at IS NULL")
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count incident medias: %w", err)
+	}
+	if err := query.Order("created_at, id").Limit(filter.Limit).Offset(filter.Offset).Find(&medias).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get incident medias: %w", err)
+	}
+	return medias, total, nil
+}
+
+// SoftDelete marks a media deleted unless it already is. It reports false when the media was
+// already deleted.
+func (r *IncidentMediaRepository) SoftDelete(ctx context.Context, id string, updates map[string]interface{}) (bool, error) {
+	result := getDB(ctx, r.db).Model(&models.IncidentMedia{}).Where("id = ? AND deleted_at IS NULL", id).Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to delete incident media: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetPurgeable returns media deleted before the given time whose files were not purged yet
+func (r *IncidentMediaRepository) GetPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]models.IncidentMedia, error) {
+	var medias []models.IncidentMedia
+	if err := getDB(ctx, r.db).Where("deleted_at <= ? AND purged_at IS NULL", deletedBefore).
+		Order("deleted_at").Limit(limit).Find(&medias).Error; err != nil {
+		return nil, fmt.Errorf("failed to get purgeable incident medias: %w", err)
+	}
+	return medias, nil
+}
+
+// GetStoredKeys returns which of the given object keys belong to media whose files were not
+// purged. Media uploaded before object keys were recorded are matched by their file name.
+func (r *IncidentMediaRepository) GetStoredKeys(ctx context.Context, keys []string) ([]string, error) {
+	var medias []models.IncidentMedia
+	if err := getDB(ctx, r.db).Select("object_key", "file_name").
+		Where("purged_at IS NULL AND (object_key IN ? OR (COALESCE(object_key, '') = '' AND file_name IN ?))", keys, keys).
+		Find(&medias).Error; err != nil {
+		return nil, fmt.Errorf("failed to get stored incident media keys: %w", err)
+	}
+	stored := make([]string, 0, len(medias))
+	for i := range medias {
+		stored = append(stored, medias[i].Key())
+	}
+	return stored, nil
+}
@@ -17,26 +17,83 @@ func NewIncidentRepository(db *gorm.DB) *IncidentRepository {
 }
 
 func (r *IncidentRepository) CreateIncident(ctx context.Context, Incident *models.Incident) (*models.Incident, error) {
-	if err := r.db.WithContext(ctx).Create(Incident).Error; err != nil {
+	if err := getDB(ctx, r.db).Create(Incident).Error; err != nil {
 		return nil, fmt.Errorf("failed to create Incident: %w", err)
 	}
 	return Incident, nil
 }
 func (r *IncidentRepository) GetIncidents(ctx context.Context) ([]models.Incident, error) {
 	var Incidents []models.Incident
-	if err := r.db.WithContext(ctx).Find(&Incidents).Error; err != nil {
+	if err := getDB(ctx, r.db).Find(&Incidents).Error; err != nil {
 		return nil, fmt.Errorf("failed to get Incidents: %w", err)
 	}
 	return Incidents, nil
 }
 
+// latestMission loads the most recent mission of an incident, which is the only one that can be unfinished
+func latestMission(db *gorm.DB) *gorm.DB {
+	return db.Where("incident_guidances.created_at = (SELECT MAX(ig.created_at) FROM incident_guidances ig WHERE ig.incident_id = incident_guidances.incident_id)")
+}
+
 func (r *IncidentRepository) GetIncidentByID(ctx context.Context, id string) (*models.Incident, error) {
 	var Incident models.Incident
-	if err := r.db.WithContext(ctx).Preload("IncidentGuidance").
-		Preload("IncidentGuidance.IncidentGuidanceSteps").
+	if err := getDB(ctx, r.db).Preload("Alarm.Premise").Preload("CreatedBy").Preload("ResolvedBy").Preload("IncidentGuidance", latestMission).
+		Preload("IncidentGuidance.IncidentGuidanceSteps", orderedSteps).
 		Preload("IncidentGuidance.Assignee").
 		Preload("IncidentGuidance.Assigner").First(&Incident, "id = ?", id).Error; err != nil {
 		return nil, fmt.Errorf("failed to get Incident: %w", err)
 	}
 	return &Incident, nil
 }
+
+// IncidentFilter narrows down and pages the incidents returned by GetIncidentsPage
+type IncidentFilter struct {
+	Status   string
+	Severity string
+	Limit    int
+	Offset   int
+}
+
+// GetIncidentsPage returns a page of incidents, newest first, with the number of incidents matching the filter
+func (r *IncidentRepository) GetIncidentsPage(ctx context.Context, filter IncidentFilter) ([]models.Incident, int64, error) {
+	var incidents []models.Incident
+	var total int64
+	query := getDB(ctx, r.db).Model(&models.Incident{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Severity != "" {
+		query = query.Where("severity = ?", filter.Severity)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count incidents: %w", err)
+	}
+	if err := query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&incidents).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get incidents: %w", err)
+	}
+	return incidents, total, nil
+}
+
+// UpdateStatus moves an incident to a new status only if its current status is one of fromStatuses.
+// It reports false when the incident did not have one of the expected statuses.
+func (r *IncidentRepository) UpdateStatus(ctx context.Context, id string, fromStatuses []string, updates map[string]interface{}) (bool, error) {
+	result := getDB(ctx, r.db).Model(&models.Incident{}).Where("id = ? AND status IN ?", id, fromStatuses).Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update incident status: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *IncidentRepository) UpdateIncidentStatus(ctx context.Context, id string, status string) error {
+	if err := getDB(ctx, r.db).Model(&models.Incident{}).Where("id = ?", id).Update("status", status).Error; err != nil {
+		return fmt.Errorf("failed to update Incident status: %w", err)
+	}
+	return nil
+}
+
+func (r *IncidentRepository) UpdateIncident(ctx context.Context, id string, updates map[string]interface{}) error {
+	if err := getDB(ctx, r.db).Model(&models.Incident{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update Incident: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// IncidentStatusChangeRepository stores the append-only status history of incidents
+type IncidentStatusChangeRepository struct {
+	db *gorm.DB
+}
+
+func NewIncidentStatusChangeRepository(db *gorm.DB) *IncidentStatusChangeRepository {
+	return &IncidentStatusChangeRepository{db: db}
+}
+
+func (r *IncidentStatusChangeRepository) Create(ctx context.Context, change *models.IncidentStatusChange) error {
+	if err := getDB(ctx, r.db).Create(change).Error; err != nil {
+		return fmt.Errorf("failed to create incident status change: %w", err)
+	}
+	return nil
+}
+
+// GetByIncidentID returns the status history of an incident, oldest first
+func (r *IncidentStatusChangeRepository) GetByIncidentID(ctx context.Context, incidentID string) ([]models.IncidentStatusChange, error) {
+	var changes []models.IncidentStatusChange
+	if err := getDB(ctx, r.db).Preload("Actor").Where("incident_id = ?", incidentID).Order("created_at").Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get incident status changes: %w", err)
+	}
+	return changes, nil
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// MediaCustodyRepository stores the append-only chain of custody of incident media. Entries are
+// never updated or deleted.
+type MediaCustodyRepository struct {
+	db *gorm.DB
+}
+
+func NewMediaCustodyRepository(db *gorm.DB) *MediaCustodyRepository {
+	return &MediaCustodyRepository{db: db}
+}
+
+// BatchCreate records custody entries
+func (r *MediaCustodyRepository) BatchCreate(ctx context.Context, entries []models.MediaCustodyEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := getDB(ctx, r.db).Create(entries).Error; err != nil {
+		return fmt.Errorf("failed to create media custody entries: %w", err)
+	}
+	return nil
+}
+
+// GetByIncidentMediaID returns the chain of custody of a media, oldest first
+func (r *MediaCustodyRepository) GetByIncidentMediaID(ctx context.Context, incidentMediaID string) ([]models.MediaCustodyEntry, error) {
+	var entries []models.MediaCustodyEntry
+	if err := getDB(ctx, r.db).Preload("Actor").Where("incident_media_id = ?", incidentMediaID).Order("created_at").Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get media custody entries: %w", err)
+	}
+	return entries, nil
+}
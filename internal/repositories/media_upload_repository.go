@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MediaUploadRepository struct {
+	db *gorm.DB
+}
+
+func NewMediaUploadRepository(db *gorm.DB) *MediaUploadRepository {
+	return &MediaUploadRepository{db: db}
+}
+
+func (r *MediaUploadRepository) Create(ctx context.Context, upload *models.MediaUpload) error {
+	if err := getDB(ctx, r.db).Create(upload).Error; err != nil {
+		return fmt.Errorf("failed to create media upload: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns an upload with its uploaded parts in part number order
+func (r *MediaUploadRepository) GetByID(ctx context.Context, id string) (*models.MediaUpload, error) {
+	var upload models.MediaUpload
+	if err := getDB(ctx, r.db).Preload("Parts", func(db *gorm.DB) *gorm.DB {
+		return db.Order("part_number")
+	}).First(&upload, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get media upload: %w", err)
+	}
+	return &upload, nil
+}
+
+// Confirm marks a pending upload confirmed and links it to the media it was recorded as. It
+// reports false when the upload was not pending.
+func (r *MediaUploadRepository) Confirm(ctx context.Context, id string, incidentMediaID string) (bool, error) {
+	result := getDB(ctx, r.db).Model(&models.MediaUpload{}).Where("id = ? AND status = ?", id, models.MediaUploadStatusPending).Updates(map[string]interface{}{
+		"status":            models.MediaUploadStatusConfirmed,
+		"incident_media_id": incidentMediaID,
+	})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to confirm media upload: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateStatus moves a pending upload to status. It reports false when the upload was not pending.
+func (r *MediaUploadRepository) UpdateStatus(ctx context.Context, id string, status string) (bool, error) {
+	result := getDB(ctx, r.db).Model(&models.MediaUpload{}).Where("id = ? AND status = ?", id, models.MediaUploadStatusPending).Update("status", status)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update media upload status: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SavePart records an uploaded part of a resumable upload, replacing the part with the same number
+// if it was uploaded again, and pushes back when the upload is discarded
+func (r *MediaUploadRepository) SavePart(ctx context.Context, part *models.MediaUploadPart, expiresAt time.Time) error {
+	db := getDB(ctx, r.db)
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "media_upload_id"}, {Name: "part_number"}},
+		DoUpdates: clause.AssignmentColumns([]string{"etag", "size", "updated_at"}),
+	}).Create(part).Error; err != nil {
+		return fmt.Errorf("failed to save media upload part: %w", err)
+	}
+	if err := db.Model(&models.MediaUpload{}).Where("id = ?", part.MediaUploadID).Update("expires_at", expiresAt).Error; err != nil {
+		return fmt.Errorf("failed to extend media upload: %w", err)
+	}
+	return nil
+}
+
+// GetAbandoned returns up to limit pending uploads that had no activity since before
+func (r *MediaUploadRepository) GetAbandoned(ctx context.Context, before time.Time, limit int) ([]models.MediaUpload, error) {
+	var uploads []models.MediaUpload
+	if err := getDB(ctx, r.db).Where("status = ? AND updated_at < ?", models.MediaUploadStatusPending, before).Order("updated_at").Limit(limit).Find(&uploads).Error; err != nil {
+		return nil, fmt.Errorf("failed to get abandoned media uploads: %w", err)
+	}
+	return uploads, nil
+}
+
+// GetPendingKeys returns which of the given object keys belong to uploads that are still pending
+func (r *MediaUploadRepository) GetPendingKeys(ctx context.Context, keys []string) ([]string, error) {
+	var pending []string
+	if err := getDB(ctx, r.db).Model(&models.MediaUpload{}).Where("status = ? AND object_key IN ?", models.MediaUploadStatusPending, keys).
+		Pluck("object_key", &pending).Error; err != nil {
+		return nil, fmt.Errorf("failed to get pending media upload keys: %w", err)
+	}
+	return pending, nil
+}
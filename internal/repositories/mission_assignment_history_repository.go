@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// MissionAssignmentHistoryRepository stores the append-only assignment history of missions
+type MissionAssignmentHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewMissionAssignmentHistoryRepository(db *gorm.DB) *MissionAssignmentHistoryRepository {
+	return &MissionAssignmentHistoryRepository{db: db}
+}
+
+func (r *MissionAssignmentHistoryRepository) Create(ctx context.Context, history *models.MissionAssignmentHistory) error {
+	if err := getDB(ctx, r.db).Create(history).Error; err != nil {
+		return fmt.Errorf("failed to create mission assignment history: %w", err)
+	}
+	return nil
+}
+
+// GetByIncidentGuidanceID returns the assignment history of a mission, oldest first
+func (r *MissionAssignmentHistoryRepository) GetByIncidentGuidanceID(ctx context.Context, incidentGuidanceID string) ([]models.MissionAssignmentHistory, error) {
+	var histories []models.MissionAssignmentHistory
+	if err := getDB(ctx, r.db).Preload("Actor").Preload("FromUser").Preload("ToUser").Where("incident_guidance_id = ?", incidentGuidanceID).Order("created_at").Find(&histories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get mission assignment history: %w", err)
+	}
+	return histories, nil
+}
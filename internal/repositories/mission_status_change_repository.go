@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// MissionStatusChangeRepository stores the append-only status history of missions
+type MissionStatusChangeRepository struct {
+	db *gorm.DB
+}
+
+func NewMissionStatusChangeRepository(db *gorm.DB) *MissionStatusChangeRepository {
+	return &MissionStatusChangeRepository{db: db}
+}
+
+func (r *MissionStatusChangeRepository) Create(ctx context.Context, change *models.MissionStatusChange) error {
+	if err := getDB(ctx, r.db).Create(change).Error; err != nil {
+		return fmt.Errorf("failed to create mission status change: %w", err)
+	}
+	return nil
+}
+
+// GetByIncidentGuidanceID returns the status history of a mission, oldest first
+func (r *MissionStatusChangeRepository) GetByIncidentGuidanceID(ctx context.Context, incidentGuidanceID string) ([]models.MissionStatusChange, error) {
+	var changes []models.MissionStatusChange
+	if err := getDB(ctx, r.db).Where("incident_guidance_id = ?", incidentGuidanceID).Order("created_at").Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get mission status changes: %w", err)
+	}
+	return changes, nil
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxEventRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxEventRepository(db *gorm.DB) *OutboxEventRepository {
+	return &OutboxEventRepository{db: db}
+}
+
+func (r *OutboxEventRepository) Create(ctx context.Context, event *models.OutboxEvent) error {
+	if err := getDB(ctx, r.db).Create(event).Error; err != nil {
+		return fmt.Errorf("failed to create outbox event: %w", err)
+	}
+	return nil
+}
+
+// ClaimPending returns up to limit pending events due at now, oldest first, and leases them until
+// leaseUntil so concurrent relays skip them. Events whose lease expires without being marked are
+// claimed again.
+func (r *OutboxEventRepository) ClaimPending(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.OutboxStatusPending, now).
+			Order("created_at, id").Limit(limit).Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		ids := make([]string, 0, len(events))
+		for _, event := range events {
+			ids = append(ids, event.ID.String())
+		}
+		return tx.Model(&models.OutboxEvent{}).Where("id IN ?", ids).Update("next_attempt_at", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	return events, nil
+}
+
+func (r *OutboxEventRepository) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	if err := getDB(ctx, r.db).Model(&models.OutboxEvent{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update outbox event: %w", err)
+	}
+	return nil
+}
+
+// DeletePublishedBefore removes events published before the given time and returns how many were removed
+func (r *OutboxEventRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := getDB(ctx, r.db).Where("status = ? AND published_at < ?", models.OutboxStatusPublished, before).Delete(&models.OutboxEvent{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete published outbox events: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type OverdueEventRepository struct {
+	db *gorm.DB
+}
+
+func NewOverdueEventRepository(db *gorm.DB) *OverdueEventRepository {
+	return &OverdueEventRepository{db: db}
+}
+
+func (r *OverdueEventRepository) Create(ctx context.Context, event *models.OverdueEvent) error {
+	if err := getDB(ctx, r.db).Create(event).Error; err != nil {
+		return fmt.Errorf("failed to create overdue event: %w", err)
+	}
+	return nil
+}
+
+// GetByIncidentID returns the overdue events of the missions of an incident, oldest first
+func (r *OverdueEventRepository) GetByIncidentID(ctx context.Context, incidentID string) ([]models.OverdueEvent, error) {
+	var overdueEvents []models.OverdueEvent
+	if err := getDB(ctx, r.db).Where("incident_id = ?", incidentID).Order("created_at").Find(&overdueEvents).Error; err != nil {
+		return nil, fmt.Errorf("failed to get overdue events: %w", err)
+	}
+	return overdueEvents, nil
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type PremiseRepository struct {
+	db *gorm.DB
+}
+
+func NewPremiseRepository(db *gorm.DB) *PremiseRepository {
+	return &PremiseRepository{db: db}
+}
+
+func (r *PremiseRepository) GetByID(ctx context.Context, id string) (*models.Premise, error) {
+	var premise models.Premise
+	if err := getDB(ctx, r.db).First(&premise, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get premise: %w", err)
+	}
+	return &premise, nil
+}
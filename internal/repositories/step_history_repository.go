@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// StepHistoryRepository stores the append-only history of the steps of missions
+type StepHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewStepHistoryRepository(db *gorm.DB) *StepHistoryRepository {
+	return &StepHistoryRepository{db: db}
+}
+
+func (r *StepHistoryRepository) Create(ctx context.Context, history *models.StepHistory) error {
+	if err := getDB(ctx, r.db).Create(history).Error; err != nil {
+		return fmt.Errorf("failed to create step history: %w", err)
+	}
+	return nil
+}
+
+// GetByIncidentGuidanceID returns the history of the steps of a mission, oldest first
+func (r *StepHistoryRepository) GetByIncidentGuidanceID(ctx context.Context, incidentGuidanceID string) ([]models.StepHistory, error) {
+	var histories []models.StepHistory
+	if err := getDB(ctx, r.db).Preload("Actor").Where("incident_guidance_id = ?", incidentGuidanceID).Order("created_at").Find(&histories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get step history: %w", err)
+	}
+	return histories, nil
+}
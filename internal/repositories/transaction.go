@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// TransactionManager runs repository calls inside a shared database transaction
+type TransactionManager struct {
+	db *gorm.DB
+}
+
+func NewTransactionManager(db *gorm.DB) *TransactionManager {
+	return &TransactionManager{db: db}
+}
+
+// WithinTransaction executes fn in a transaction. Repositories called with the
+// context passed to fn use the transaction; it is committed when fn returns nil
+// and rolled back otherwise.
+func (m *TransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return getDB(ctx, m.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// getDB returns the transaction stored in ctx, or db bound to ctx when there is none
+func getDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return db.WithContext(ctx)
+}
+
+// IsNotFound reports whether err was caused by a missing record
+func IsNotFound(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
+
+// IsDuplicateKey reports whether err was caused by a unique constraint violation
+func IsDuplicateKey(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	return err != nil && strings.Contains(err.Error(), "SQLSTATE 23505")
+}
+
+// IsForeignKeyViolation reports whether err was caused by a reference to a missing record
+func IsForeignKeyViolation(err error) bool {
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return true
+	}
+	return err != nil && strings.Contains(err.Error(), "SQLSTATE 23503")
+}
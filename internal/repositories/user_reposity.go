@@ -17,14 +17,14 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 }
 
 func (r *UserRepository) CreateUser(ctx context.Context, User *models.User) (*models.User, error) {
-	if err := r.db.WithContext(ctx).Create(User).Error; err != nil {
+	if err := getDB(ctx, r.db).Create(User).Error; err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 	return User, nil
 }
 func (r *UserRepository) GetUsers(ctx context.Context) ([]models.User, error) {
 	var Users []models.User
-	if err := r.db.WithContext(ctx).Find(&Users).Error; err != nil {
+	if err := getDB(ctx, r.db).Find(&Users).Error; err != nil {
 		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
 	return Users, nil
@@ -32,15 +32,27 @@ func (r *UserRepository) GetUsers(ctx context.Context) ([]models.User, error) {
 
 func (r *UserRepository) GetUserByID(ctx context.Context, id string) (*models.User, error) {
 	var User models.User
-	if err := r.db.WithContext(ctx).First(&User, "id = ?", id).Error; err != nil {
+	if err := getDB(ctx, r.db).First(&User, "id = ?", id).Error; err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	return &User, nil
 }
 func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	var User models.User
-	if err := r.db.WithContext(ctx).First(&User, "email = ?", email).Error; err != nil {
+	if err := getDB(ctx, r.db).First(&User, "email = ?", email).Error; err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	return &User, nil
 }
+
+// GetUsersByIDs returns the users with the given ids; unknown ids are ignored
+func (r *UserRepository) GetUsersByIDs(ctx context.Context, ids []string) ([]models.User, error) {
+	var users []models.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	if err := getDB(ctx, r.db).Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	return users, nil
+}
@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// WebhookDeliveryFilter narrows down and pages the deliveries returned by GetDeliveries
+type WebhookDeliveryFilter struct {
+	Status string
+	Limit  int
+	Offset int
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	if err := getDB(ctx, r.db).Create(subscription).Error; err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) GetSubscriptionByID(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	if err := getDB(ctx, r.db).First(&subscription, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	return &subscription, nil
+}
+
+func (r *WebhookRepository) GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	if err := getDB(ctx, r.db).Order("created_at").Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookRepository) GetActiveSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	if err := getDB(ctx, r.db).Where("active = ?", true).Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get active webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookRepository) UpdateSubscription(ctx context.Context, id string, updates map[string]interface{}) error {
+	if err := getDB(ctx, r.db).Model(&models.WebhookSubscription{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// DeleteSubscription deletes a subscription and its delivery log
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id string) error {
+	db := getDB(ctx, r.db)
+	if err := db.Where("subscription_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+		return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+	if err := db.Where("id = ?", id).Delete(&models.WebhookSubscription{}).Error; err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// CreateDeliveries queues deliveries, skipping those already queued for the same subscription and
+// event so a redelivered event is only sent once per subscription
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if err := getDB(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) GetDeliveryByID(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := getDB(ctx, r.db).First(&delivery, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+// GetDeliveries returns the deliveries of a subscription, newest first
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, subscriptionID string, filter WebhookDeliveryFilter) ([]models.WebhookDelivery, int64, error) {
+	var deliveries []models.WebhookDelivery
+	var total int64
+	query := getDB(ctx, r.db).Model(&models.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+	if err := query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&deliveries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+// ClaimPendingDeliveries returns up to limit pending deliveries due at now with their subscription,
+// oldest first, and leases them until leaseUntil so concurrent workers skip them
+func (r *WebhookRepository) ClaimPendingDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var due []models.WebhookDelivery
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+			Order("created_at, id").Limit(limit).Find(&due).Error; err != nil {
+			return err
+		}
+		if len(due) == 0 {
+			return nil
+		}
+		ids := make([]string, 0, len(due))
+		for _, delivery := range due {
+			ids = append(ids, delivery.ID.String())
+		}
+		if err := tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", leaseUntil).Error; err != nil {
+			return err
+		}
+		return tx.Preload("Subscription").Where("id IN ?", ids).Order("created_at, id").Find(&deliveries).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, id string, updates map[string]interface{}) error {
+	if err := getDB(ctx, r.db).Model(&models.WebhookDelivery{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	controller "scs-guard/internal/controllers"
 
 	middleware "scs-guard/internal/middlewares"
+	"scs-guard/pkg/storage"
 
 	"github.com/labstack/echo/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
@@ -15,6 +16,14 @@ import (
 func (s *Server) MapHandlers(e *echo.Echo) error {
 	// Init handlers
 	missionHandler := controller.NewMissionHandler(*s.deps.MissionService)
+	incidentHandler := controller.NewIncidentHandler(*s.deps.IncidentService)
+	commentHandler := controller.NewCommentHandler(*s.deps.CommentService)
+	mediaHandler := controller.NewMediaHandler(*s.deps.MediaService)
+	templateHandler := controller.NewTemplateHandler(*s.deps.TemplateService)
+	eventHandler := controller.NewEventHandler(*s.deps.MissionService, s.cfg.Events)
+	webhookHandler := controller.NewWebhookHandler(*s.deps.WebhookService)
+	alarmHandler := controller.NewAlarmHandler(*s.deps.AlarmService)
+	dispatchHandler := controller.NewDispatchHandler(*s.deps.DispatchService)
 
 	mw := middleware.NewMiddlewareManager(s.cfg, []string{"*"}, s.logger)
 	e.Use(mw.RequestLoggerMiddleware)
@@ -28,6 +37,13 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	health := v1.Group("/health")
 	missionGroup := v1.Group("/missions", mw.JWTAuth)
+	incidentGroup := v1.Group("/incidents", mw.JWTAuth)
+	templateGroup := v1.Group("/templates", mw.JWTAuth)
+	eventGroup := v1.Group("/events", mw.JWTAuth)
+	webhookGroup := v1.Group("/webhooks", mw.JWTAuth)
+	dispatchGroup := v1.Group("/dispatch", mw.JWTAuth)
+	// alarms are sent by devices, which authenticate with an API key instead of a JWT
+	alarmGroup := v1.Group("/alarms")
 
 	// Health check endpoint
 	// @Summary Health check
@@ -40,7 +56,19 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 	health.GET("", func(c echo.Context) error {
 		return c.JSON(http.StatusOK, map[string]string{"status": "OK"})
 	})
-	missionHandler.RegisterRoutes(missionGroup)
+	missionHandler.RegisterRoutes(missionGroup, mw)
+	incidentHandler.RegisterRoutes(incidentGroup, mw)
+	commentHandler.RegisterRoutes(incidentGroup, mw)
+	mediaHandler.RegisterRoutes(incidentGroup, mw)
+	templateHandler.RegisterRoutes(templateGroup, mw)
+	eventHandler.RegisterRoutes(eventGroup, mw)
+	webhookHandler.RegisterRoutes(webhookGroup, mw)
+	alarmHandler.RegisterRoutes(alarmGroup, mw)
+	dispatchHandler.RegisterRoutes(dispatchGroup, mw)
+	// files of the local storage backend are up- and downloaded through the API with presigned URLs
+	if local, ok := s.deps.Storage.(*storage.LocalStorage); ok {
+		controller.NewStorageHandler(local).RegisterRoutes(v1.Group("/storage"), mw)
+	}
 
 	return nil
 
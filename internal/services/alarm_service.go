@@ -0,0 +1,455 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	config "scs-guard/config"
+	"scs-guard/internal/dto"
+	"scs-guard/internal/models"
+	repositories "scs-guard/internal/repositories"
+	"scs-guard/pkg/errors"
+	"scs-guard/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// apiKeyPrefixLength is how much of a device API key is kept in clear to recognise it
+const apiKeyPrefixLength = 9
+
+// severityRank orders severities from least to most severe
+var severityRank = map[string]int{"low": 1, "medium": 2, "high": 3}
+
+// AlarmService ingests alarms from devices and correlates them into incidents. Repeats of an
+// alarm from the same premise and type within the dedupe window are counted on the first alarm;
+// other alarms join the open incident of a related recent alarm or open a new incident, which is
+// then dispatched once the alarm and the incident are stored.
+type AlarmService struct {
+	alarmRepo    repositories.AlarmRepository
+	deviceRepo   repositories.DeviceRepository
+	ruleRepo     repositories.AlarmCorrelationRuleRepository
+	incidentRepo repositories.IncidentRepository
+	incidents    *IncidentService
+	txManager    repositories.TransactionManager
+	dispatcher   *DispatchService
+	cfg          config.AlarmConfig
+	logger       logger.Logger
+}
+
+func NewAlarmService(alarmRepo repositories.AlarmRepository, deviceRepo repositories.DeviceRepository, ruleRepo repositories.AlarmCorrelationRuleRepository, incidentRepo repositories.IncidentRepository, incidents *IncidentService, txManager repositories.TransactionManager, dispatcher *DispatchService, cfg config.AlarmConfig, logger logger.Logger) *AlarmService {
+	return &AlarmService{
+		alarmRepo:    alarmRepo,
+		deviceRepo:   deviceRepo,
+		ruleRepo:     ruleRepo,
+		incidentRepo: incidentRepo,
+		incidents:    incidents,
+		txManager:    txManager,
+		dispatcher:   dispatcher,
+		cfg:          cfg,
+		logger:       logger,
+	}
+}
+
+// correlation is the effective correlation configuration for an alarm
+type correlation struct {
+	dedupeWindow      time.Duration
+	correlationWindow time.Duration
+	acrossTypes       bool
+	severities        map[string]string
+}
+
+// IngestAlarm stores an alarm sent by a device and attaches it to an incident
+func (s *AlarmService) IngestAlarm(ctx context.Context, device *models.Device, ingestDto dto.IngestAlarmDto) (*dto.AlarmIngestionResultDto, error) {
+	rules, err := s.ruleRepo.GetActive(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get alarm correlation rules", err)
+	}
+	settings := s.resolveCorrelation(rules, device.PremiseID, ingestDto.Type)
+	triggeredAt := time.Now()
+	if ingestDto.TriggeredAt != nil {
+		triggeredAt = *ingestDto.TriggeredAt
+	}
+	premiseID := device.PremiseID.String()
+
+	result := &dto.AlarmIngestionResultDto{}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.alarmRepo.LockBurst(ctx, premiseID, ingestDto.Type); err != nil {
+			return errors.NewDatabaseError("lock alarm burst", err)
+		}
+
+		if settings.dedupeWindow > 0 {
+			latest, err := s.alarmRepo.GetLatestInBurst(ctx, premiseID, ingestDto.Type, triggeredAt.Add(-settings.dedupeWindow))
+			if err != nil && !repositories.IsNotFound(err) {
+				return errors.NewDatabaseError("get alarm burst", err)
+			}
+			if latest != nil {
+				return s.countRepeat(ctx, latest, ingestDto, triggeredAt, result)
+			}
+		}
+
+		alarm := &models.Alarm{
+			PremiseID:       device.PremiseID,
+			DeviceID:        &device.ID,
+			Type:            ingestDto.Type,
+			Description:     ingestDto.Description,
+			Severity:        ingestDto.Severity,
+			TriggeredAt:     triggeredAt,
+			LastTriggeredAt: triggeredAt,
+			Occurrences:     1,
+		}
+		if err := s.alarmRepo.Create(ctx, alarm); err != nil {
+			return errors.NewDatabaseError("create alarm", err)
+		}
+		incident, created, err := s.correlate(ctx, alarm, device, settings)
+		if err != nil {
+			return err
+		}
+		alarm.IncidentID = &incident.ID
+		result.Alarm = *alarm
+		result.Incident = incident
+		result.IncidentCreated = created
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !result.IncidentCreated {
+		return result, nil
+	}
+
+	// New incidents are dispatched after the alarm and the incident are committed, so a failed
+	// dispatch never loses the alarm; the incident is left for an operator to dispatch instead
+	result.Dispatch, err = s.dispatcher.DispatchIncident(ctx, result.Incident, dto.DispatchAttributesDto{
+		AlarmType: result.Alarm.Type,
+		Severity:  result.Incident.Severity,
+		PremiseID: premiseID,
+		At:        &triggeredAt,
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to dispatch incident %s opened by alarm %s: %v", result.Incident.ID, result.Alarm.ID, err)
+		result.DispatchError = err.Error()
+		if appErr, ok := errors.IsAppError(err); ok {
+			result.DispatchError = appErr.Message
+		}
+		if err := s.incidents.recordDispatchFailed(ctx, result.Incident, result.Alarm.ID, result.DispatchError); err != nil {
+			s.logger.Errorf("Failed to record the failed dispatch of incident %s: %v", result.Incident.ID, err)
+		}
+	}
+	return result, nil
+}
+
+// countRepeat counts an alarm as a repeat of the latest alarm of its burst, raising the burst
+// severity and the severity of its incident when the repeat is more severe
+func (s *AlarmService) countRepeat(ctx context.Context, latest *models.Alarm, ingestDto dto.IngestAlarmDto, triggeredAt time.Time, result *dto.AlarmIngestionResultDto) error {
+	updates := map[string]interface{}{"occurrences": latest.Occurrences + 1}
+	if triggeredAt.After(latest.LastTriggeredAt) {
+		updates["last_triggered_at"] = triggeredAt
+		latest.LastTriggeredAt = triggeredAt
+	}
+	if severityRank[ingestDto.Severity] > severityRank[latest.Severity] {
+		updates["severity"] = ingestDto.Severity
+		latest.Severity = ingestDto.Severity
+	}
+	if err := s.alarmRepo.Update(ctx, latest.ID.String(), updates); err != nil {
+		return errors.NewDatabaseError("update alarm", err)
+	}
+	latest.Occurrences++
+	result.Alarm = *latest
+	result.Deduplicated = true
+	if latest.IncidentID == nil {
+		return nil
+	}
+	incident, err := s.incidentRepo.GetIncidentByID(ctx, latest.IncidentID.String())
+	if err != nil {
+		return errors.NewDatabaseError("get incident", err)
+	}
+	result.Incident = incident
+	return nil
+}
+
+// correlate attaches a new alarm to the open incident of a related recent alarm, or opens a new
+// incident for it. It returns the incident and whether it was created. It must run inside a transaction.
+func (s *AlarmService) correlate(ctx context.Context, alarm *models.Alarm, device *models.Device, settings correlation) (*models.Incident, bool, error) {
+	severity := settings.severities[alarm.Severity]
+	alarmType := alarm.Type
+	if settings.acrossTypes {
+		alarmType = ""
+	}
+
+	if settings.correlationWindow > 0 {
+		incident, err := s.alarmRepo.GetOpenIncident(ctx, alarm.PremiseID.String(), alarmType, alarm.TriggeredAt.Add(-settings.correlationWindow))
+		if err != nil && !repositories.IsNotFound(err) {
+			return nil, false, errors.NewDatabaseError("get open incident", err)
+		}
+		if incident != nil {
+			if err := s.alarmRepo.Update(ctx, alarm.ID.String(), map[string]interface{}{"incident_id": incident.ID}); err != nil {
+				return nil, false, errors.NewDatabaseError("attach alarm to incident", err)
+			}
+			if severityRank[severity] > severityRank[incident.Severity] {
+				if err := s.incidentRepo.UpdateIncident(ctx, incident.ID.String(), map[string]interface{}{"severity": severity}); err != nil {
+					return nil, false, errors.NewDatabaseError("raise incident severity", err)
+				}
+				incident.Severity = severity
+			}
+			return incident, false, nil
+		}
+	}
+
+	incident := &models.Incident{
+		Name:        fmt.Sprintf("%s alarm", alarm.Type),
+		Description: alarm.Description,
+		AlarmID:     &alarm.ID,
+		Status:      models.IncidentStatusNew,
+		Severity:    severity,
+	}
+	if device.Premise != nil {
+		incident.Name = fmt.Sprintf("%s alarm at %s", alarm.Type, device.Premise.Name)
+		incident.Location = device.Premise.Address
+	}
+	if _, err := s.incidentRepo.CreateIncident(ctx, incident); err != nil {
+		return nil, false, errors.NewDatabaseError("create incident", err)
+	}
+	if err := s.incidents.recordOpened(ctx, incident, nil); err != nil {
+		return nil, false, err
+	}
+	if err := s.alarmRepo.Update(ctx, alarm.ID.String(), map[string]interface{}{"incident_id": incident.ID}); err != nil {
+		return nil, false, errors.NewDatabaseError("attach alarm to incident", err)
+	}
+	return incident, true, nil
+}
+
+// resolveCorrelation returns the settings of the highest priority rule matching the premise and
+// alarm type, falling back to the configured defaults. rules must be ordered by priority.
+func (s *AlarmService) resolveCorrelation(rules []models.AlarmCorrelationRule, premiseID uuid.UUID, alarmType string) correlation {
+	for _, rule := range rules {
+		if rule.AlarmType != "" && rule.AlarmType != alarmType {
+			continue
+		}
+		if rule.PremiseID != nil && *rule.PremiseID != premiseID {
+			continue
+		}
+		return correlation{
+			dedupeWindow:      time.Duration(rule.DedupeWindowSeconds) * time.Second,
+			correlationWindow: time.Duration(rule.CorrelationWindowSeconds) * time.Second,
+			acrossTypes:       rule.CorrelateAcrossTypes,
+			severities: map[string]string{
+				"low":    orDefault(rule.SeverityLow, "low"),
+				"medium": orDefault(rule.SeverityMedium, "medium"),
+				"high":   orDefault(rule.SeverityHigh, "high"),
+			},
+		}
+	}
+	return correlation{
+		dedupeWindow:      s.cfg.DedupeWindow,
+		correlationWindow: s.cfg.CorrelationWindow,
+		severities:        map[string]string{"low": "low", "medium": "medium", "high": "high"},
+	}
+}
+
+// AuthenticateDevice returns the active device owning an API key
+func (s *AlarmService) AuthenticateDevice(ctx context.Context, apiKey string) (*models.Device, error) {
+	device, err := s.deviceRepo.GetByAPIKeyHash(ctx, hashAPIKey(apiKey))
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewUnauthorizedError("invalid api key")
+		}
+		return nil, errors.NewDatabaseError("get device", err)
+	}
+	if !device.Active {
+		return nil, errors.NewUnauthorizedError("device is deactivated")
+	}
+	now := time.Now()
+	if err := s.deviceRepo.Update(ctx, device.ID.String(), map[string]interface{}{"last_seen_at": now}); err == nil {
+		device.LastSeenAt = &now
+	}
+	return device, nil
+}
+
+// CreateDevice registers a device for a premise and issues its API key. The key is only returned here.
+func (s *AlarmService) CreateDevice(ctx context.Context, createDto dto.CreateDeviceDto) (*dto.DeviceAPIKeyDto, error) {
+	premiseID, err := uuid.Parse(createDto.PremiseID)
+	if err != nil {
+		return nil, errors.NewBadRequestError("invalid premise id")
+	}
+	apiKey, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	device := &models.Device{
+		Name:       createDto.Name,
+		PremiseID:  premiseID,
+		APIKeyHash: hashAPIKey(apiKey),
+		KeyPrefix:  apiKey[:apiKeyPrefixLength],
+		Active:     true,
+	}
+	if err := s.deviceRepo.Create(ctx, device); err != nil {
+		if repositories.IsForeignKeyViolation(err) {
+			return nil, errors.NewNotFoundError("premise")
+		}
+		return nil, errors.NewDatabaseError("create device", err)
+	}
+	return &dto.DeviceAPIKeyDto{Device: *device, APIKey: apiKey}, nil
+}
+
+func (s *AlarmService) GetDevices(ctx context.Context) ([]models.Device, error) {
+	devices, err := s.deviceRepo.GetAll(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get devices", err)
+	}
+	return devices, nil
+}
+
+// RotateDeviceKey issues a new API key for a device, invalidating the previous one
+func (s *AlarmService) RotateDeviceKey(ctx context.Context, id string) (*dto.DeviceAPIKeyDto, error) {
+	device, err := s.getDevice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	apiKey, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	device.APIKeyHash = hashAPIKey(apiKey)
+	device.KeyPrefix = apiKey[:apiKeyPrefixLength]
+	if err := s.deviceRepo.Update(ctx, id, map[string]interface{}{
+		"api_key_hash": device.APIKeyHash,
+		"key_prefix":   device.KeyPrefix,
+	}); err != nil {
+		return nil, errors.NewDatabaseError("rotate device key", err)
+	}
+	return &dto.DeviceAPIKeyDto{Device: *device, APIKey: apiKey}, nil
+}
+
+// SetDeviceActive activates or deactivates a device; deactivated devices cannot send alarms
+func (s *AlarmService) SetDeviceActive(ctx context.Context, id string, active bool) (*models.Device, error) {
+	if _, err := s.getDevice(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.deviceRepo.Update(ctx, id, map[string]interface{}{"active": active}); err != nil {
+		return nil, errors.NewDatabaseError("update device", err)
+	}
+	return s.getDevice(ctx, id)
+}
+
+func (s *AlarmService) getDevice(ctx context.Context, id string) (*models.Device, error) {
+	device, err := s.deviceRepo.GetByID(ctx, id)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("device")
+		}
+		return nil, errors.NewDatabaseError("get device", err)
+	}
+	return device, nil
+}
+
+func (s *AlarmService) CreateRule(ctx context.Context, ruleDto dto.AlarmCorrelationRuleDto) (*models.AlarmCorrelationRule, error) {
+	rule := &models.AlarmCorrelationRule{}
+	if err := applyRuleDto(rule, ruleDto); err != nil {
+		return nil, err
+	}
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, errors.NewDatabaseError("create alarm correlation rule", err)
+	}
+	return s.GetRule(ctx, rule.ID.String())
+}
+
+func (s *AlarmService) GetRules(ctx context.Context) ([]models.AlarmCorrelationRule, error) {
+	rules, err := s.ruleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get alarm correlation rules", err)
+	}
+	return rules, nil
+}
+
+func (s *AlarmService) GetRule(ctx context.Context, id string) (*models.AlarmCorrelationRule, error) {
+	rule, err := s.ruleRepo.GetByID(ctx, id)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("alarm correlation rule")
+		}
+		return nil, errors.NewDatabaseError("get alarm correlation rule", err)
+	}
+	return rule, nil
+}
+
+func (s *AlarmService) UpdateRule(ctx context.Context, id string, ruleDto dto.AlarmCorrelationRuleDto) (*models.AlarmCorrelationRule, error) {
+	rule, err := s.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyRuleDto(rule, ruleDto); err != nil {
+		return nil, err
+	}
+	if err := s.ruleRepo.Update(ctx, id, map[string]interface{}{
+		"name":                       rule.Name,
+		"priority":                   rule.Priority,
+		"alarm_type":                 rule.AlarmType,
+		"premise_id":                 rule.PremiseID,
+		"dedupe_window_seconds":      rule.DedupeWindowSeconds,
+		"correlation_window_seconds": rule.CorrelationWindowSeconds,
+		"correlate_across_types":     rule.CorrelateAcrossTypes,
+		"severity_low":               rule.SeverityLow,
+		"severity_medium":            rule.SeverityMedium,
+		"severity_high":              rule.SeverityHigh,
+		"active":                     rule.Active,
+	}); err != nil {
+		return nil, errors.NewDatabaseError("update alarm correlation rule", err)
+	}
+	return s.GetRule(ctx, id)
+}
+
+func (s *AlarmService) DeleteRule(ctx context.Context, id string) error {
+	if _, err := s.GetRule(ctx, id); err != nil {
+		return err
+	}
+	if err := s.ruleRepo.Delete(ctx, id); err != nil {
+		return errors.NewDatabaseError("delete alarm correlation rule", err)
+	}
+	return nil
+}
+
+// applyRuleDto copies a rule request onto a rule, defaulting the severity mapping to identity
+func applyRuleDto(rule *models.AlarmCorrelationRule, ruleDto dto.AlarmCorrelationRuleDto) error {
+	rule.Name = ruleDto.Name
+	rule.Priority = ruleDto.Priority
+	rule.AlarmType = ruleDto.AlarmType
+	rule.PremiseID = nil
+	if ruleDto.PremiseID != nil && *ruleDto.PremiseID != "" {
+		premiseID, err := uuid.Parse(*ruleDto.PremiseID)
+		if err != nil {
+			return errors.NewBadRequestError("invalid premise id")
+		}
+		rule.PremiseID = &premiseID
+	}
+	rule.DedupeWindowSeconds = ruleDto.DedupeWindowSeconds
+	rule.CorrelationWindowSeconds = ruleDto.CorrelationWindowSeconds
+	rule.CorrelateAcrossTypes = ruleDto.CorrelateAcrossTypes
+	rule.SeverityLow = orDefault(ruleDto.SeverityLow, "low")
+	rule.SeverityMedium = orDefault(ruleDto.SeverityMedium, "medium")
+	rule.SeverityHigh = orDefault(ruleDto.SeverityHigh, "high")
+	rule.Active = ruleDto.Active == nil || *ruleDto.Active
+	return nil
+}
+
+func orDefault(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func hashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateAPIKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return "dk_" + hex.EncodeToString(key), nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"scs-guard/config"
+	"scs-guard/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestResolveCorrelation(t *testing.T) {
+	s := &AlarmService{cfg: config.AlarmConfig{DedupeWindow: time.Minute, CorrelationWindow: 30 * time.Minute}}
+	premise := uuid.New()
+	other := uuid.New()
+	// rules are ordered by priority, as returned by the repository
+	rules := []models.AlarmCorrelationRule{
+		{Name: "premise fire", AlarmType: "fire", PremiseID: &premise, DedupeWindowSeconds: 10, SeverityLow: "high", SeverityMedium: "high", SeverityHigh: "high"},
+		{Name: "any fire", AlarmType: "fire", CorrelationWindowSeconds: 60, CorrelateAcrossTypes: true},
+		{Name: "other premise", PremiseID: &other, DedupeWindowSeconds: 5},
+	}
+
+	tests := []struct {
+		name        string
+		premiseID   uuid.UUID
+		alarmType   string
+		dedupe      time.Duration
+		correlation time.Duration
+		acrossTypes bool
+		lowMapsTo   string
+	}{
+		{"premise specific rule wins", premise, "fire", 10 * time.Second, 0, false, "high"},
+		{"type rule for other premises", uuid.New(), "fire", 0, time.Minute, true, "low"},
+		{"premise wide rule", other, "intrusion", 5 * time.Second, 0, false, "low"},
+		{"no matching rule uses defaults", premise, "intrusion", time.Minute, 30 * time.Minute, false, "low"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.resolveCorrelation(rules, tt.premiseID, tt.alarmType)
+			if got.dedupeWindow != tt.dedupe {
+				t.Errorf("expected dedupe window %v, got %v", tt.dedupe, got.dedupeWindow)
+			}
+			if got.correlationWindow != tt.correlation {
+				t.Errorf("expected correlation window %v, got %v", tt.correlation, got.correlationWindow)
+			}
+			if got.acrossTypes != tt.acrossTypes {
+				t.Errorf("expected across types %v, got %v", tt.acrossTypes, got.acrossTypes)
+			}
+			if got.severities["low"] != tt.lowMapsTo {
+				t.Errorf("expected low severity to map to %q, got %q", tt.lowMapsTo, got.severities["low"])
+			}
+		})
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	key, err := generateAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := generateAPIKey()
+	if key == other {
+		t.Error("expected distinct api keys")
+	}
+	if len(key) != 67 || key[:3] != "dk_" {
+		t.Errorf("unexpected api key format %q", key)
+	}
+	if hashAPIKey(key) != hashAPIKey(key) || hashAPIKey(key) == hashAPIKey(other) {
+		t.Error("expected api key hash to be deterministic and distinct")
+	}
+}
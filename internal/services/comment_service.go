@@ -0,0 +1,340 @@
+package services
+
+import (
+	"context"
+	"scs-guard/internal/dto"
+	"scs-guard/internal/events"
+	"scs-guard/internal/models"
+	repositories "scs-guard/internal/repositories"
+	"scs-guard/pkg/errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommentService manages the comments left on incidents, their missions and mission steps.
+// Guards can only comment on incidents whose mission is assigned to them; operators and admins
+// can comment on every incident and moderate comments.
+type CommentService struct {
+	commentRepo     repositories.CommentRepository
+	incidentRepo    repositories.IncidentRepository
+	userRepo        repositories.UserRepository
+	outboxEventRepo repositories.OutboxEventRepository
+	txManager       repositories.TransactionManager
+}
+
+func NewCommentService(commentRepo repositories.CommentRepository, incidentRepo repositories.IncidentRepository, userRepo repositories.UserRepository, outboxEventRepo repositories.OutboxEventRepository, txManager repositories.TransactionManager) *CommentService {
+	return &CommentService{
+		commentRepo:     commentRepo,
+		incidentRepo:    incidentRepo,
+		userRepo:        userRepo,
+		outboxEventRepo: outboxEventRepo,
+		txManager:       txManager,
+	}
+}
+
+// CreateComment adds a comment to an incident, its mission or one of the mission steps and
+// notifies the users it mentions
+func (s *CommentService) CreateComment(ctx context.Context, incidentID string, userID string, role string, createDto dto.CreateCommentDto) (*models.Comment, error) {
+	incident, err := s.getIncident(ctx, incidentID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	author, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid user id")
+	}
+	comment, err := commentTarget(incident, createDto.MissionID, createDto.StepID)
+	if err != nil {
+		return nil, err
+	}
+	mentions, err := s.resolveMentions(ctx, createDto.MentionIDs)
+	if err != nil {
+		return nil, err
+	}
+	comment.AuthorID = author
+	comment.Body = createDto.Body
+	for _, mention := range mentions {
+		comment.Mentions = append(comment.Mentions, models.CommentMention{UserID: mention})
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.addComment(ctx, comment, incident.IncidentGuidance)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getComment(ctx, comment.ID.String())
+}
+
+// GetComments returns a page of the comments of an incident, oldest first. Only operators and
+// admins may include deleted comments.
+func (s *CommentService) GetComments(ctx context.Context, incidentID string, userID string, role string, filter repositories.CommentFilter) (*dto.CommentPageDto, error) {
+	if _, err := s.getIncident(ctx, incidentID, userID, role); err != nil {
+		return nil, err
+	}
+	if filter.IncludeDeleted && role == models.RoleGuard {
+		return nil, errors.NewForbiddenError("only operators and admins can read deleted comments")
+	}
+	filter.IncidentID = incidentID
+	comments, total, err := s.commentRepo.GetPage(ctx, filter)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get comments", err)
+	}
+	return &dto.CommentPageDto{Comments: comments, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+// UpdateComment lets the author edit a comment. The previous text is kept in the edit history and
+// the mentions are replaced; users mentioned for the first time are notified.
+func (s *CommentService) UpdateComment(ctx context.Context, incidentID string, commentID string, userID string, role string, updateDto dto.UpdateCommentDto) (*models.Comment, error) {
+	incident, err := s.getIncident(ctx, incidentID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.getIncidentComment(ctx, incident, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID.String() != userID {
+		return nil, errors.NewForbiddenError("only the author can edit this comment")
+	}
+	if comment.DeletedAt != nil {
+		return nil, errors.NewConflictError("comment is deleted")
+	}
+	mentions, err := s.resolveMentions(ctx, updateDto.MentionIDs)
+	if err != nil {
+		return nil, err
+	}
+	var newMentions []*uuid.UUID
+	for i := range mentions {
+		if !isMentioned(comment, mentions[i]) {
+			newMentions = append(newMentions, &mentions[i])
+		}
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.commentRepo.CreateEdit(ctx, &models.CommentEdit{
+			CommentID:    comment.ID,
+			EditorID:     comment.AuthorID,
+			PreviousBody: comment.Body,
+		}); err != nil {
+			return errors.NewDatabaseError("create comment edit", err)
+		}
+		ok, err := s.commentRepo.Update(ctx, commentID, map[string]interface{}{
+			"body":      updateDto.Body,
+			"edited_at": time.Now(),
+		})
+		if err != nil {
+			return errors.NewDatabaseError("update comment", err)
+		}
+		if !ok {
+			return errors.NewConflictError("comment was deleted by another request")
+		}
+		if err := s.commentRepo.ReplaceMentions(ctx, comment.ID, mentions); err != nil {
+			return errors.NewDatabaseError("update comment mentions", err)
+		}
+		comment.Body = updateDto.Body
+		comment.Mentions = nil
+		for _, mention := range mentions {
+			comment.Mentions = append(comment.Mentions, models.CommentMention{CommentID: comment.ID, UserID: mention})
+		}
+		return s.recordEvent(ctx, events.TypeCommentEdited, incident.IncidentGuidance, comment, newMentions)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getComment(ctx, commentID)
+}
+
+// DeleteComment soft deletes a comment. Authors can delete their own comments; operators and
+// admins can delete any comment.
+func (s *CommentService) DeleteComment(ctx context.Context, incidentID string, commentID string, userID string, role string, reason string) (*models.Comment, error) {
+	incident, err := s.getIncident(ctx, incidentID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.getIncidentComment(ctx, incident, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if role == models.RoleGuard && comment.AuthorID.String() != userID {
+		return nil, errors.NewForbiddenError("only the author can delete this comment")
+	}
+	actor, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid user id")
+	}
+	ok, err := s.commentRepo.Update(ctx, commentID, map[string]interface{}{
+		"deleted_at":    time.Now(),
+		"deleted_by_id": actor,
+		"delete_reason": reason,
+	})
+	if err != nil {
+		return nil, errors.NewDatabaseError("delete comment", err)
+	}
+	if !ok {
+		return nil, errors.NewConflictError("comment is already deleted")
+	}
+	return s.getComment(ctx, commentID)
+}
+
+// GetCommentEdits returns the previous versions of a comment, oldest first. The history of
+// deleted comments is only available to operators and admins.
+func (s *CommentService) GetCommentEdits(ctx context.Context, incidentID string, commentID string, userID string, role string) ([]models.CommentEdit, error) {
+	incident, err := s.getIncident(ctx, incidentID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.getIncidentComment(ctx, incident, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.DeletedAt != nil && role == models.RoleGuard {
+		return nil, errors.NewNotFoundError("comment")
+	}
+	edits, err := s.commentRepo.GetEdits(ctx, commentID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get comment edits", err)
+	}
+	return edits, nil
+}
+
+// addComment stores a new comment and notifies the assignee of the mission and the mentioned
+// users. It must run inside a transaction.
+func (s *CommentService) addComment(ctx context.Context, comment *models.Comment, mission *models.IncidentGuidance) error {
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		return errors.NewDatabaseError("create comment", err)
+	}
+	var mentioned []*uuid.UUID
+	for i := range comment.Mentions {
+		mentioned = append(mentioned, &comment.Mentions[i].UserID)
+	}
+	return s.recordEvent(ctx, events.TypeCommentAdded, mission, comment, mentioned)
+}
+
+// recordEvent stores a comment event in the outbox for the assignee of the mission and the users
+// given. It must run inside the transaction making the change.
+func (s *CommentService) recordEvent(ctx context.Context, eventType string, mission *models.IncidentGuidance, comment *models.Comment, users []*uuid.UUID) error {
+	event := events.Event{
+		Type:       eventType,
+		IncidentID: &comment.IncidentID,
+		Data:       comment,
+	}
+	if mission != nil {
+		event.MissionID = &mission.ID
+		users = append(users, mission.AssigneeID)
+	}
+	event.UserIDs = userIDs(users...)
+	outboxEvent, err := newOutboxEvent(event)
+	if err != nil {
+		return err
+	}
+	if err := s.outboxEventRepo.Create(ctx, outboxEvent); err != nil {
+		return errors.NewDatabaseError("create outbox event", err)
+	}
+	return nil
+}
+
+// getIncident loads an incident with its mission and checks that the user may read and write
+// its comments
+func (s *CommentService) getIncident(ctx context.Context, incidentID string, userID string, role string) (*models.Incident, error) {
+	incident, err := s.incidentRepo.GetIncidentByID(ctx, incidentID)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("incident")
+		}
+		return nil, errors.NewDatabaseError("get incident", err)
+	}
+	if role == models.RoleGuard {
+		mission := incident.IncidentGuidance
+		if mission == nil || mission.AssigneeID == nil || mission.AssigneeID.String() != userID {
+			return nil, errors.NewForbiddenError("incident is not assigned to you")
+		}
+	}
+	return incident, nil
+}
+
+func (s *CommentService) getComment(ctx context.Context, commentID string) (*models.Comment, error) {
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("comment")
+		}
+		return nil, errors.NewDatabaseError("get comment", err)
+	}
+	return comment, nil
+}
+
+// getIncidentComment loads a comment and checks that it belongs to the incident
+func (s *CommentService) getIncidentComment(ctx context.Context, incident *models.Incident, commentID string) (*models.Comment, error) {
+	comment, err := s.getComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.IncidentID != incident.ID {
+		return nil, errors.NewNotFoundError("comment")
+	}
+	return comment, nil
+}
+
+// resolveMentions parses the ids of the mentioned users, dropping duplicates, and checks that
+// every user exists
+func (s *CommentService) resolveMentions(ctx context.Context, ids []string) ([]uuid.UUID, error) {
+	var mentions []uuid.UUID
+	var unique []string
+	seen := map[uuid.UUID]bool{}
+	for _, id := range ids {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return nil, errors.NewBadRequestError("invalid mentioned user id " + id)
+		}
+		if seen[parsed] {
+			continue
+		}
+		seen[parsed] = true
+		mentions = append(mentions, parsed)
+		unique = append(unique, id)
+	}
+	users, err := s.userRepo.GetUsersByIDs(ctx, unique)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get mentioned users", err)
+	}
+	if len(users) != len(mentions) {
+		return nil, errors.NewNotFoundError("mentioned user")
+	}
+	return mentions, nil
+}
+
+// commentTarget returns a comment on the step of the incident mission when stepID is set, on the
+// mission when only missionID is set, and on the incident otherwise
+func commentTarget(incident *models.Incident, missionID string, stepID string) (*models.Comment, error) {
+	comment := &models.Comment{IncidentID: incident.ID, TargetType: models.CommentTargetIncident}
+	if missionID == "" && stepID == "" {
+		return comment, nil
+	}
+	mission := incident.IncidentGuidance
+	if mission == nil || (missionID != "" && mission.ID.String() != missionID) {
+		return nil, errors.NewNotFoundError("mission")
+	}
+	comment.TargetType = models.CommentTargetMission
+	comment.IncidentGuidanceID = &mission.ID
+	if stepID == "" {
+		return comment, nil
+	}
+	for i := range mission.IncidentGuidanceSteps {
+		if step := &mission.IncidentGuidanceSteps[i]; step.ID.String() == stepID {
+			comment.TargetType = models.CommentTargetStep
+			comment.IncidentGuidanceStepID = &step.ID
+			return comment, nil
+		}
+	}
+	return nil, errors.NewNotFoundError("step")
+}
+
+func isMentioned(comment *models.Comment, userID uuid.UUID) bool {
+	for _, mention := range comment.Mentions {
+		if mention.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
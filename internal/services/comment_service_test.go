@@ -0,0 +1,55 @@
+package services
+
+import (
+	"scs-guard/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCommentTarget(t *testing.T) {
+	step := models.IncidentGuidanceStep{}
+	step.ID = uuid.New()
+	mission := &models.IncidentGuidance{IncidentGuidanceSteps: []models.IncidentGuidanceStep{step}}
+	mission.ID = uuid.New()
+	incident := &models.Incident{IncidentGuidance: mission}
+	incident.ID = uuid.New()
+
+	tests := []struct {
+		name      string
+		incident  *models.Incident
+		missionID string
+		stepID    string
+		expected  string
+		wantErr   bool
+	}{
+		{"incident", incident, "", "", models.CommentTargetIncident, false},
+		{"mission", incident, mission.ID.String(), "", models.CommentTargetMission, false},
+		{"step", incident, "", step.ID.String(), models.CommentTargetStep, false},
+		{"step of the mission", incident, mission.ID.String(), step.ID.String(), models.CommentTargetStep, false},
+		{"other mission", incident, uuid.NewString(), "", "", true},
+		{"unknown step", incident, "", uuid.NewString(), "", true},
+		{"incident without mission", &models.Incident{}, "", step.ID.String(), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comment, err := commentTarget(tt.incident, tt.missionID, tt.stepID)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got target %s", comment.TargetType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if comment.TargetType != tt.expected {
+				t.Errorf("target = %s, want %s", comment.TargetType, tt.expected)
+			}
+			if tt.expected == models.CommentTargetStep && (comment.IncidentGuidanceStepID == nil || *comment.IncidentGuidanceStepID != step.ID || comment.IncidentGuidanceID == nil) {
+				t.Errorf("expected the step and its mission to be set, got %+v", comment)
+			}
+		})
+	}
+}
@@ -0,0 +1,483 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	config "scs-guard/config"
+	"scs-guard/internal/dto"
+	"scs-guard/internal/models"
+	repositories "scs-guard/internal/repositories"
+	"scs-guard/pkg/errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxPremiseDepth bounds the walk up the premise hierarchy when looking for covering guards
+const maxPremiseDepth = 16
+
+// DispatchService picks the guidance template and the guard for new incidents. Dispatch rules are
+// matched against the incident alarm type, severity, premise and time of day; the highest priority
+// matching rule selects the template, and an available guard covering the premise is assigned
+// when the rule dispatches automatically.
+type DispatchService struct {
+	ruleRepo             repositories.DispatchRuleRepository
+	guardPremiseRepo     repositories.GuardPremiseRepository
+	premiseRepo          repositories.PremiseRepository
+	guidanceTemplateRepo repositories.GuidanceTemplateRepository
+	incidentRepo         repositories.IncidentRepository
+	missions             *MissionService
+	cfg                  config.DispatchConfig
+	location             *time.Location
+}
+
+func NewDispatchService(ruleRepo repositories.DispatchRuleRepository, guardPremiseRepo repositories.GuardPremiseRepository, premiseRepo repositories.PremiseRepository, guidanceTemplateRepo repositories.GuidanceTemplateRepository, incidentRepo repositories.IncidentRepository, missions *MissionService, cfg config.DispatchConfig) (*DispatchService, error) {
+	location, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dispatch time zone %q: %w", cfg.Timezone, err)
+	}
+	return &DispatchService{
+		ruleRepo:             ruleRepo,
+		guardPremiseRepo:     guardPremiseRepo,
+		premiseRepo:          premiseRepo,
+		guidanceTemplateRepo: guidanceTemplateRepo,
+		incidentRepo:         incidentRepo,
+		missions:             missions,
+		cfg:                  cfg,
+		location:             location,
+	}, nil
+}
+
+// Plan evaluates the dispatch rules for incident attributes without changing anything and
+// explains which rule, template and guard were picked
+func (s *DispatchService) Plan(ctx context.Context, attributes dto.DispatchAttributesDto) (*dto.DispatchPlanDto, error) {
+	at := time.Now()
+	if attributes.At != nil {
+		at = *attributes.At
+	}
+	attributes.At = &at
+	local := at.In(s.location)
+
+	rules, err := s.ruleRepo.GetActive(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get dispatch rules", err)
+	}
+	plan := &dto.DispatchPlanDto{
+		Attributes:  attributes,
+		LocalTime:   local.Format("15:04"),
+		Evaluations: make([]dto.DispatchRuleEvaluationDto, 0, len(rules)),
+		Notes:       []string{},
+	}
+	for i := range rules {
+		mismatches := ruleMismatches(rules[i], attributes, local)
+		plan.Evaluations = append(plan.Evaluations, dto.DispatchRuleEvaluationDto{
+			Rule:       rules[i],
+			Matched:    len(mismatches) == 0,
+			Mismatches: mismatches,
+		})
+		if len(mismatches) == 0 && plan.Rule == nil {
+			plan.Rule = &rules[i]
+		}
+	}
+
+	if plan.Rule != nil {
+		plan.Notes = append(plan.Notes, fmt.Sprintf("rule %q matched with priority %d", plan.Rule.Name, plan.Rule.Priority))
+		plan.Template, err = s.ruleTemplate(ctx, plan)
+		plan.AutoAssign = plan.Rule.AutoAssign
+	} else if s.cfg.CategoryFallback && attributes.AlarmType != "" {
+		plan.Notes = append(plan.Notes, "no rule matched, looking for a template in the category of the alarm type")
+		plan.Template, err = s.categoryTemplate(ctx, plan, attributes.AlarmType, true)
+		plan.AutoAssign = true
+	} else {
+		plan.Notes = append(plan.Notes, "no rule matched")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if plan.Template == nil {
+		plan.AutoAssign = false
+		plan.Notes = append(plan.Notes, "no template selected, an operator has to assign the mission")
+		return plan, nil
+	}
+	if !plan.AutoAssign {
+		plan.Notes = append(plan.Notes, "the rule does not dispatch automatically, an operator has to pick the guard")
+		return plan, nil
+	}
+
+	plan.Guard, err = s.pickGuard(ctx, plan, attributes.PremiseID)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Guard == nil {
+		plan.AutoAssign = false
+	}
+	return plan, nil
+}
+
+// ruleTemplate returns the published template selected by the matched rule of a plan
+func (s *DispatchService) ruleTemplate(ctx context.Context, plan *dto.DispatchPlanDto) (*models.GuidanceTemplate, error) {
+	rule := plan.Rule
+	if rule.TemplateLineageID == nil {
+		return s.categoryTemplate(ctx, plan, rule.TemplateCategory, false)
+	}
+	versions, err := s.guidanceTemplateRepo.GetGuidanceTemplateVersions(ctx, rule.TemplateLineageID.String())
+	if err != nil {
+		return nil, errors.NewDatabaseError("get guidance template versions", err)
+	}
+	for i := range versions {
+		if versions[i].Status == models.TemplateStatusPublished {
+			plan.Notes = append(plan.Notes, fmt.Sprintf("template %q version %d selected by the rule", versions[i].Name, versions[i].Version))
+			return &versions[i], nil
+		}
+	}
+	plan.Notes = append(plan.Notes, fmt.Sprintf("template %s of the rule has no published version", rule.TemplateLineageID))
+	return nil, nil
+}
+
+// categoryTemplate returns the first published template of a category, by name
+func (s *DispatchService) categoryTemplate(ctx context.Context, plan *dto.DispatchPlanDto, category string, ignoreCase bool) (*models.GuidanceTemplate, error) {
+	filter := repositories.GuidanceTemplateFilter{Category: category}
+	if ignoreCase {
+		filter.Category = ""
+	}
+	templates, err := s.guidanceTemplateRepo.GetGuidanceTemplates(ctx, filter)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get guidance templates", err)
+	}
+	for i := range templates {
+		if templates[i].Status == models.TemplateStatusPublished && (!ignoreCase || strings.EqualFold(templates[i].Category, category)) {
+			plan.Notes = append(plan.Notes, fmt.Sprintf("template %q selected from category %q", templates[i].Name, templates[i].Category))
+			return &templates[i], nil
+		}
+	}
+	plan.Notes = append(plan.Notes, fmt.Sprintf("no published template in category %q", category))
+	return nil, nil
+}
+
+// pickGuard returns the least busy guard covering the premise or one of its parents who holds
+// fewer unfinished missions than allowed, or nil when there is none
+func (s *DispatchService) pickGuard(ctx context.Context, plan *dto.DispatchPlanDto, premiseID string) (*dto.GuardCandidateDto, error) {
+	if premiseID == "" {
+		plan.Notes = append(plan.Notes, "the incident has no premise, an operator has to pick the guard")
+		return nil, nil
+	}
+	premiseIDs, err := s.premiseChain(ctx, premiseID)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.guardPremiseRepo.GetCandidates(ctx, premiseIDs)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get guard candidates", err)
+	}
+	for _, candidate := range candidates {
+		if candidate.ActiveMissions < s.cfg.MaxActiveMissions {
+			plan.Notes = append(plan.Notes, fmt.Sprintf("guard %s picked with %d unfinished missions", candidate.Name, candidate.ActiveMissions))
+			return &dto.GuardCandidateDto{User: candidate.User, ActiveMissions: candidate.ActiveMissions}, nil
+		}
+	}
+	plan.Notes = append(plan.Notes, fmt.Sprintf("none of the %d guards covering the premise is available, an operator has to pick the guard", len(candidates)))
+	return nil, nil
+}
+
+// premiseChain returns the premise and its parents, closest first
+func (s *DispatchService) premiseChain(ctx context.Context, premiseID string) ([]uuid.UUID, error) {
+	chain := []uuid.UUID{}
+	seen := map[uuid.UUID]bool{}
+	for id := premiseID; id != "" && len(chain) < maxPremiseDepth; {
+		premise, err := s.premiseRepo.GetByID(ctx, id)
+		if err != nil {
+			if repositories.IsNotFound(err) {
+				break
+			}
+			return nil, errors.NewDatabaseError("get premise", err)
+		}
+		if seen[premise.ID] {
+			break
+		}
+		seen[premise.ID] = true
+		chain = append(chain, premise.ID)
+		id = ""
+		if premise.ParentPremiseID != nil {
+			id = premise.ParentPremiseID.String()
+		}
+	}
+	if len(chain) == 0 {
+		if id, err := uuid.Parse(premiseID); err == nil {
+			chain = append(chain, id)
+		}
+	}
+	return chain, nil
+}
+
+// DispatchIncident plans the dispatch of an incident and, when the plan dispatches automatically,
+// creates the mission for the picked guard
+func (s *DispatchService) DispatchIncident(ctx context.Context, incident *models.Incident, attributes dto.DispatchAttributesDto) (*dto.DispatchResultDto, error) {
+	plan, err := s.Plan(ctx, attributes)
+	if err != nil {
+		return nil, err
+	}
+	result := &dto.DispatchResultDto{Plan: *plan}
+	if !plan.AutoAssign {
+		return result, nil
+	}
+	result.Mission, err = s.missions.createMission(ctx, incident, plan.Template, &plan.Guard.User, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DispatchIncidentByID runs dispatch for an existing incident that has no mission yet
+func (s *DispatchService) DispatchIncidentByID(ctx context.Context, incidentID string) (*dto.DispatchResultDto, error) {
+	incident, err := s.getIncident(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if incident.IncidentGuidance != nil {
+		return nil, errors.NewConflictError("the incident already has a mission")
+	}
+	result, err := s.DispatchIncident(ctx, incident, incidentAttributes(incident))
+	if err != nil {
+		return nil, err
+	}
+	if result.Mission != nil {
+		result.Mission, err = s.missions.getMission(ctx, result.Mission.ID.String())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Explain is a dry run of dispatch for an existing incident or for incident attributes
+func (s *DispatchService) Explain(ctx context.Context, explainDto dto.ExplainDispatchDto) (*dto.DispatchPlanDto, error) {
+	attributes := explainDto.DispatchAttributesDto
+	if explainDto.IncidentID != "" {
+		incident, err := s.getIncident(ctx, explainDto.IncidentID)
+		if err != nil {
+			return nil, err
+		}
+		attributes = incidentAttributes(incident)
+		attributes.At = explainDto.At
+	}
+	return s.Plan(ctx, attributes)
+}
+
+func (s *DispatchService) getIncident(ctx context.Context, id string) (*models.Incident, error) {
+	incident, err := s.incidentRepo.GetIncidentByID(ctx, id)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("incident")
+		}
+		return nil, errors.NewDatabaseError("get incident", err)
+	}
+	return incident, nil
+}
+
+// incidentAttributes returns the dispatch attributes of an incident, read from its alarm
+func incidentAttributes(incident *models.Incident) dto.DispatchAttributesDto {
+	attributes := dto.DispatchAttributesDto{Severity: incident.Severity}
+	if incident.Alarm != nil {
+		attributes.AlarmType = incident.Alarm.Type
+		attributes.PremiseID = incident.Alarm.PremiseID.String()
+	}
+	return attributes
+}
+
+// ruleMismatches returns the conditions of a rule that incident attributes do not meet at a local time
+func ruleMismatches(rule models.DispatchRule, attributes dto.DispatchAttributesDto, local time.Time) []string {
+	var mismatches []string
+	if rule.AlarmType != "" && !strings.EqualFold(rule.AlarmType, attributes.AlarmType) {
+		mismatches = append(mismatches, fmt.Sprintf("alarm type is %q, rule requires %q", attributes.AlarmType, rule.AlarmType))
+	}
+	if rule.Severity != "" && rule.Severity != attributes.Severity {
+		mismatches = append(mismatches, fmt.Sprintf("severity is %q, rule requires %q", attributes.Severity, rule.Severity))
+	}
+	if rule.PremiseID != nil && rule.PremiseID.String() != attributes.PremiseID {
+		mismatches = append(mismatches, fmt.Sprintf("premise is %q, rule requires %s", attributes.PremiseID, rule.PremiseID))
+	}
+	if rule.StartTime != "" && !inTimeWindow(rule.StartTime, rule.EndTime, local) {
+		mismatches = append(mismatches, fmt.Sprintf("time is %s, rule requires %s-%s", local.Format("15:04"), rule.StartTime, rule.EndTime))
+	}
+	return mismatches
+}
+
+// inTimeWindow reports whether the time of day of local is within [start, end). The window wraps
+// past midnight when end is before start.
+func inTimeWindow(start string, end string, local time.Time) bool {
+	from, err := time.Parse("15:04", start)
+	if err != nil {
+		return false
+	}
+	to, err := time.Parse("15:04", end)
+	if err != nil {
+		return false
+	}
+	minute := local.Hour()*60 + local.Minute()
+	fromMinute := from.Hour()*60 + from.Minute()
+	toMinute := to.Hour()*60 + to.Minute()
+	if fromMinute <= toMinute {
+		return minute >= fromMinute && minute < toMinute
+	}
+	return minute >= fromMinute || minute < toMinute
+}
+
+func (s *DispatchService) CreateRule(ctx context.Context, ruleDto dto.DispatchRuleDto) (*models.DispatchRule, error) {
+	rule := &models.DispatchRule{}
+	if err := s.applyRuleDto(ctx, rule, ruleDto); err != nil {
+		return nil, err
+	}
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, errors.NewDatabaseError("create dispatch rule", err)
+	}
+	return s.GetRule(ctx, rule.ID.String())
+}
+
+func (s *DispatchService) GetRules(ctx context.Context) ([]models.DispatchRule, error) {
+	rules, err := s.ruleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get dispatch rules", err)
+	}
+	return rules, nil
+}
+
+func (s *DispatchService) GetRule(ctx context.Context, id string) (*models.DispatchRule, error) {
+	rule, err := s.ruleRepo.GetByID(ctx, id)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("dispatch rule")
+		}
+		return nil, errors.NewDatabaseError("get dispatch rule", err)
+	}
+	return rule, nil
+}
+
+func (s *DispatchService) UpdateRule(ctx context.Context, id string, ruleDto dto.DispatchRuleDto) (*models.DispatchRule, error) {
+	rule, err := s.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRuleDto(ctx, rule, ruleDto); err != nil {
+		return nil, err
+	}
+	if err := s.ruleRepo.Update(ctx, id, map[string]interface{}{
+		"name":                rule.Name,
+		"priority":            rule.Priority,
+		"alarm_type":          rule.AlarmType,
+		"severity":            rule.Severity,
+		"premise_id":          rule.PremiseID,
+		"start_time":          rule.StartTime,
+		"end_time":            rule.EndTime,
+		"template_category":   rule.TemplateCategory,
+		"template_lineage_id": rule.TemplateLineageID,
+		"auto_assign":         rule.AutoAssign,
+		"active":              rule.Active,
+	}); err != nil {
+		return nil, errors.NewDatabaseError("update dispatch rule", err)
+	}
+	return s.GetRule(ctx, id)
+}
+
+func (s *DispatchService) DeleteRule(ctx context.Context, id string) error {
+	if _, err := s.GetRule(ctx, id); err != nil {
+		return err
+	}
+	if err := s.ruleRepo.Delete(ctx, id); err != nil {
+		return errors.NewDatabaseError("delete dispatch rule", err)
+	}
+	return nil
+}
+
+// applyRuleDto copies a rule request onto a rule, checking that its template exists
+func (s *DispatchService) applyRuleDto(ctx context.Context, rule *models.DispatchRule, ruleDto dto.DispatchRuleDto) error {
+	if (ruleDto.StartTime == "") != (ruleDto.EndTime == "") {
+		return errors.NewBadRequestError("start_time and end_time must be given together")
+	}
+	rule.Name = ruleDto.Name
+	rule.Priority = ruleDto.Priority
+	rule.AlarmType = ruleDto.AlarmType
+	rule.Severity = ruleDto.Severity
+	rule.StartTime = ruleDto.StartTime
+	rule.EndTime = ruleDto.EndTime
+	rule.TemplateCategory = ruleDto.TemplateCategory
+	rule.AutoAssign = ruleDto.AutoAssign == nil || *ruleDto.AutoAssign
+	rule.Active = ruleDto.Active == nil || *ruleDto.Active
+	rule.PremiseID = nil
+	if ruleDto.PremiseID != nil && *ruleDto.PremiseID != "" {
+		premiseID, err := uuid.Parse(*ruleDto.PremiseID)
+		if err != nil {
+			return errors.NewBadRequestError("invalid premise id")
+		}
+		rule.PremiseID = &premiseID
+	}
+	rule.TemplateLineageID = nil
+	if ruleDto.TemplateLineageID != nil && *ruleDto.TemplateLineageID != "" {
+		versions, err := s.guidanceTemplateRepo.GetGuidanceTemplateVersions(ctx, *ruleDto.TemplateLineageID)
+		if err != nil {
+			return errors.NewDatabaseError("get guidance template versions", err)
+		}
+		if len(versions) == 0 {
+			return errors.NewNotFoundError("guidance template")
+		}
+		lineageID := versions[0].Lineage()
+		rule.TemplateLineageID = &lineageID
+	}
+	return nil
+}
+
+// GetPremiseGuards returns the guards covering a premise
+func (s *DispatchService) GetPremiseGuards(ctx context.Context, premiseID string) ([]models.GuardPremise, error) {
+	if _, err := s.getPremise(ctx, premiseID); err != nil {
+		return nil, err
+	}
+	guardPremises, err := s.guardPremiseRepo.GetByPremise(ctx, premiseID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get premise guards", err)
+	}
+	return guardPremises, nil
+}
+
+// AddPremiseGuard lets a guard cover a premise and its sub-premises
+func (s *DispatchService) AddPremiseGuard(ctx context.Context, premiseID string, addDto dto.AddGuardPremiseDto) (*models.GuardPremise, error) {
+	premise, err := s.getPremise(ctx, premiseID)
+	if err != nil {
+		return nil, err
+	}
+	guard, err := s.missions.getGuard(ctx, addDto.UserID)
+	if err != nil {
+		return nil, err
+	}
+	guardPremise := &models.GuardPremise{UserID: guard.ID, PremiseID: premise.ID}
+	if err := s.guardPremiseRepo.Create(ctx, guardPremise); err != nil {
+		if repositories.IsDuplicateKey(err) {
+			return nil, errors.NewConflictError("the guard already covers this premise")
+		}
+		return nil, errors.NewDatabaseError("create guard premise", err)
+	}
+	guardPremise.User = guard
+	return guardPremise, nil
+}
+
+// RemovePremiseGuard stops a guard from covering a premise
+func (s *DispatchService) RemovePremiseGuard(ctx context.Context, premiseID string, userID string) error {
+	removed, err := s.guardPremiseRepo.Delete(ctx, premiseID, userID)
+	if err != nil {
+		return errors.NewDatabaseError("delete guard premise", err)
+	}
+	if !removed {
+		return errors.NewNotFoundError("guard premise")
+	}
+	return nil
+}
+
+func (s *DispatchService) getPremise(ctx context.Context, id string) (*models.Premise, error) {
+	premise, err := s.premiseRepo.GetByID(ctx, id)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("premise")
+		}
+		return nil, errors.NewDatabaseError("get premise", err)
+	}
+	return premise, nil
+}
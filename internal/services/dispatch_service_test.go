@@ -0,0 +1,69 @@
+package services
+
+import (
+	"scs-guard/internal/dto"
+	"scs-guard/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestInTimeWindow(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name  string
+		start string
+		end   string
+		at    time.Time
+		want  bool
+	}{
+		{"inside day window", "08:00", "18:00", at(12, 0), true},
+		{"start is inclusive", "08:00", "18:00", at(8, 0), true},
+		{"end is exclusive", "08:00", "18:00", at(18, 0), false},
+		{"outside day window", "08:00", "18:00", at(7, 59), false},
+		{"night window before midnight", "22:00", "06:00", at(23, 30), true},
+		{"night window after midnight", "22:00", "06:00", at(3, 0), true},
+		{"outside night window", "22:00", "06:00", at(12, 0), false},
+		{"invalid window", "25:00", "06:00", at(3, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inTimeWindow(tt.start, tt.end, tt.at); got != tt.want {
+				t.Errorf("inTimeWindow(%s, %s, %s) = %v, want %v", tt.start, tt.end, tt.at.Format("15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleMismatches(t *testing.T) {
+	premise := uuid.New()
+	night := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	day := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rule := models.DispatchRule{AlarmType: "fire", Severity: "high", PremiseID: &premise, StartTime: "22:00", EndTime: "06:00"}
+	attributes := dto.DispatchAttributesDto{AlarmType: "Fire", Severity: "high", PremiseID: premise.String()}
+
+	tests := []struct {
+		name       string
+		rule       models.DispatchRule
+		attributes dto.DispatchAttributesDto
+		local      time.Time
+		mismatches int
+	}{
+		{"every condition met", rule, attributes, night, 0},
+		{"empty rule matches anything", models.DispatchRule{}, dto.DispatchAttributesDto{}, day, 0},
+		{"outside time window", rule, attributes, day, 1},
+		{"other severity and premise", rule, dto.DispatchAttributesDto{AlarmType: "fire", Severity: "low", PremiseID: uuid.NewString()}, night, 2},
+		{"no attributes", rule, dto.DispatchAttributesDto{}, day, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ruleMismatches(tt.rule, tt.attributes, tt.local)
+			if len(got) != tt.mismatches {
+				t.Errorf("expected %d mismatches, got %v", tt.mismatches, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	config "scs-guard/config"
+	"scs-guard/internal/dto"
+	"scs-guard/internal/events"
+	"scs-guard/internal/models"
+	repositories "scs-guard/internal/repositories"
+	"scs-guard/pkg/errors"
+	"scs-guard/pkg/storage"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// incidentTransitions lists the statuses an incident may move to from each status.
+// Resolved incidents can be reopened to in progress until they are closed; closed incidents are final.
+var incidentTransitions = map[string][]string{
+	models.IncidentStatusNew:        {models.IncidentStatusInProgress, models.IncidentStatusResolved},
+	models.IncidentStatusInProgress: {models.IncidentStatusResolved},
+	models.IncidentStatusResolved:   {models.IncidentStatusInProgress, models.IncidentStatusClosed},
+}
+
+// canTransitionIncident reports whether an incident may move from one status to another
+func canTransitionIncident(from string, to string) bool {
+	for _, status := range incidentTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IncidentService manages incidents and drives their status workflow. Every status change is
+// recorded in the incident status history and published as an incident.status_changed event.
+type IncidentService struct {
+	incidentRepo            repositories.IncidentRepository
+	statusChangeRepo        repositories.IncidentStatusChangeRepository
+	alarmRepo               repositories.AlarmRepository
+	assignmentHistoryRepo   repositories.MissionAssignmentHistoryRepository
+	missionStatusChangeRepo repositories.MissionStatusChangeRepository
+	stepHistoryRepo         repositories.StepHistoryRepository
+	incidentMediaRepo       repositories.IncidentMediaRepository
+	custodyRepo             repositories.MediaCustodyRepository
+	overdueEventRepo        repositories.OverdueEventRepository
+	userRepo                repositories.UserRepository
+	commentRepo             repositories.CommentRepository
+	outboxEventRepo         repositories.OutboxEventRepository
+	txManager               repositories.TransactionManager
+	storage                 storage.Storage
+	mediaCfg                config.MediaConfig
+}
+
+func NewIncidentService(incidentRepo repositories.IncidentRepository, statusChangeRepo repositories.IncidentStatusChangeRepository, alarmRepo repositories.AlarmRepository, assignmentHistoryRepo repositories.MissionAssignmentHistoryRepository, missionStatusChangeRepo repositories.MissionStatusChangeRepository, stepHistoryRepo repositories.StepHistoryRepository, incidentMediaRepo repositories.IncidentMediaRepository, custodyRepo repositories.MediaCustodyRepository, overdueEventRepo repositories.OverdueEventRepository, userRepo repositories.UserRepository, commentRepo repositories.CommentRepository, outboxEventRepo repositories.OutboxEventRepository, txManager repositories.TransactionManager, store storage.Storage, mediaCfg config.MediaConfig) *IncidentService {
+	return &IncidentService{
+		incidentRepo:            incidentRepo,
+		statusChangeRepo:        statusChangeRepo,
+		alarmRepo:               alarmRepo,
+		assignmentHistoryRepo:   assignmentHistoryRepo,
+		missionStatusChangeRepo: missionStatusChangeRepo,
+		stepHistoryRepo:         stepHistoryRepo,
+		incidentMediaRepo:       incidentMediaRepo,
+		custodyRepo:             custodyRepo,
+		overdueEventRepo:        overdueEventRepo,
+		userRepo:                userRepo,
+		commentRepo:             commentRepo,
+		outboxEventRepo:         outboxEventRepo,
+		txManager:               txManager,
+		storage:                 store,
+		mediaCfg:                mediaCfg,
+	}
+}
+
+// CreateIncident opens an incident by hand, optionally linked to the alarm that caused it
+func (s *IncidentService) CreateIncident(ctx context.Context, userID string, createDto dto.CreateIncidentDto) (*models.Incident, error) {
+	creator, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid user id")
+	}
+	incident := &models.Incident{
+		Name:        createDto.Name,
+		Description: createDto.Description,
+		Severity:    createDto.Severity,
+		Location:    createDto.Location,
+		Status:      models.IncidentStatusNew,
+		CreatedByID: &creator,
+	}
+	if createDto.AlarmID != "" {
+		alarmID, err := uuid.Parse(createDto.AlarmID)
+		if err != nil {
+			return nil, errors.NewBadRequestError("invalid alarm id")
+		}
+		incident.AlarmID = &alarmID
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.incidentRepo.CreateIncident(ctx, incident); err != nil {
+			if repositories.IsForeignKeyViolation(err) {
+				return errors.NewNotFoundError("alarm")
+			}
+			return errors.NewDatabaseError("create incident", err)
+		}
+		if incident.AlarmID != nil {
+			if err := s.alarmRepo.Update(ctx, incident.AlarmID.String(), map[string]interface{}{"incident_id": incident.ID}); err != nil {
+				return errors.NewDatabaseError("link alarm to incident", err)
+			}
+		}
+		return s.recordOpened(ctx, incident, &creator)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetIncident(ctx, incident.ID.String())
+}
+
+// recordOpened starts the status history of a new incident. A nil actor means the incident was
+// opened by the system. It must run inside the transaction creating the incident.
+func (s *IncidentService) recordOpened(ctx context.Context, incident *models.Incident, actor *uuid.UUID) error {
+	if err := s.statusChangeRepo.Create(ctx, &models.IncidentStatusChange{
+		IncidentID: incident.ID,
+		ToStatus:   incident.Status,
+		ActorID:    actor,
+	}); err != nil {
+		return errors.NewDatabaseError("create incident status change", err)
+	}
+	return nil
+}
+
+// recordDispatchFailed tells operators that an incident opened by an alarm could not be
+// dispatched, so they can dispatch it themselves
+func (s *IncidentService) recordDispatchFailed(ctx context.Context, incident *models.Incident, alarmID uuid.UUID, reason string) error {
+	outboxEvent, err := newOutboxEvent(events.Event{
+		Type:       events.TypeIncidentDispatchFailed,
+		IncidentID: &incident.ID,
+		Data:       events.DispatchFailure{AlarmID: alarmID, Error: reason},
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.outboxEventRepo.Create(ctx, outboxEvent); err != nil {
+		return errors.NewDatabaseError("create outbox event", err)
+	}
+	return nil
+}
+
+// GetIncidents returns a page of incidents, newest first
+func (s *IncidentService) GetIncidents(ctx context.Context, filter repositories.IncidentFilter) (*dto.IncidentPageDto, error) {
+	incidents, total, err := s.incidentRepo.GetIncidentsPage(ctx, filter)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get incidents", err)
+	}
+	return &dto.IncidentPageDto{Incidents: incidents, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+func (s *IncidentService) GetIncident(ctx context.Context, id string) (*models.Incident, error) {
+	incident, err := s.incidentRepo.GetIncidentByID(ctx, id)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("incident")
+		}
+		return nil, errors.NewDatabaseError("get incident", err)
+	}
+	return incident, nil
+}
+
+// UpdateIncident edits the details of an incident that is not closed
+func (s *IncidentService) UpdateIncident(ctx context.Context, id string, updateDto dto.UpdateIncidentDto) (*models.Incident, error) {
+	incident, err := s.GetIncident(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status == models.IncidentStatusClosed {
+		return nil, errors.NewBadRequestError("closed incidents cannot be edited")
+	}
+	updates := map[string]interface{}{}
+	if updateDto.Name != nil {
+		updates["name"] = *updateDto.Name
+	}
+	if updateDto.Description != nil {
+		updates["description"] = *updateDto.Description
+	}
+	if updateDto.Severity != nil {
+		updates["severity"] = *updateDto.Severity
+	}
+	if updateDto.Location != nil {
+		updates["location"] = *updateDto.Location
+	}
+	if len(updates) > 0 {
+		if err := s.incidentRepo.UpdateIncident(ctx, id, updates); err != nil {
+			return nil, errors.NewDatabaseError("update incident", err)
+		}
+	}
+	return s.GetIncident(ctx, id)
+}
+
+// ResolveIncident resolves an incident with a summary of how it was handled. It is refused while
+// mandatory steps of its mission are incomplete unless an override reason is given.
+func (s *IncidentService) ResolveIncident(ctx context.Context, id string, userID string, resolveDto dto.ResolveIncidentDto) (*models.Incident, error) {
+	actor, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid user id")
+	}
+	incident, err := s.GetIncident(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !canTransitionIncident(incident.Status, models.IncidentStatusResolved) {
+		return nil, invalidIncidentTransitionError(incident.Status, models.IncidentStatusResolved)
+	}
+	incomplete := incompleteMandatorySteps(incident)
+	if len(incomplete) > 0 && resolveDto.OverrideReason == "" {
+		return nil, errors.NewValidationError("the incident has incomplete mandatory steps, give an override reason to resolve it anyway", incomplete)
+	}
+	updates := map[string]interface{}{
+		"resolution_summary":         resolveDto.ResolutionSummary,
+		"resolution_override_reason": "",
+		"resolved_at":                time.Now(),
+		"resolved_by_id":             actor,
+	}
+	reason := ""
+	if len(incomplete) > 0 {
+		updates["resolution_override_reason"] = resolveDto.OverrideReason
+		reason = fmt.Sprintf("resolved with %d incomplete mandatory steps: %s", len(incomplete), resolveDto.OverrideReason)
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.transition(ctx, incident, nil, models.IncidentStatusResolved, &actor, reason, updates)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetIncident(ctx, id)
+}
+
+// ReopenIncident moves a resolved incident back to in progress
+func (s *IncidentService) ReopenIncident(ctx context.Context, id string, userID string, reason string) (*models.Incident, error) {
+	return s.changeStatus(ctx, id, userID, models.IncidentStatusInProgress, reason, map[string]interface{}{
+		"resolved_at":    nil,
+		"resolved_by_id": nil,
+	})
+}
+
+// CloseIncident closes a resolved incident for good
+func (s *IncidentService) CloseIncident(ctx context.Context, id string, userID string, reason string) (*models.Incident, error) {
+	return s.changeStatus(ctx, id, userID, models.IncidentStatusClosed, reason, map[string]interface{}{
+		"closed_at": time.Now(),
+	})
+}
+
+func (s *IncidentService) changeStatus(ctx context.Context, id string, userID string, target string, reason string, updates map[string]interface{}) (*models.Incident, error) {
+	actor, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid user id")
+	}
+	incident, err := s.GetIncident(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.transition(ctx, incident, nil, target, &actor, reason, updates)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetIncident(ctx, id)
+}
+
+// transition moves an incident to the target status, records the change in its history and
+// publishes it, failing if the transition is not allowed or the incident was changed concurrently.
+// The event is attached to mission, or to the mission of the incident when mission is nil. It
+// must run inside a transaction.
+func (s *IncidentService) transition(ctx context.Context, incident *models.Incident, mission *models.IncidentGuidance, target string, actor *uuid.UUID, reason string, updates map[string]interface{}) error {
+	if !canTransitionIncident(incident.Status, target) {
+		return invalidIncidentTransitionError(incident.Status, target)
+	}
+	updates["status"] = target
+	ok, err := s.incidentRepo.UpdateStatus(ctx, incident.ID.String(), []string{incident.Status}, updates)
+	if err != nil {
+		return errors.NewDatabaseError("update incident status", err)
+	}
+	if !ok {
+		return errors.NewConflictError("incident status was changed by another request")
+	}
+	if err := s.statusChangeRepo.Create(ctx, &models.IncidentStatusChange{
+		IncidentID: incident.ID,
+		FromStatus: incident.Status,
+		ToStatus:   target,
+		ActorID:    actor,
+		Reason:     reason,
+	}); err != nil {
+		return errors.NewDatabaseError("create incident status change", err)
+	}
+	change := events.StatusChange{From: incident.Status, To: target}
+	incident.Status = target
+
+	if mission == nil {
+		mission = incident.IncidentGuidance
+	}
+	event := events.Event{
+		Type:       events.TypeIncidentStatusChanged,
+		IncidentID: &incident.ID,
+		Data:       change,
+	}
+	if mission != nil {
+		event.MissionID = &mission.ID
+		event.UserIDs = userIDs(mission.AssigneeID)
+	}
+	outboxEvent, err := newOutboxEvent(event)
+	if err != nil {
+		return err
+	}
+	if err := s.outboxEventRepo.Create(ctx, outboxEvent); err != nil {
+		return errors.NewDatabaseError("create outbox event", err)
+	}
+	return nil
+}
+
+// incompleteMandatorySteps returns the mandatory steps of the incident mission that are neither
+// completed nor skipped
+func incompleteMandatorySteps(incident *models.Incident) []dto.IncompleteStepDto {
+	incomplete := []dto.IncompleteStepDto{}
+	if incident.IncidentGuidance == nil {
+		return incomplete
+	}
+	for _, step := range incident.IncidentGuidance.IncidentGuidanceSteps {
+		if !step.Optional && !step.IsCompleted && step.State != models.StepStateSkipped {
+			incomplete = append(incomplete, dto.IncompleteStepDto{
+				StepID:     step.ID.String(),
+				StepNumber: step.StepNumber,
+				Title:      step.Title,
+			})
+		}
+	}
+	return incomplete
+}
+
+func invalidIncidentTransitionError(from string, to string) error {
+	return errors.NewBadRequestError(fmt.Sprintf("incident cannot move from %s to %s", from, to))
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"scs-guard/internal/models"
+	"testing"
+)
+
+func TestCanTransitionIncident(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected bool
+	}{
+		{"start new", models.IncidentStatusNew, models.IncidentStatusInProgress, true},
+		{"resolve new", models.IncidentStatusNew, models.IncidentStatusResolved, true},
+		{"resolve in progress", models.IncidentStatusInProgress, models.IncidentStatusResolved, true},
+		{"reopen resolved", models.IncidentStatusResolved, models.IncidentStatusInProgress, true},
+		{"close resolved", models.IncidentStatusResolved, models.IncidentStatusClosed, true},
+		{"close new", models.IncidentStatusNew, models.IncidentStatusClosed, false},
+		{"close in progress", models.IncidentStatusInProgress, models.IncidentStatusClosed, false},
+		{"reopen closed", models.IncidentStatusClosed, models.IncidentStatusInProgress, false},
+		{"back to new", models.IncidentStatusInProgress, models.IncidentStatusNew, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canTransitionIncident(tt.from, tt.to); got != tt.expected {
+				t.Errorf("canTransitionIncident(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIncompleteMandatorySteps(t *testing.T) {
+	if got := incompleteMandatorySteps(&models.Incident{}); len(got) != 0 {
+		t.Errorf("expected no incomplete steps without a mission, got %v", got)
+	}
+
+	incident := &models.Incident{IncidentGuidance: &models.IncidentGuidance{
+		IncidentGuidanceSteps: []models.IncidentGuidanceStep{
+			{StepNumber: 1, Title: "done", IsCompleted: true},
+			{StepNumber: 2, Title: "optional", Optional: true},
+			{StepNumber: 3, Title: "mandatory"},
+		},
+	}}
+	got := incompleteMandatorySteps(incident)
+	if len(got) != 1 || got[0].StepNumber != 3 || got[0].Title != "mandatory" {
+		t.Errorf("expected only step 3 to be reported, got %v", got)
+	}
+}
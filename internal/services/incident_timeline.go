@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"scs-guard/internal/dto"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// timelineEntry is a timeline entry whose user actor is not resolved yet
+type timelineEntry struct {
+	dto.TimelineEntryDto
+	actorID *uuid.UUID
+}
+
+func newTimelineEntry(entryType string, at time.Time, sourceID uuid.UUID, actorID *uuid.UUID, data interface{}) timelineEntry {
+	return timelineEntry{
+		TimelineEntryDto: dto.TimelineEntryDto{
+			Type:       entryType,
+			OccurredAt: at,
+			SourceID:   sourceID.String(),
+			Data:       data,
+		},
+		actorID: actorID,
+	}
+}
+
+// GetTimeline returns a page of everything that happened during an incident, oldest first: the
+// alarms that triggered it, its status changes, the assignment, status changes, step history and
+// missed deadlines of its mission, and the media and comments added to it. Deleted comments
+// are left out. The media on the page get download URLs, which is recorded in their chain of
+// custody as a view by userID.
+func (s *IncidentService) GetTimeline(ctx context.Context, userID string, id string, limit int, offset int) (*dto.IncidentTimelineDto, error) {
+	incident, err := s.GetIncident(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.collectTimeline(ctx, incident)
+	if err != nil {
+		return nil, err
+	}
+	sortTimeline(entries)
+	page := pageTimeline(entries, limit, offset)
+	if err := s.resolveTimelineActors(ctx, page); err != nil {
+		return nil, err
+	}
+	if err := s.showTimelineMedia(ctx, userID, page); err != nil {
+		return nil, err
+	}
+
+	result := &dto.IncidentTimelineDto{
+		Entries: make([]dto.TimelineEntryDto, 0, len(page)),
+		Total:   int64(len(entries)),
+		Limit:   limit,
+		Offset:  offset,
+	}
+	for _, entry := range page {
+		result.Entries = append(result.Entries, entry.TimelineEntryDto)
+	}
+	return result, nil
+}
+
+// collectTimeline gathers the timeline entries of an incident from every source, unsorted
+func (s *IncidentService) collectTimeline(ctx context.Context, incident *models.Incident) ([]timelineEntry, error) {
+	var entries []timelineEntry
+	incidentID := incident.ID.String()
+
+	alarms, err := s.alarmRepo.GetByIncidentID(ctx, incidentID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get incident alarms", err)
+	}
+	// The triggering alarm of an incident opened by hand may not be linked back to it
+	if incident.Alarm != nil && !containsAlarm(alarms, incident.Alarm.ID) {
+		alarms = append(alarms, *incident.Alarm)
+	}
+	entries = append(entries, alarmEntries(incident, alarms)...)
+
+	statusChanges, err := s.statusChangeRepo.GetByIncidentID(ctx, incidentID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get incident status changes", err)
+	}
+	for i := range statusChanges {
+		change := &statusChanges[i]
+		change.Actor = nil
+		entries = append(entries, newTimelineEntry(dto.TimelineIncidentStatusChanged, change.CreatedAt, change.ID, change.ActorID, change))
+	}
+
+	if mission := incident.IncidentGuidance; mission != nil {
+		missionEntries, err := s.missionTimeline(ctx, mission)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, missionEntries...)
+	}
+
+	medias, err := s.incidentMediaRepo.GetByIncidentID(ctx, incidentID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get incident media", err)
+	}
+	for i := range medias {
+		media := &medias[i]
+		entries = append(entries, newTimelineEntry(dto.TimelineMediaUploaded, media.CreatedAt, media.ID, media.UploadedByID, media))
+	}
+
+	comments, err := s.commentRepo.GetByIncidentID(ctx, incidentID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get comments", err)
+	}
+	for i := range comments {
+		comment := &comments[i]
+		entries = append(entries, newTimelineEntry(dto.TimelineCommentAdded, comment.CreatedAt, comment.ID, &comment.AuthorID, comment))
+	}
+
+	overdueEvents, err := s.overdueEventRepo.GetByIncidentID(ctx, incidentID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get overdue events", err)
+	}
+	for i := range overdueEvents {
+		event := &overdueEvents[i]
+		entryType := dto.TimelineMissionOverdue
+		if event.Kind == models.OverdueKindStep {
+			entryType = dto.TimelineStepOverdue
+		}
+		entries = append(entries, newTimelineEntry(entryType, event.CreatedAt, event.ID, nil, event))
+	}
+	return entries, nil
+}
+
+// missionTimeline returns the assignment history, status history and step history of a mission
+func (s *IncidentService) missionTimeline(ctx context.Context, mission *models.IncidentGuidance) ([]timelineEntry, error) {
+	var entries []timelineEntry
+	missionID := mission.ID.String()
+
+	histories, err := s.assignmentHistoryRepo.GetByIncidentGuidanceID(ctx, missionID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get mission assignment history", err)
+	}
+	for i := range histories {
+		history := &histories[i]
+		history.Actor = nil
+		entries = append(entries, newTimelineEntry(assignmentEntryType(history.Action), history.CreatedAt, history.ID, history.ActorID, history))
+	}
+
+	statusChanges, err := s.missionStatusChangeRepo.GetByIncidentGuidanceID(ctx, missionID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get mission status changes", err)
+	}
+	for i := range statusChanges {
+		change := &statusChanges[i]
+		entries = append(entries, newTimelineEntry(dto.TimelineMissionStatusChanged, change.CreatedAt, change.ID, change.ActorID, change))
+	}
+
+	stepHistories, err := s.stepHistoryRepo.GetByIncidentGuidanceID(ctx, missionID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get step history", err)
+	}
+	recorded := map[uuid.UUID]bool{}
+	for i := range stepHistories {
+		history := &stepHistories[i]
+		history.Actor = nil
+		recorded[history.IncidentGuidanceStepID] = true
+		entries = append(entries, newTimelineEntry(stepEntryType(history.Action), history.CreatedAt, history.ID, history.ActorID, history))
+	}
+
+	for i := range mission.IncidentGuidanceSteps {
+		step := &mission.IncidentGuidanceSteps[i]
+		// Steps completed before the step history was kept only show their last completion
+		if !step.IsCompleted || recorded[step.ID] {
+			continue
+		}
+		// Steps completed before completion times were recorded fall back to their last update
+		at := step.UpdatedAt
+		if step.CompletedAt != nil {
+			at = *step.CompletedAt
+		}
+		actorID := step.CompletedByID
+		if actorID == nil {
+			actorID = mission.AssigneeID
+		}
+		entries = append(entries, newTimelineEntry(dto.TimelineStepCompleted, at, step.ID, actorID, step))
+	}
+	return entries, nil
+}
+
+// showTimelineMedia fills in the download URLs of the media in the given entries and records that
+// userID viewed them
+func (s *IncidentService) showTimelineMedia(ctx context.Context, userID string, entries []timelineEntry) error {
+	var custody []models.MediaCustodyEntry
+	for _, entry := range entries {
+		media, ok := entry.Data.(*models.IncidentMedia)
+		if !ok {
+			continue
+		}
+		if err := signMedia(ctx, s.storage, s.mediaCfg.DownloadURLExpiry, media); err != nil {
+			return err
+		}
+		custody = append(custody, newCustodyEntry(media, models.CustodyActionViewed, actorID(userID), ""))
+	}
+	if err := s.custodyRepo.BatchCreate(ctx, custody); err != nil {
+		return errors.NewDatabaseError("record media custody", err)
+	}
+	return nil
+}
+
+// resolveTimelineActors loads the users that caused the given entries in one query
+func (s *IncidentService) resolveTimelineActors(ctx context.Context, entries []timelineEntry) error {
+	var ids []string
+	seen := map[uuid.UUID]bool{}
+	for _, entry := range entries {
+		if entry.actorID != nil && !seen[*entry.actorID] {
+			seen[*entry.actorID] = true
+			ids = append(ids, entry.actorID.String())
+		}
+	}
+	users, err := s.userRepo.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return errors.NewDatabaseError("get timeline actors", err)
+	}
+	byID := make(map[uuid.UUID]models.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+	for i := range entries {
+		if entries[i].actorID == nil {
+			continue
+		}
+		actor := &dto.TimelineActorDto{Type: dto.TimelineActorUser, ID: entries[i].actorID.String()}
+		if user, ok := byID[*entries[i].actorID]; ok {
+			actor.Name = user.Name
+			actor.Role = user.Role
+		}
+		entries[i].Actor = actor
+	}
+	return nil
+}
+
+// alarmEntries returns an entry for every alarm of an incident. The alarm the incident was opened
+// for is the triggering alarm; the others were correlated into it later. Alarms are attributed to
+// the device that raised them.
+func alarmEntries(incident *models.Incident, alarms []models.Alarm) []timelineEntry {
+	entries := make([]timelineEntry, 0, len(alarms))
+	for i := range alarms {
+		alarm := &alarms[i]
+		entryType := dto.TimelineAlarmCorrelated
+		if incident.AlarmID != nil && *incident.AlarmID == alarm.ID {
+			entryType = dto.TimelineAlarmTriggered
+		}
+		entry := newTimelineEntry(entryType, alarm.TriggeredAt, alarm.ID, nil, alarm)
+		if alarm.Device != nil {
+			entry.Actor = &dto.TimelineActorDto{Type: dto.TimelineActorDevice, ID: alarm.Device.ID.String(), Name: alarm.Device.Name}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func containsAlarm(alarms []models.Alarm, id uuid.UUID) bool {
+	for _, alarm := range alarms {
+		if alarm.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func assignmentEntryType(action string) string {
+	switch action {
+	case models.AssignmentActionReassigned:
+		return dto.TimelineMissionReassigned
+	case models.AssignmentActionEscalated:
+		return dto.TimelineMissionEscalated
+	default:
+		return dto.TimelineMissionAssigned
+	}
+}
+
+func stepEntryType(action string) string {
+	switch action {
+	case models.StepActionSkipped:
+		return dto.TimelineStepSkipped
+	case models.StepActionCompletionUndone:
+		return dto.TimelineStepCompletionUndone
+	default:
+		return dto.TimelineStepCompleted
+	}
+}
+
+// sortTimeline orders entries oldest first. Entries that happened at the same time keep the order
+// they were collected in.
+func sortTimeline(entries []timelineEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].OccurredAt.Before(entries[j].OccurredAt)
+	})
+}
+
+// pageTimeline returns the entries of a page, which is empty past the end of the timeline
+func pageTimeline(entries []timelineEntry, limit int, offset int) []timelineEntry {
+	if offset >= len(entries) {
+		return nil
+	}
+	end := offset + limit
+	if end > len(entries) {
+		end = len(entries)
+	}
+	return entries[offset:end]
+}
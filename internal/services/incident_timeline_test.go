@@ -0,0 +1,73 @@
+package services
+
+import (
+	"scs-guard/internal/dto"
+	"scs-guard/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSortTimeline(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []timelineEntry{
+		newTimelineEntry(dto.TimelineStepCompleted, start.Add(2*time.Minute), uuid.New(), nil, nil),
+		newTimelineEntry(dto.TimelineAlarmTriggered, start, uuid.New(), nil, nil),
+		newTimelineEntry(dto.TimelineIncidentStatusChanged, start, uuid.New(), nil, nil),
+		newTimelineEntry(dto.TimelineMissionAssigned, start.Add(time.Minute), uuid.New(), nil, nil),
+	}
+	sortTimeline(entries)
+
+	expected := []string{dto.TimelineAlarmTriggered, dto.TimelineIncidentStatusChanged, dto.TimelineMissionAssigned, dto.TimelineStepCompleted}
+	for i, entryType := range expected {
+		if entries[i].Type != entryType {
+			t.Errorf("entry %d = %s, want %s", i, entries[i].Type, entryType)
+		}
+	}
+}
+
+func TestPageTimeline(t *testing.T) {
+	entries := make([]timelineEntry, 5)
+	tests := []struct {
+		name     string
+		limit    int
+		offset   int
+		expected int
+	}{
+		{"first page", 2, 0, 2},
+		{"last partial page", 2, 4, 1},
+		{"whole timeline", 50, 0, 5},
+		{"past the end", 2, 5, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pageTimeline(entries, tt.limit, tt.offset); len(got) != tt.expected {
+				t.Errorf("pageTimeline(%d, %d) returned %d entries, want %d", tt.limit, tt.offset, len(got), tt.expected)
+			}
+		})
+	}
+}
+
+func TestAlarmEntries(t *testing.T) {
+	triggering := models.Alarm{Device: &models.Device{Name: "Fire panel"}}
+	triggering.ID = uuid.New()
+	correlated := models.Alarm{}
+	correlated.ID = uuid.New()
+	incident := &models.Incident{AlarmID: &triggering.ID}
+
+	entries := alarmEntries(incident, []models.Alarm{triggering, correlated})
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Type != dto.TimelineAlarmTriggered || entries[1].Type != dto.TimelineAlarmCorrelated {
+		t.Errorf("unexpected entry types %s, %s", entries[0].Type, entries[1].Type)
+	}
+	if entries[0].Actor == nil || entries[0].Actor.Type != dto.TimelineActorDevice || entries[0].Actor.Name != "Fire panel" {
+		t.Errorf("expected the triggering alarm to be attributed to its device, got %+v", entries[0].Actor)
+	}
+	if entries[1].Actor != nil {
+		t.Errorf("expected no actor for an alarm without device, got %+v", entries[1].Actor)
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/errors"
+	"sync"
+)
+
+// mediaFile is a file of a multi-file upload and the media recorded for it
+type mediaFile struct {
+	data  io.Reader
+	size  int64
+	media *models.IncidentMedia
+}
+
+// storeMediaFiles stores the files of a multi-file upload under the object keys of their media,
+// at most the configured number at a time, and fills in the size and SHA-256 hash of each media.
+// Once a file fails no further files are started, and every file of the upload is removed again
+// so a failed upload leaves nothing behind in storage.
+func (s *MissionService) storeMediaFiles(ctx context.Context, files []mediaFile) error {
+	uploadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	slots := make(chan struct{}, max(1, s.mediaCfg.UploadConcurrency))
+	for i := range files {
+		slots <- struct{}{}
+		if uploadCtx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(file *mediaFile) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			hash := sha256.New()
+			info, err := s.storage.Put(uploadCtx, file.media.ObjectKey, io.TeeReader(file.data, hash), file.size, file.media.FileType)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+			file.media.FileSize = info.Size
+			file.media.SHA256 = hex.EncodeToString(hash.Sum(nil))
+		}(&files[i])
+	}
+	wg.Wait()
+
+	// Files are not started once the request is canceled
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		s.discardMediaFiles(ctx, files)
+		return errors.NewInternalError("failed to store the uploaded files", firstErr)
+	}
+	return nil
+}
+
+// discardMediaFiles removes the stored files of an upload that failed, even when the request was
+// canceled. Files that were never stored are not found, and files that cannot be removed are left
+// for the storage reconciliation to report, so errors are ignored.
+func (s *MissionService) discardMediaFiles(ctx context.Context, files []mediaFile) {
+	ctx = context.WithoutCancel(ctx)
+	for i := range files {
+		_ = s.storage.Delete(ctx, files[i].media.ObjectKey)
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	config "scs-guard/config"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/logger"
+	"scs-guard/pkg/storage"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// failingStorage fails to store one object and passes everything else on
+type failingStorage struct {
+	storage.Storage
+	failKey string
+}
+
+func (s *failingStorage) Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) (storage.ObjectInfo, error) {
+	if key == s.failKey {
+		return storage.ObjectInfo{}, fmt.Errorf("storage unavailable")
+	}
+	return s.Storage.Put(ctx, key, data, size, contentType)
+}
+
+func newTestMediaBatch(t *testing.T, count int) ([]models.IncidentMedia, []mediaFile) {
+	t.Helper()
+	incidentID := uuid.New()
+	medias := make([]models.IncidentMedia, count)
+	files := make([]mediaFile, count)
+	for i := range medias {
+		id := uuid.New()
+		medias[i] = models.IncidentMedia{Base: models.Base{ID: id}, FileName: "IMG_0001.jpg", ObjectKey: mediaObjectKey(incidentID, id, "IMG_0001.jpg"), FileType: "image/jpeg"}
+		content := fmt.Sprintf("photo %d", i)
+		files[i] = mediaFile{data: strings.NewReader(content), size: int64(len(content)), media: &medias[i]}
+	}
+	return medias, files
+}
+
+func TestStoreMediaFilesKeepsFilesWithTheSameName(t *testing.T) {
+	store, err := storage.NewLocalStorage(t.TempDir(), "http://localhost:8080", "secret", logger.GetLogger())
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	s := &MissionService{storage: store, mediaCfg: config.MediaConfig{UploadConcurrency: 2}}
+	ctx := context.Background()
+	medias, files := newTestMediaBatch(t, 5)
+
+	if err := s.storeMediaFiles(ctx, files); err != nil {
+		t.Fatalf("storeMediaFiles() error = %v", err)
+	}
+	for i := range medias {
+		hash, size, err := hashObject(ctx, store, medias[i].ObjectKey)
+		if err != nil {
+			t.Fatalf("hashObject(%s) error = %v", medias[i].ObjectKey, err)
+		}
+		if medias[i].SHA256 != hash || medias[i].FileSize != size {
+			t.Errorf("media %d = %s, %d bytes, want %s, %d bytes", i, medias[i].SHA256, medias[i].FileSize, hash, size)
+		}
+	}
+	if objects, err := store.List(ctx, ""); err != nil || len(objects) != len(medias) {
+		t.Errorf("List() = %d objects, error = %v, want %d", len(objects), err, len(medias))
+	}
+}
+
+func TestStoreMediaFilesRemovesTheBatchOnFailure(t *testing.T) {
+	local, err := storage.NewLocalStorage(t.TempDir(), "http://localhost:8080", "secret", logger.GetLogger())
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	ctx := context.Background()
+	medias, files := newTestMediaBatch(t, 5)
+	s := &MissionService{storage: &failingStorage{Storage: local, failKey: medias[2].ObjectKey}, mediaCfg: config.MediaConfig{UploadConcurrency: 2}}
+
+	if err := s.storeMediaFiles(ctx, files); err == nil {
+		t.Fatal("storeMediaFiles() error = nil, want the storage error")
+	}
+	if objects, err := local.List(ctx, ""); err != nil || len(objects) != 0 {
+		t.Errorf("List() = %v, error = %v, want no objects left", objects, err)
+	}
+}
+
+func TestSourceObjectKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"incident/upload/photo.jpg", "incident/upload/photo.jpg"},
+		{"incident/upload/photo.jpg.thumbnail.jpg", "incident/upload/photo.jpg"},
+		{"incident/upload/photo.png.preview.jpg", "incident/upload/photo.png"},
+		{"incident/upload/photo.jpg.stripped.jpg", "incident/upload/photo.jpg"},
+	}
+	for _, tt := range tests {
+		if got := sourceObjectKey(tt.key); got != tt.want {
+			t.Errorf("sourceObjectKey(%s) = %s, want %s", tt.key, got, tt.want)
+		}
+	}
+}
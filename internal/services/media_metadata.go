@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"io"
+	"math"
+	config "scs-guard/config"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/exif"
+)
+
+// earthRadius is the mean radius of the earth in meters
+const earthRadius = 6371000
+
+// readPhotoMetadata reads the EXIF metadata of a JPEG photo. Other files and photos without
+// readable metadata have none.
+func readPhotoMetadata(r io.Reader) *exif.Metadata {
+	metadata, err := exif.Decode(r)
+	if err != nil {
+		return nil
+	}
+	return metadata
+}
+
+// readStoredPhotoMetadata reads the EXIF metadata of a photo in object storage
+func (s *MissionService) readStoredPhotoMetadata(ctx context.Context, key string) *exif.Metadata {
+	object, _, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil
+	}
+	defer object.Close()
+	return readPhotoMetadata(object)
+}
+
+// applyPhotoMetadata stores where, when and with what a photo was taken on its media, and flags
+// the photo when it was taken before its incident started or far from the premise of the
+// incident. Photos without metadata are not flagged.
+func applyPhotoMetadata(media *models.IncidentMedia, metadata *exif.Metadata, incident *models.Incident, mediaCfg config.MediaConfig) {
+	if metadata == nil {
+		return
+	}
+	media.CapturedAt = metadata.CapturedAt
+	media.Latitude = metadata.Latitude
+	media.Longitude = metadata.Longitude
+	media.DeviceMake = metadata.Make
+	media.DeviceModel = metadata.Model
+	media.Orientation = metadata.Orientation
+
+	// The incident started when its alarm went off, which can be before it was created
+	startedAt := incident.CreatedAt
+	if incident.Alarm != nil && !incident.Alarm.TriggeredAt.IsZero() && incident.Alarm.TriggeredAt.Before(startedAt) {
+		startedAt = incident.Alarm.TriggeredAt
+	}
+	if media.CapturedAt != nil && media.CapturedAt.Before(startedAt.Add(-mediaCfg.CaptureTimeTolerance)) {
+		media.MetadataFlags = append(media.MetadataFlags, models.MediaFlagCapturedBeforeIncident)
+	}
+
+	if incident.Alarm == nil || incident.Alarm.Premise == nil || media.Latitude == nil {
+		return
+	}
+	premise := incident.Alarm.Premise
+	if premise.Latitude == nil || premise.Longitude == nil {
+		return
+	}
+	distance := distanceMeters(*media.Latitude, *media.Longitude, *premise.Latitude, *premise.Longitude)
+	media.DistanceFromPremise = &distance
+	if distance > mediaCfg.MaxCaptureDistance {
+		media.MetadataFlags = append(media.MetadataFlags, models.MediaFlagFarFromPremise)
+	}
+}
+
+// distanceMeters returns the great-circle distance between two points given in decimal degrees
+func distanceMeters(lat1, lon1, lat2, lon2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
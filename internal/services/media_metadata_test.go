@@ -0,0 +1,107 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"math"
+	config "scs-guard/config"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/exif"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDistanceMeters(t *testing.T) {
+	// Brandenburg Gate to the Berlin TV tower
+	got := distanceMeters(52.516275, 13.377704, 52.520817, 13.409419)
+	if math.Abs(got-2206) > 10 {
+		t.Errorf("distanceMeters() = %.0f, want about 2206", got)
+	}
+	if got := distanceMeters(52.5, 13.4, 52.5, 13.4); got != 0 {
+		t.Errorf("distanceMeters() of the same point = %f, want 0", got)
+	}
+}
+
+func TestApplyPhotoMetadata(t *testing.T) {
+	createdAt := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	premiseLat, premiseLon := 52.516275, 13.377704
+	nearLat, nearLon := 52.5165, 13.3780
+	farLat, farLon := 52.520817, 13.409419
+	at := func(d time.Duration) *time.Time {
+		captured := createdAt.Add(d)
+		return &captured
+	}
+	incident := func(alarm *models.Alarm) *models.Incident {
+		incident := &models.Incident{Alarm: alarm}
+		incident.CreatedAt = createdAt
+		return incident
+	}
+	premise := &models.Premise{Latitude: &premiseLat, Longitude: &premiseLon}
+
+	tests := []struct {
+		name     string
+		metadata *exif.Metadata
+		incident *models.Incident
+		want     []string
+	}{
+		{"no metadata", nil, incident(nil), nil},
+		{"taken during the incident near the premise", &exif.Metadata{CapturedAt: at(time.Minute), Latitude: &nearLat, Longitude: &nearLon}, incident(&models.Alarm{Premise: premise}), nil},
+		{"clock slightly off", &exif.Metadata{CapturedAt: at(-2 * time.Minute)}, incident(nil), nil},
+		{"taken before the incident", &exif.Metadata{CapturedAt: at(-time.Hour)}, incident(nil), []string{models.MediaFlagCapturedBeforeIncident}},
+		{"taken after the alarm went off", &exif.Metadata{CapturedAt: at(-time.Hour)}, incident(&models.Alarm{TriggeredAt: createdAt.Add(-2 * time.Hour)}), nil},
+		{"taken far from the premise", &exif.Metadata{Latitude: &farLat, Longitude: &farLon}, incident(&models.Alarm{Premise: premise}), []string{models.MediaFlagFarFromPremise}},
+		{"premise without location", &exif.Metadata{Latitude: &farLat, Longitude: &farLon}, incident(&models.Alarm{Premise: &models.Premise{}}), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			media := &models.IncidentMedia{}
+			applyPhotoMetadata(media, tt.metadata, tt.incident, config.MediaConfig{CaptureTimeTolerance: 5 * time.Minute, MaxCaptureDistance: 500})
+			if strings.Join(media.MetadataFlags, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("applyPhotoMetadata() flags = %v, want %v", media.MetadataFlags, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripMetadataRejectsOtherFormats(t *testing.T) {
+	s, store := newTestMediaProcessor(t)
+	ctx := context.Background()
+	if _, err := store.Put(ctx, "incident/photo.png", strings.NewReader("\x89PNG\r\n\x1a\n"), 8, "image/png"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	for _, media := range []*models.IncidentMedia{
+		{MediaType: "image", ObjectKey: "incident/photo.png"},
+		{MediaType: "video", ObjectKey: "incident/clip.mp4"},
+	} {
+		if err := s.stripMetadata(ctx, media); err == nil || !strings.Contains(err.Error(), "JPEG") {
+			t.Errorf("stripMetadata(%s) error = %v, want a JPEG only error", media.ObjectKey, err)
+		}
+	}
+}
+
+func TestStripMetadataPointsAtTheCopy(t *testing.T) {
+	s, store := newTestMediaProcessor(t)
+	ctx := context.Background()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 8, 8)), nil); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+	if _, err := store.Put(ctx, "incident/photo.jpg", &buf, int64(buf.Len()), "image/jpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	media := &models.IncidentMedia{MediaType: "image", ObjectKey: "incident/photo.jpg", SHA256: "original"}
+	if err := s.stripMetadata(ctx, media); err != nil {
+		t.Fatalf("stripMetadata() error = %v", err)
+	}
+	hash, size, err := hashObject(ctx, store, "incident/photo.jpg.stripped.jpg")
+	if err != nil {
+		t.Fatalf("hashObject() of the copy error = %v", err)
+	}
+	if media.SHA256 != hash || media.FileSize != size || !strings.Contains(media.FileUrl, "photo.jpg.stripped.jpg") {
+		t.Errorf("stripMetadata() = %s, %d, %s, want the hash, size and URL of the copy", media.SHA256, media.FileSize, media.FileUrl)
+	}
+}
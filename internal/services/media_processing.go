@@ -0,0 +1,113 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/errors"
+	"scs-guard/pkg/imaging"
+	"scs-guard/pkg/storage"
+	"time"
+)
+
+// derivedImageQuality is the JPEG quality of thumbnails and previews
+const derivedImageQuality = 80
+
+// The keys of the copies made of a file are its own key with one of these suffixes
+const (
+	thumbnailKeySuffix = ".thumbnail.jpg"
+	previewKeySuffix   = ".preview.jpg"
+	strippedKeySuffix  = ".stripped.jpg"
+)
+
+// ProcessPendingMedia makes the thumbnail and preview of a batch of images waiting for them and
+// returns how many media were processed. The original file is never changed: the resized copies
+// are stored as separate JPEG objects next to it, turned upright as the photo's EXIF orientation
+// says. Files that cannot be read or stored are tried again once the lease expires, up to the
+// maximum number of attempts; images that cannot be decoded fail at once.
+func (s *MediaService) ProcessPendingMedia(ctx context.Context) (int, error) {
+	now := time.Now()
+	pending, err := s.incidentMediaRepo.ClaimPendingProcessing(ctx, now, now.Add(s.mediaCfg.ProcessingLease), s.mediaCfg.ProcessingBatchSize)
+	if err != nil {
+		return 0, errors.NewDatabaseError("claim media to process", err)
+	}
+	processed := 0
+	for i := range pending {
+		media := &pending[i]
+		updates, err := s.processMedia(ctx, media)
+		if err != nil {
+			updates = map[string]interface{}{
+				"processing_attempts": media.ProcessingAttempts + 1,
+				"processing_error":    err.Error(),
+			}
+			if media.ProcessingAttempts+1 >= s.mediaCfg.ProcessingMaxAttempts {
+				updates["processing_status"] = models.MediaProcessingFailed
+			}
+		} else {
+			processed++
+		}
+		if err := s.incidentMediaRepo.Update(ctx, media.ID.String(), updates); err != nil {
+			return processed, errors.NewDatabaseError("update media processing", err)
+		}
+	}
+	return processed, nil
+}
+
+// processMedia stores the thumbnail and preview of an image and returns the columns to update
+// with the outcome. An error means the files could not be read or stored and processing should
+// be tried again.
+func (s *MediaService) processMedia(ctx context.Context, media *models.IncidentMedia) (map[string]interface{}, error) {
+	if media.MediaType != "image" {
+		return map[string]interface{}{"processing_status": models.MediaProcessingSkipped}, nil
+	}
+	failed := func(reason string) map[string]interface{} {
+		return map[string]interface{}{"processing_status": models.MediaProcessingFailed, "processing_error": reason}
+	}
+
+	object, _, err := s.storage.Get(ctx, media.Key())
+	if err != nil {
+		if storage.IsNotFound(err) {
+			return failed("the file is missing from storage"), nil
+		}
+		return nil, fmt.Errorf("read the file: %w", err)
+	}
+	img, _, err := imaging.Decode(object, s.mediaCfg.MaxImagePixels)
+	object.Close()
+	if err != nil {
+		return failed(fmt.Sprintf("decode the image: %v", err)), nil
+	}
+
+	thumbnailKey, previewKey := derivedImageKeys(media.Key())
+	for _, derived := range []struct {
+		key     string
+		maxSide int
+	}{
+		{thumbnailKey, s.mediaCfg.ThumbnailSize},
+		{previewKey, s.mediaCfg.PreviewSize},
+	} {
+		var buf bytes.Buffer
+		if err := imaging.EncodeJPEG(&buf, imaging.Orient(imaging.Resize(img, derived.maxSide), media.Orientation), derivedImageQuality); err != nil {
+			return failed(fmt.Sprintf("encode the resized image: %v", err)), nil
+		}
+		if _, err := s.storage.Put(ctx, derived.key, &buf, int64(buf.Len()), "image/jpeg"); err != nil {
+			return nil, fmt.Errorf("store %s: %w", derived.key, err)
+		}
+	}
+	return map[string]interface{}{
+		"processing_status": models.MediaProcessingDone,
+		"processing_error":  "",
+		"thumbnail_key":     thumbnailKey,
+		"preview_key":       previewKey,
+	}, nil
+}
+
+// derivedImageKeys returns the keys the thumbnail and preview of a file are stored under
+func derivedImageKeys(key string) (string, string) {
+	return key + thumbnailKeySuffix, key + previewKeySuffix
+}
+
+// strippedImageKey returns the key the copy of a photo without its metadata is stored under
+func strippedImageKey(key string) string {
+	return key + strippedKeySuffix
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	config "scs-guard/config"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/logger"
+	"scs-guard/pkg/storage"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestMediaProcessor(t *testing.T) (*MediaService, *storage.LocalStorage) {
+	t.Helper()
+	store, err := storage.NewLocalStorage(t.TempDir(), "http://localhost:8080", "secret", logger.GetLogger())
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	mediaCfg := config.MediaConfig{ThumbnailSize: 32, PreviewSize: 128, MaxImagePixels: 1000000}
+	return &MediaService{storage: store, mediaCfg: mediaCfg}, store
+}
+
+func TestProcessMediaMakesThumbnailAndPreview(t *testing.T) {
+	s, store := newTestMediaProcessor(t)
+	ctx := context.Background()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 400, 200))); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	if _, err := store.Put(ctx, "incident/photo.png", &buf, int64(buf.Len()), "image/png"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	updates, err := s.processMedia(ctx, &models.IncidentMedia{MediaType: "image", ObjectKey: "incident/photo.png"})
+	if err != nil {
+		t.Fatalf("processMedia() error = %v", err)
+	}
+	if updates["processing_status"] != models.MediaProcessingDone {
+		t.Fatalf("processMedia() status = %v, want done", updates["processing_status"])
+	}
+	for key, wantWidth := range map[string]int{
+		"incident/photo.png.thumbnail.jpg": 32,
+		"incident/photo.png.preview.jpg":   128,
+	} {
+		object, info, err := store.Get(ctx, key)
+		if err != nil {
+			t.Fatalf("Get(%s) error = %v", key, err)
+		}
+		bounds, format, err := image.DecodeConfig(object)
+		object.Close()
+		if err != nil || format != "jpeg" || info.ContentType != "image/jpeg" {
+			t.Fatalf("%s is %s %s, error = %v, want a JPEG", key, format, info.ContentType, err)
+		}
+		if bounds.Width != wantWidth || bounds.Height != wantWidth/2 {
+			t.Errorf("%s is %dx%d, want %dx%d", key, bounds.Width, bounds.Height, wantWidth, wantWidth/2)
+		}
+	}
+}
+
+func TestProcessMediaFailures(t *testing.T) {
+	s, store := newTestMediaProcessor(t)
+	ctx := context.Background()
+	if _, err := store.Put(ctx, "incident/broken.jpg", strings.NewReader("not a jpeg"), 10, "image/jpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		media  *models.IncidentMedia
+		status string
+	}{
+		{"video", &models.IncidentMedia{MediaType: "video", ObjectKey: "incident/clip.mp4"}, models.MediaProcessingSkipped},
+		{"undecodable image", &models.IncidentMedia{MediaType: "image", ObjectKey: "incident/broken.jpg"}, models.MediaProcessingFailed},
+		{"missing file", &models.IncidentMedia{MediaType: "image", ObjectKey: "incident/missing.jpg"}, models.MediaProcessingFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates, err := s.processMedia(ctx, tt.media)
+			if err != nil {
+				t.Fatalf("processMedia() error = %v", err)
+			}
+			if updates["processing_status"] != tt.status {
+				t.Errorf("processMedia() status = %v, want %s", updates["processing_status"], tt.status)
+			}
+		})
+	}
+}
+
+func TestSignMediaSkipsMissingDerivedImages(t *testing.T) {
+	_, store := newTestMediaProcessor(t)
+	media := &models.IncidentMedia{ObjectKey: "incident/photo.jpg"}
+	if err := signMedia(context.Background(), store, time.Hour, media); err != nil {
+		t.Fatalf("signMedia() error = %v", err)
+	}
+	if media.FileUrl == "" || media.ThumbnailUrl != "" || media.PreviewUrl != "" {
+		t.Errorf("signMedia() = %q, %q, %q, want only the file URL", media.FileUrl, media.ThumbnailUrl, media.PreviewUrl)
+	}
+
+	media.ThumbnailKey, media.PreviewKey = derivedImageKeys(media.ObjectKey)
+	if err := signMedia(context.Background(), store, time.Hour, media); err != nil {
+		t.Fatalf("signMedia() error = %v", err)
+	}
+	if !strings.Contains(media.ThumbnailUrl, "photo.jpg.thumbnail.jpg") || !strings.Contains(media.PreviewUrl, "photo.jpg.preview.jpg") {
+		t.Errorf("signMedia() = %q, %q, want the thumbnail and preview URLs", media.ThumbnailUrl, media.PreviewUrl)
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"scs-guard/pkg/errors"
+	"scs-guard/pkg/storage"
+	"strings"
+	"time"
+)
+
+// reconcileBatchSize is how many stored objects are looked up in the database at once
+const reconcileBatchSize = 500
+
+// ReconcileStorage looks for orphaned objects in storage and, when configured to, removes them.
+// It returns the orphans found and how many of them were removed.
+func (s *MediaService) ReconcileStorage(ctx context.Context) ([]storage.ObjectInfo, int, error) {
+	orphans, err := s.FindOrphanedObjects(ctx)
+	if err != nil || !s.mediaCfg.DeleteOrphans {
+		return orphans, 0, err
+	}
+	removed := 0
+	for _, orphan := range orphans {
+		if err := s.storage.Delete(ctx, orphan.Key); err != nil && !storage.IsNotFound(err) {
+			return orphans, removed, errors.NewInternalError("failed to remove an orphaned object", err)
+		}
+		removed++
+	}
+	return orphans, removed, nil
+}
+
+// FindOrphanedObjects returns the objects in storage that belong to no media and no pending
+// upload, such as files left behind by an upload whose removal failed after the upload itself
+// did. Thumbnails, previews and stripped copies belong to the media of the file they were made
+// of, and the files of deleted media belong to them until they are purged. Objects younger than
+// the grace period are skipped, since the media of their upload may not be recorded yet.
+func (s *MediaService) FindOrphanedObjects(ctx context.Context) ([]storage.ObjectInfo, error) {
+	objects, err := s.storage.List(ctx, "")
+	if err != nil {
+		return nil, errors.NewInternalError("failed to list stored objects", err)
+	}
+	cutoff := time.Now().Add(-s.mediaCfg.OrphanGracePeriod)
+	var candidates []storage.ObjectInfo
+	for _, object := range objects {
+		if object.LastModified.Before(cutoff) {
+			candidates = append(candidates, object)
+		}
+	}
+
+	known := make(map[string]bool)
+	for start := 0; start < len(candidates); start += reconcileBatchSize {
+		var keys []string
+		for _, object := range candidates[start:min(start+reconcileBatchSize, len(candidates))] {
+			keys = append(keys, object.Key)
+			if source := sourceObjectKey(object.Key); source != object.Key {
+				keys = append(keys, source)
+			}
+		}
+		stored, err := s.incidentMediaRepo.GetStoredKeys(ctx, keys)
+		if err != nil {
+			return nil, errors.NewDatabaseError("get stored media keys", err)
+		}
+		pending, err := s.mediaUploadRepo.GetPendingKeys(ctx, keys)
+		if err != nil {
+			return nil, errors.NewDatabaseError("get pending upload keys", err)
+		}
+		for _, key := range append(stored, pending...) {
+			known[key] = true
+		}
+	}
+
+	var orphans []storage.ObjectInfo
+	for _, object := range candidates {
+		if !known[object.Key] && !known[sourceObjectKey(object.Key)] {
+			orphans = append(orphans, object)
+		}
+	}
+	return orphans, nil
+}
+
+// sourceObjectKey returns the key of the file a thumbnail, preview or stripped copy was made of,
+// and the key itself for any other object
+func sourceObjectKey(key string) string {
+	for _, suffix := range []string{thumbnailKeySuffix, previewKeySuffix, strippedKeySuffix} {
+		if source, ok := strings.CutSuffix(key, suffix); ok {
+			return source
+		}
+	}
+	return key
+}
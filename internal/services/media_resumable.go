@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"scs-guard/internal/dto"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/errors"
+	"scs-guard/pkg/storage"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// abandonedUploadBatchSize is how many abandoned uploads are discarded per cleanup run
+const abandonedUploadBatchSize = 100
+
+// InitiateResumableUpload starts an upload of a large image or video of an incident that the guard
+// sends in parts, so that a dropped connection only loses the part being sent. The file size is
+// checked against the maximum size of its media type up front.
+func (s *MissionService) InitiateResumableUpload(ctx context.Context, userID string, initDto dto.InitiateResumableUploadDto) (*dto.ResumableUploadDto, error) {
+	incident, _, step, err := s.getMediaTarget(ctx, userID, initDto.IncidentID, initDto.StepID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMediaType(initDto.ContentType, step, initDto.Signature); err != nil {
+		return nil, err
+	}
+	if maxSize := s.maxMediaSize(getFileType(initDto.ContentType)); initDto.FileSize > maxSize {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("file size exceeds %d bytes", maxSize))
+	}
+	uploaderID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid user ID")
+	}
+
+	id := uuid.New()
+	upload := &models.MediaUpload{
+		Base:         models.Base{ID: id},
+		IncidentID:   incident.ID,
+		Signature:    initDto.Signature,
+		UploadedByID: uploaderID,
+		ObjectKey:    mediaObjectKey(incident.ID, id, initDto.FileName),
+		FileName:     initDto.FileName,
+		ContentType:  initDto.ContentType,
+		Status:       models.MediaUploadStatusPending,
+		ExpiresAt:    time.Now().Add(s.mediaCfg.UploadTTL),
+		FileSize:     initDto.FileSize,
+		PartSize:     s.mediaCfg.PartSize,
+	}
+	if step != nil {
+		upload.IncidentGuidanceStepID = &step.ID
+	}
+	upload.MultipartUploadID, err = s.storage.NewMultipartUpload(ctx, upload.ObjectKey, upload.ContentType)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to start the upload", err)
+	}
+	if err := s.mediaUploadRepo.Create(ctx, upload); err != nil {
+		return nil, errors.NewDatabaseError("create media upload", err)
+	}
+	return toResumableUploadDto(upload), nil
+}
+
+// GetResumableUpload returns the state of a resumable upload so that the guard can send the parts
+// that are missing
+func (s *MissionService) GetResumableUpload(ctx context.Context, userID string, uploadID string) (*dto.ResumableUploadDto, error) {
+	upload, err := s.getOwnUpload(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if !upload.Resumable() {
+		return nil, errors.NewBadRequestError("the upload is not resumable")
+	}
+	return toResumableUploadDto(upload), nil
+}
+
+// UploadPart stores a part of a resumable upload. A part can be sent again, which replaces it.
+// Every part has the part size except the last, which holds the rest of the file.
+func (s *MissionService) UploadPart(ctx context.Context, userID string, uploadID string, partNumber int, data io.Reader, size int64) (*models.MediaUploadPart, error) {
+	upload, err := s.getPendingUpload(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if !upload.Resumable() {
+		return nil, errors.NewBadRequestError("the upload is not resumable")
+	}
+	if partNumber < 1 || partNumber > upload.PartCount() {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("part number must be between 1 and %d", upload.PartCount()))
+	}
+	if expected := upload.ExpectedPartSize(partNumber); size != expected {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("part %d must be %d bytes, got %d", partNumber, expected, size))
+	}
+
+	etag, err := s.storage.PutPart(ctx, upload.ObjectKey, upload.MultipartUploadID, partNumber, data, size)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			return nil, errors.NewConflictError("the upload was discarded")
+		}
+		return nil, errors.NewInternalError("failed to store the part", err)
+	}
+	part := &models.MediaUploadPart{
+		MediaUploadID: upload.ID,
+		PartNumber:    partNumber,
+		ETag:          etag,
+		Size:          size,
+	}
+	if err := s.mediaUploadRepo.SavePart(ctx, part, time.Now().Add(s.mediaCfg.UploadTTL)); err != nil {
+		return nil, errors.NewDatabaseError("save media upload part", err)
+	}
+	return part, nil
+}
+
+// CompleteResumableUpload assembles the parts of a resumable upload once all of them were sent
+// and records the file as incident media
+func (s *MissionService) CompleteResumableUpload(ctx context.Context, userID string, uploadID string) (*models.IncidentMedia, error) {
+	upload, err := s.getPendingUpload(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if !upload.Resumable() {
+		return nil, errors.NewBadRequestError("the upload is not resumable, confirm it instead")
+	}
+	if missing := upload.PartCount() - len(upload.Parts); missing > 0 {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("%d of %d parts have not been uploaded yet", missing, upload.PartCount()))
+	}
+
+	parts := make([]storage.CompletePart, len(upload.Parts))
+	for i, part := range upload.Parts {
+		parts[i] = storage.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag}
+	}
+	// The multipart upload is gone when an earlier attempt assembled the file but failed to
+	// record it, in which case the file is recorded now
+	if err := s.storage.CompleteMultipartUpload(ctx, upload.ObjectKey, upload.MultipartUploadID, parts); err != nil && !storage.IsNotFound(err) {
+		return nil, errors.NewInternalError("failed to assemble the uploaded parts", err)
+	}
+	return s.recordUploadedMedia(ctx, userID, upload)
+}
+
+// AbortMediaUpload discards an upload that was not confirmed or completed, along with whatever
+// was stored of its file
+func (s *MissionService) AbortMediaUpload(ctx context.Context, userID string, uploadID string) error {
+	upload, err := s.getPendingUpload(ctx, userID, uploadID)
+	if err != nil {
+		return err
+	}
+	if err := s.discardUploadedData(ctx, upload); err != nil {
+		return errors.NewInternalError("failed to discard the uploaded data", err)
+	}
+	ok, err := s.mediaUploadRepo.UpdateStatus(ctx, uploadID, models.MediaUploadStatusAborted)
+	if err != nil {
+		return errors.NewDatabaseError("abort media upload", err)
+	}
+	if !ok {
+		return errors.NewConflictError("the upload was confirmed or discarded by another request")
+	}
+	return nil
+}
+
+// CleanupAbandonedUploads discards pending uploads that had no activity for longer than the
+// upload TTL and returns how many were discarded
+func (s *MissionService) CleanupAbandonedUploads(ctx context.Context) (int, error) {
+	uploads, err := s.mediaUploadRepo.GetAbandoned(ctx, time.Now().Add(-s.mediaCfg.UploadTTL), abandonedUploadBatchSize)
+	if err != nil {
+		return 0, errors.NewDatabaseError("get abandoned media uploads", err)
+	}
+	discarded := 0
+	for i := range uploads {
+		if err := s.discardUploadedData(ctx, &uploads[i]); err != nil {
+			return discarded, errors.NewInternalError("failed to discard the uploaded data", err)
+		}
+		ok, err := s.mediaUploadRepo.UpdateStatus(ctx, uploads[i].ID.String(), models.MediaUploadStatusExpired)
+		if err != nil {
+			return discarded, errors.NewDatabaseError("expire media upload", err)
+		}
+		if ok {
+			discarded++
+		}
+	}
+	return discarded, nil
+}
+
+// discardUploadedData deletes the parts and the file stored for an upload. Data that is already
+// gone is ignored.
+func (s *MissionService) discardUploadedData(ctx context.Context, upload *models.MediaUpload) error {
+	if upload.Resumable() {
+		if err := s.storage.AbortMultipartUpload(ctx, upload.ObjectKey, upload.MultipartUploadID); err != nil && !storage.IsNotFound(err) {
+			return err
+		}
+	}
+	if err := s.storage.Delete(ctx, upload.ObjectKey); err != nil && !storage.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func toResumableUploadDto(upload *models.MediaUpload) *dto.ResumableUploadDto {
+	uploaded := make([]int, 0, len(upload.Parts))
+	for _, part := range upload.Parts {
+		uploaded = append(uploaded, part.PartNumber)
+	}
+	return &dto.ResumableUploadDto{
+		UploadID:      upload.ID.String(),
+		ObjectKey:     upload.ObjectKey,
+		Status:        upload.Status,
+		FileSize:      upload.FileSize,
+		PartSize:      upload.PartSize,
+		PartCount:     upload.PartCount(),
+		UploadedParts: uploaded,
+		ExpiresAt:     upload.ExpiresAt,
+	}
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"scs-guard/config"
+	"scs-guard/internal/models"
+	"testing"
+)
+
+func TestMediaUploadParts(t *testing.T) {
+	tests := []struct {
+		name      string
+		fileSize  int64
+		partSize  int64
+		wantCount int
+		wantLast  int64
+	}{
+		{"single small part", 100, 1000, 1, 100},
+		{"exact multiple", 3000, 1000, 3, 1000},
+		{"remainder", 2500, 1000, 3, 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upload := &models.MediaUpload{FileSize: tt.fileSize, PartSize: tt.partSize}
+			if got := upload.PartCount(); got != tt.wantCount {
+				t.Fatalf("PartCount() = %d, want %d", got, tt.wantCount)
+			}
+			if got := upload.ExpectedPartSize(tt.wantCount); got != tt.wantLast {
+				t.Errorf("ExpectedPartSize(last) = %d, want %d", got, tt.wantLast)
+			}
+			if tt.wantCount > 1 {
+				if got := upload.ExpectedPartSize(1); got != tt.partSize {
+					t.Errorf("ExpectedPartSize(1) = %d, want %d", got, tt.partSize)
+				}
+			}
+		})
+	}
+}
+
+func TestMaxMediaSize(t *testing.T) {
+	s := &MissionService{mediaCfg: config.MediaConfig{MaxImageSize: 10, MaxVideoSize: 2000}}
+	if got := s.maxMediaSize("image"); got != 10 {
+		t.Errorf("maxMediaSize(image) = %d, want 10", got)
+	}
+	if got := s.maxMediaSize("video"); got != 2000 {
+		t.Errorf("maxMediaSize(video) = %d, want 2000", got)
+	}
+}
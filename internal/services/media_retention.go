@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/errors"
+	"scs-guard/pkg/storage"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// purgeBatchSize is how many deleted media have their files purged per run
+const purgeBatchSize = 100
+
+// DeleteMedia removes a media uploaded by mistake from its incident. The media is kept with who
+// deleted it and why, and its files stay in storage until the retention period after deletion
+// expires. The deletion is recorded in the chain of custody.
+func (s *MediaService) DeleteMedia(ctx context.Context, userID string, incidentID string, mediaID string, reason string) (*models.IncidentMedia, error) {
+	media, err := s.getMedia(ctx, incidentID, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid user id")
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		ok, err := s.incidentMediaRepo.SoftDelete(ctx, mediaID, map[string]interface{}{
+			"deleted_at":    time.Now(),
+			"deleted_by_id": actor,
+			"delete_reason": reason,
+		})
+		if err != nil {
+			return errors.NewDatabaseError("delete media", err)
+		}
+		if !ok {
+			return errors.NewConflictError("media is already deleted")
+		}
+		entry := newCustodyEntry(media, models.CustodyActionDeleted, &actor, reason)
+		if err := s.custodyRepo.BatchCreate(ctx, []models.MediaCustodyEntry{entry}); err != nil {
+			return errors.NewDatabaseError("record media custody", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getMedia(ctx, incidentID, mediaID)
+}
+
+// PurgeDeletedMedia removes from storage the files of media deleted longer than the retention
+// period ago, along with their thumbnails, previews and stripped copies, and returns how many
+// media were purged. The media and their chain of custody are kept.
+func (s *MediaService) PurgeDeletedMedia(ctx context.Context) (int, error) {
+	medias, err := s.incidentMediaRepo.GetPurgeable(ctx, time.Now().Add(-s.mediaCfg.DeletedRetention), purgeBatchSize)
+	if err != nil {
+		return 0, errors.NewDatabaseError("get purgeable media", err)
+	}
+	purged := 0
+	for i := range medias {
+		media := &medias[i]
+		for _, key := range storedKeys(media) {
+			if err := s.storage.Delete(ctx, key); err != nil && !storage.IsNotFound(err) {
+				return purged, errors.NewInternalError("failed to purge the files of a deleted media", err)
+			}
+		}
+		err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.incidentMediaRepo.Update(ctx, media.ID.String(), map[string]interface{}{"purged_at": time.Now()}); err != nil {
+				return errors.NewDatabaseError("mark media purged", err)
+			}
+			entry := newCustodyEntry(media, models.CustodyActionDeleted, nil, "files purged after the retention period")
+			if err := s.custodyRepo.BatchCreate(ctx, []models.MediaCustodyEntry{entry}); err != nil {
+				return errors.NewDatabaseError("record media custody", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// storedKeys returns the keys of every object stored for a media: its file, the thumbnail and
+// preview made of it and the copy without metadata handed out on export
+func storedKeys(media *models.IncidentMedia) []string {
+	keys := []string{media.Key(), strippedImageKey(media.Key())}
+	for _, key := range []string{media.ThumbnailKey, media.PreviewKey} {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
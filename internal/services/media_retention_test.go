@@ -0,0 +1,38 @@
+package services
+
+import (
+	"scs-guard/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestStartsDownload(t *testing.T) {
+	tests := []struct {
+		rangeHeader string
+		want        bool
+	}{
+		{"", true},
+		{"bytes=0-", true},
+		{"bytes=0-1048575", true},
+		{"bytes=1048576-", false},
+		{"bytes=-500", false},
+	}
+	for _, tt := range tests {
+		if got := startsDownload(tt.rangeHeader); got != tt.want {
+			t.Errorf("startsDownload(%q) = %v, want %v", tt.rangeHeader, got, tt.want)
+		}
+	}
+}
+
+func TestStoredKeys(t *testing.T) {
+	media := &models.IncidentMedia{ObjectKey: "incident/photo.jpg"}
+	if got := strings.Join(storedKeys(media), ","); got != "incident/photo.jpg,incident/photo.jpg.stripped.jpg" {
+		t.Errorf("storedKeys() = %s, want the file and its stripped copy", got)
+	}
+
+	media.ThumbnailKey, media.PreviewKey = derivedImageKeys(media.ObjectKey)
+	want := "incident/photo.jpg,incident/photo.jpg.stripped.jpg,incident/photo.jpg.thumbnail.jpg,incident/photo.jpg.preview.jpg"
+	if got := strings.Join(storedKeys(media), ","); got != want {
+		t.Errorf("storedKeys() = %s, want %s", got, want)
+	}
+}
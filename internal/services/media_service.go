@@ -0,0 +1,303 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	config "scs-guard/config"
+	"scs-guard/internal/dto"
+	"scs-guard/internal/models"
+	repositories "scs-guard/internal/repositories"
+	"scs-guard/pkg/errors"
+	"scs-guard/pkg/exif"
+	"scs-guard/pkg/storage"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MediaService gives operators access to the media of incidents and keeps their chain of custody:
+// every upload, view, download, export and verification of a file is recorded with its actor
+type MediaService struct {
+	incidentMediaRepo repositories.IncidentMediaRepository
+	custodyRepo       repositories.MediaCustodyRepository
+	mediaUploadRepo   repositories.MediaUploadRepository
+	storage           storage.Storage
+	mediaCfg          config.MediaConfig
+	txManager         repositories.TransactionManager
+}
+
+func NewMediaService(incidentMediaRepo repositories.IncidentMediaRepository, custodyRepo repositories.MediaCustodyRepository, mediaUploadRepo repositories.MediaUploadRepository, store storage.Storage, mediaCfg config.MediaConfig, txManager repositories.TransactionManager) *MediaService {
+	return &MediaService{
+		incidentMediaRepo: incidentMediaRepo,
+		custodyRepo:       custodyRepo,
+		mediaUploadRepo:   mediaUploadRepo,
+		storage:           store,
+		mediaCfg:          mediaCfg,
+		txManager:         txManager,
+	}
+}
+
+// GetMediaPage returns a page of the media of an incident, oldest first, with download URLs. The
+// media on the page are recorded as viewed in their chain of custody.
+func (s *MediaService) GetMediaPage(ctx context.Context, userID string, filter repositories.IncidentMediaFilter) (*dto.MediaPageDto, error) {
+	if _, err := uuid.Parse(filter.IncidentID); err != nil {
+		return nil, errors.NewNotFoundError("incident")
+	}
+	medias, total, err := s.incidentMediaRepo.GetPage(ctx, filter)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get media", err)
+	}
+	custody := make([]models.MediaCustodyEntry, 0, len(medias))
+	for i := range medias {
+		if medias[i].PurgedAt == nil {
+			if err := signMedia(ctx, s.storage, s.mediaCfg.DownloadURLExpiry, &medias[i]); err != nil {
+				return nil, err
+			}
+		}
+		custody = append(custody, newCustodyEntry(&medias[i], models.CustodyActionViewed, actorID(userID), ""))
+	}
+	if err := s.custodyRepo.BatchCreate(ctx, custody); err != nil {
+		return nil, errors.NewDatabaseError("record media custody", err)
+	}
+	return &dto.MediaPageDto{Media: medias, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+// GetMedia returns a media of an incident with download URLs of its file and, once they were made,
+// of its thumbnail and preview. Deleted media are returned as well, without URLs once their files
+// were purged. The view is recorded in the chain of custody.
+func (s *MediaService) GetMedia(ctx context.Context, userID string, incidentID string, mediaID string) (*models.IncidentMedia, error) {
+	media, err := s.getMedia(ctx, incidentID, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if media.PurgedAt == nil {
+		if err := signMedia(ctx, s.storage, s.mediaCfg.DownloadURLExpiry, media); err != nil {
+			return nil, err
+		}
+	}
+	entry := newCustodyEntry(media, models.CustodyActionViewed, actorID(userID), "")
+	if err := s.custodyRepo.BatchCreate(ctx, []models.MediaCustodyEntry{entry}); err != nil {
+		return nil, errors.NewDatabaseError("record media custody", err)
+	}
+	return media, nil
+}
+
+// GetCustody returns the chain of custody of a media of an incident, oldest first
+func (s *MediaService) GetCustody(ctx context.Context, incidentID string, mediaID string) ([]models.MediaCustodyEntry, error) {
+	media, err := s.getMedia(ctx, incidentID, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.custodyRepo.GetByIncidentMediaID(ctx, media.ID.String())
+	if err != nil {
+		return nil, errors.NewDatabaseError("get media custody", err)
+	}
+	return entries, nil
+}
+
+// VerifyMedia re-hashes the stored file of a media and compares it with the hash recorded at
+// upload. The verification and its outcome are recorded in the chain of custody.
+func (s *MediaService) VerifyMedia(ctx context.Context, userID string, incidentID string, mediaID string) (*dto.MediaVerificationDto, error) {
+	media, err := s.getMedia(ctx, incidentID, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	result := &dto.MediaVerificationDto{
+		MediaID:        media.ID.String(),
+		ExpectedSHA256: media.SHA256,
+		ExpectedSize:   media.FileSize,
+		VerifiedAt:     time.Now(),
+	}
+	result.ActualSHA256, result.ActualSize, err = hashObject(ctx, s.storage, media.Key())
+	switch {
+	case storage.IsNotFound(err):
+		result.Status = dto.MediaIntegrityMissing
+	case err != nil:
+		return nil, errors.NewInternalError("failed to hash the stored file", err)
+	default:
+		result.Status = integrityStatus(media, result.ActualSHA256, result.ActualSize)
+	}
+
+	entry := newCustodyEntry(media, models.CustodyActionVerified, actorID(userID), result.Status)
+	entry.SHA256 = result.ActualSHA256
+	if err := s.custodyRepo.BatchCreate(ctx, []models.MediaCustodyEntry{entry}); err != nil {
+		return nil, errors.NewDatabaseError("record media custody", err)
+	}
+	return result, nil
+}
+
+// OpenMedia opens the file of a media to be streamed to userID. rangeHeader is the Range header of
+// the request: a download is recorded in the chain of custody when it starts at the beginning of
+// the file, so that a video player fetching the rest of the file in ranges records it once.
+func (s *MediaService) OpenMedia(ctx context.Context, userID string, incidentID string, mediaID string, rangeHeader string) (*models.IncidentMedia, io.ReadSeekCloser, storage.ObjectInfo, error) {
+	media, err := s.getMedia(ctx, incidentID, mediaID)
+	if err != nil {
+		return nil, nil, storage.ObjectInfo{}, err
+	}
+	if media.PurgedAt != nil {
+		return nil, nil, storage.ObjectInfo{}, errors.NewNotFoundError("file")
+	}
+	object, info, err := s.storage.Get(ctx, media.Key())
+	if err != nil {
+		if storage.IsNotFound(err) {
+			return nil, nil, storage.ObjectInfo{}, errors.NewNotFoundError("file")
+		}
+		return nil, nil, storage.ObjectInfo{}, errors.NewInternalError("failed to read the file", err)
+	}
+	if startsDownload(rangeHeader) {
+		entry := newCustodyEntry(media, models.CustodyActionDownloaded, actorID(userID), "")
+		if err := s.custodyRepo.BatchCreate(ctx, []models.MediaCustodyEntry{entry}); err != nil {
+			object.Close()
+			return nil, nil, storage.ObjectInfo{}, errors.NewDatabaseError("record media custody", err)
+		}
+	}
+	return media, object, info, nil
+}
+
+// startsDownload reports whether a request with the given Range header reads the file from its
+// first byte
+func startsDownload(rangeHeader string) bool {
+	return rangeHeader == "" || strings.HasPrefix(strings.TrimSpace(rangeHeader), "bytes=0-")
+}
+
+// ExportMedia hands the file of a media out of the system, for example for a police report or an
+// insurance claim. The export is recorded in the chain of custody with its recipient, and the
+// media is returned with a presigned download URL and the hash the recipient can check it against.
+func (s *MediaService) ExportMedia(ctx context.Context, userID string, incidentID string, mediaID string, exportDto dto.ExportMediaDto) (*models.IncidentMedia, error) {
+	media, err := s.getMedia(ctx, incidentID, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if media.PurgedAt != nil {
+		return nil, errors.NewNotFoundError("file")
+	}
+	medias := []models.IncidentMedia{*media}
+	if err := signMediaURLs(ctx, s.storage, s.mediaCfg.DownloadURLExpiry, medias); err != nil {
+		return nil, err
+	}
+	exported := &medias[0]
+	details := exportDto.Recipient
+	if exportDto.Reason != "" {
+		details = fmt.Sprintf("%s: %s", exportDto.Recipient, exportDto.Reason)
+	}
+	if exportDto.StripMetadata {
+		if err := s.stripMetadata(ctx, exported); err != nil {
+			return nil, err
+		}
+		details += " (metadata stripped)"
+	}
+	entry := newCustodyEntry(media, models.CustodyActionExported, actorID(userID), details)
+	entry.SHA256 = exported.SHA256
+	if err := s.custodyRepo.BatchCreate(ctx, []models.MediaCustodyEntry{entry}); err != nil {
+		return nil, errors.NewDatabaseError("record media custody", err)
+	}
+	return exported, nil
+}
+
+// stripMetadata stores a copy of a JPEG photo without its metadata next to the original and
+// points the download URL, size and hash of media at the copy
+func (s *MediaService) stripMetadata(ctx context.Context, media *models.IncidentMedia) error {
+	if media.MediaType != "image" {
+		return errors.NewBadRequestError("metadata can only be stripped from JPEG photos")
+	}
+	object, _, err := s.storage.Get(ctx, media.Key())
+	if err != nil {
+		if storage.IsNotFound(err) {
+			return errors.NewConflictError("the file is missing from storage")
+		}
+		return errors.NewInternalError("failed to read the file", err)
+	}
+	defer object.Close()
+	var stripped bytes.Buffer
+	if err := exif.Strip(&stripped, object); err != nil {
+		if exif.IsNotJPEG(err) {
+			return errors.NewBadRequestError("metadata can only be stripped from JPEG photos")
+		}
+		return errors.NewInternalError("failed to strip the metadata", err)
+	}
+
+	hash := sha256.Sum256(stripped.Bytes())
+	key := strippedImageKey(media.Key())
+	size := int64(stripped.Len())
+	if _, err := s.storage.Put(ctx, key, &stripped, size, "image/jpeg"); err != nil {
+		return errors.NewInternalError("failed to store the stripped copy", err)
+	}
+	u, err := s.storage.PresignGet(ctx, key, s.mediaCfg.DownloadURLExpiry)
+	if err != nil {
+		return errors.NewInternalError("failed to create download URL", err)
+	}
+	media.FileUrl = u.String()
+	media.FileSize = size
+	media.SHA256 = hex.EncodeToString(hash[:])
+	return nil
+}
+
+// integrityStatus compares the hash and size of a stored file with those recorded for its media
+func integrityStatus(media *models.IncidentMedia, sha256 string, size int64) string {
+	if media.SHA256 == "" {
+		return dto.MediaIntegrityUnhashed
+	}
+	if sha256 != media.SHA256 || size != media.FileSize {
+		return dto.MediaIntegrityTampered
+	}
+	return dto.MediaIntegrityIntact
+}
+
+func (s *MediaService) getMedia(ctx context.Context, incidentID string, mediaID string) (*models.IncidentMedia, error) {
+	if _, err := uuid.Parse(incidentID); err != nil {
+		return nil, errors.NewNotFoundError("media")
+	}
+	if _, err := uuid.Parse(mediaID); err != nil {
+		return nil, errors.NewNotFoundError("media")
+	}
+	media, err := s.incidentMediaRepo.GetByID(ctx, incidentID, mediaID)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("media")
+		}
+		return nil, errors.NewDatabaseError("get media", err)
+	}
+	return media, nil
+}
+
+// newCustodyEntry records an action on a media in its chain of custody, along with the hash the
+// file had when it was uploaded
+func newCustodyEntry(media *models.IncidentMedia, action string, actorID *uuid.UUID, details string) models.MediaCustodyEntry {
+	return models.MediaCustodyEntry{
+		IncidentMediaID: media.ID,
+		IncidentID:      media.IncidentID,
+		Action:          action,
+		ActorID:         actorID,
+		SHA256:          media.SHA256,
+		Details:         details,
+	}
+}
+
+// actorID parses the ID of the user performing an action. An invalid ID records no actor.
+func actorID(userID string) *uuid.UUID {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// hashObject reads a stored file and returns its hex SHA-256 hash and size
+func hashObject(ctx context.Context, store storage.Storage, key string) (string, int64, error) {
+	object, _, err := store.Get(ctx, key)
+	if err != nil {
+		return "", 0, err
+	}
+	defer object.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, object)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
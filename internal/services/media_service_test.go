@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"scs-guard/internal/dto"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/logger"
+	"scs-guard/pkg/storage"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestHashObject(t *testing.T) {
+	store, err := storage.NewLocalStorage(t.TempDir(), "http://localhost:8080", "secret", logger.GetLogger())
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	ctx := context.Background()
+	if _, err := store.Put(ctx, "incident/photo.jpg", strings.NewReader("abc"), 3, "image/jpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	hash, size, err := hashObject(ctx, store, "incident/photo.jpg")
+	if err != nil {
+		t.Fatalf("hashObject() error = %v", err)
+	}
+	if want := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"; hash != want || size != 3 {
+		t.Errorf("hashObject() = %s, %d, want %s, 3", hash, size, want)
+	}
+	if _, _, err := hashObject(ctx, store, "incident/missing.jpg"); !storage.IsNotFound(err) {
+		t.Errorf("hashObject() of a missing file error = %v, want not found", err)
+	}
+}
+
+func TestIntegrityStatus(t *testing.T) {
+	media := &models.IncidentMedia{SHA256: "abc", FileSize: 3}
+	tests := []struct {
+		name  string
+		media *models.IncidentMedia
+		hash  string
+		size  int64
+		want  string
+	}{
+		{"intact", media, "abc", 3, dto.MediaIntegrityIntact},
+		{"different hash", media, "abd", 3, dto.MediaIntegrityTampered},
+		{"different size", media, "abc", 4, dto.MediaIntegrityTampered},
+		{"legacy media", &models.IncidentMedia{FileSize: 3}, "abc", 3, dto.MediaIntegrityUnhashed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := integrityStatus(tt.media, tt.hash, tt.size); got != tt.want {
+				t.Errorf("integrityStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCustodyEntry(t *testing.T) {
+	actor := uuid.New()
+	media := &models.IncidentMedia{Base: models.Base{ID: uuid.New()}, IncidentID: uuid.New(), SHA256: "abc"}
+	entry := newCustodyEntry(media, models.CustodyActionExported, &actor, "Police")
+	if entry.IncidentMediaID != media.ID || entry.IncidentID != media.IncidentID || entry.SHA256 != "abc" {
+		t.Errorf("newCustodyEntry() = %+v, want it to identify the media and carry its hash", entry)
+	}
+	if entry.ActorID == nil || *entry.ActorID != actor || entry.Action != models.CustodyActionExported || entry.Details != "Police" {
+		t.Errorf("newCustodyEntry() = %+v, want the actor, action and details", entry)
+	}
+	if actorID("not-a-uuid") != nil {
+		t.Error("expected an invalid user ID to record no actor")
+	}
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"scs-guard/internal/dto"
+	"scs-guard/internal/events"
+	"scs-guard/internal/models"
+	repositories "scs-guard/internal/repositories"
+	"scs-guard/pkg/errors"
+	"scs-guard/pkg/storage"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateMediaUpload gives the guard assigned to an incident a presigned URL to upload an image or
+// video of the incident directly to object storage. The upload must then be confirmed.
+func (s *MissionService) CreateMediaUpload(ctx context.Context, userID string, createDto dto.CreateMediaUploadDto) (*dto.MediaUploadDto, error) {
+	incident, _, step, err := s.getMediaTarget(ctx, userID, createDto.IncidentID, createDto.StepID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMediaType(createDto.ContentType, step, createDto.Signature); err != nil {
+		return nil, err
+	}
+	uploaderID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid user ID")
+	}
+
+	id := uuid.New()
+	upload := &models.MediaUpload{
+		Base:         models.Base{ID: id},
+		IncidentID:   incident.ID,
+		Signature:    createDto.Signature,
+		UploadedByID: uploaderID,
+		ObjectKey:    mediaObjectKey(incident.ID, id, createDto.FileName),
+		FileName:     createDto.FileName,
+		ContentType:  createDto.ContentType,
+		Status:       models.MediaUploadStatusPending,
+		ExpiresAt:    time.Now().Add(s.mediaCfg.UploadURLExpiry),
+	}
+	if step != nil {
+		upload.IncidentGuidanceStepID = &step.ID
+	}
+	uploadURL, err := s.storage.PresignPut(ctx, upload.ObjectKey, s.mediaCfg.UploadURLExpiry)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to create upload URL", err)
+	}
+	if err := s.mediaUploadRepo.Create(ctx, upload); err != nil {
+		return nil, errors.NewDatabaseError("create media upload", err)
+	}
+	return &dto.MediaUploadDto{
+		UploadID:  id.String(),
+		ObjectKey: upload.ObjectKey,
+		UploadURL: uploadURL.String(),
+		Method:    "PUT",
+		Headers:   map[string]string{"Content-Type": upload.ContentType},
+		ExpiresAt: upload.ExpiresAt,
+	}, nil
+}
+
+// ConfirmMediaUpload checks that the file of an upload is in object storage and records it as
+// incident media. Files larger than the maximum size for their media type are deleted.
+func (s *MissionService) ConfirmMediaUpload(ctx context.Context, userID string, uploadID string) (*models.IncidentMedia, error) {
+	upload, err := s.getPendingUpload(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Resumable() {
+		return nil, errors.NewBadRequestError("a resumable upload must be completed instead")
+	}
+	return s.recordUploadedMedia(ctx, userID, upload)
+}
+
+// getPendingUpload loads an upload created by userID that was not confirmed or discarded yet
+func (s *MissionService) getPendingUpload(ctx context.Context, userID string, uploadID string) (*models.MediaUpload, error) {
+	upload, err := s.getOwnUpload(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Status != models.MediaUploadStatusPending {
+		return nil, errors.NewConflictError(fmt.Sprintf("the upload is already %s", upload.Status))
+	}
+	return upload, nil
+}
+
+// getOwnUpload loads an upload and checks that it was created by userID
+func (s *MissionService) getOwnUpload(ctx context.Context, userID string, uploadID string) (*models.MediaUpload, error) {
+	if _, err := uuid.Parse(uploadID); err != nil {
+		return nil, errors.NewNotFoundError("media upload")
+	}
+	upload, err := s.mediaUploadRepo.GetByID(ctx, uploadID)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("media upload")
+		}
+		return nil, errors.NewDatabaseError("get media upload", err)
+	}
+	if upload.UploadedByID.String() != userID {
+		return nil, errors.NewForbiddenError("the upload was not created by you")
+	}
+	return upload, nil
+}
+
+// recordUploadedMedia records the file of an upload that is in object storage as incident media
+// and marks the upload confirmed
+func (s *MissionService) recordUploadedMedia(ctx context.Context, userID string, upload *models.MediaUpload) (*models.IncidentMedia, error) {
+	stepID := ""
+	if upload.IncidentGuidanceStepID != nil {
+		stepID = upload.IncidentGuidanceStepID.String()
+	}
+	incident, guidance, _, err := s.getMediaTarget(ctx, userID, upload.IncidentID.String(), stepID)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.storage.Stat(ctx, upload.ObjectKey)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			return nil, errors.NewBadRequestError("the file has not been uploaded yet")
+		}
+		return nil, errors.NewInternalError("failed to check the uploaded file", err)
+	}
+	mediaType := getFileType(upload.ContentType)
+	if maxSize := s.maxMediaSize(mediaType); info.Size > maxSize {
+		if err := s.storage.Delete(ctx, upload.ObjectKey); err != nil {
+			return nil, errors.NewInternalError("failed to delete the uploaded file", err)
+		}
+		return nil, errors.NewBadRequestError(fmt.Sprintf("the file is larger than %d bytes and was deleted", maxSize))
+	}
+	if info.ContentType != upload.ContentType {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("the file must be uploaded with Content-Type %s, got %s", upload.ContentType, info.ContentType))
+	}
+
+	hash, _, err := hashObject(ctx, s.storage, upload.ObjectKey)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to hash the uploaded file", err)
+	}
+
+	media := models.IncidentMedia{
+		Base:                   models.Base{ID: uuid.New()},
+		IncidentID:             upload.IncidentID,
+		MediaType:              mediaType,
+		FileSize:               info.Size,
+		FileType:               upload.ContentType,
+		FileName:               upload.FileName,
+		ObjectKey:              upload.ObjectKey,
+		IncidentGuidanceStepID: upload.IncidentGuidanceStepID,
+		Signature:              upload.Signature,
+		UploadedByID:           &upload.UploadedByID,
+		SHA256:                 hash,
+		ProcessingStatus:       models.MediaProcessingPending,
+	}
+	if mediaType == "image" {
+		applyPhotoMetadata(&media, s.readStoredPhotoMetadata(ctx, upload.ObjectKey), incident, s.mediaCfg)
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.incidentMediaRepo.BatchCreate(ctx, []models.IncidentMedia{media}); err != nil {
+			return errors.NewDatabaseError("create incident media", err)
+		}
+		ok, err := s.mediaUploadRepo.Confirm(ctx, upload.ID.String(), media.ID.String())
+		if err != nil {
+			return errors.NewDatabaseError("confirm media upload", err)
+		}
+		if !ok {
+			return errors.NewConflictError("the upload was confirmed or discarded by another request")
+		}
+		if err := s.recordUploadCustody(ctx, []models.IncidentMedia{media}); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, events.TypeMediaUploaded, guidance, []models.IncidentMedia{media})
+	})
+	if err != nil {
+		return nil, err
+	}
+	medias := []models.IncidentMedia{media}
+	if err := signMediaURLs(ctx, s.storage, s.mediaCfg.DownloadURLExpiry, medias); err != nil {
+		return nil, err
+	}
+	return &medias[0], nil
+}
+
+// maxMediaSize returns the size in bytes of the largest file of mediaType that can be uploaded
+func (s *MissionService) maxMediaSize(mediaType string) int64 {
+	if mediaType == "video" {
+		return s.mediaCfg.MaxVideoSize
+	}
+	return s.mediaCfg.MaxImageSize
+}
+
+// recordUploadCustody starts the chain of custody of uploaded media
+func (s *MissionService) recordUploadCustody(ctx context.Context, medias []models.IncidentMedia) error {
+	entries := make([]models.MediaCustodyEntry, len(medias))
+	for i := range medias {
+		entries[i] = newCustodyEntry(&medias[i], models.CustodyActionUploaded, medias[i].UploadedByID, "")
+	}
+	if err := s.custodyRepo.BatchCreate(ctx, entries); err != nil {
+		return errors.NewDatabaseError("record media custody", err)
+	}
+	return nil
+}
+
+// getMediaTarget loads the incident media is added to and checks that its mission is assigned to
+// userID. When stepID is set it also returns that step of the mission.
+func (s *MissionService) getMediaTarget(ctx context.Context, userID string, incidentID string, stepID string) (*models.Incident, *models.IncidentGuidance, *models.IncidentGuidanceStep, error) {
+	incident, err := s.incidentRepo.GetIncidentByID(ctx, incidentID)
+	if err != nil {
+		return nil, nil, nil, errors.NewBadRequestError("incident not found")
+	}
+	// Only the guard assigned to the incident's mission may document it
+	guidance := incident.IncidentGuidance
+	if guidance == nil || guidance.AssigneeID == nil || guidance.AssigneeID.String() != userID {
+		return nil, nil, nil, errors.NewForbiddenError("incident is not assigned to you")
+	}
+	if stepID == "" {
+		return incident, guidance, nil, nil
+	}
+	step := findStep(guidance, stepID)
+	if step == nil {
+		return nil, nil, nil, errors.NewNotFoundError("step")
+	}
+	return incident, guidance, step, nil
+}
+
+// checkMediaType checks that a file is an image or a video, and that a signature is an image
+// uploaded for a step
+func checkMediaType(contentType string, step *models.IncidentGuidanceStep, signature bool) error {
+	mediaType := getFileType(contentType)
+	if mediaType != "image" && mediaType != "video" {
+		return errors.NewBadRequestError("invalid file type: only image and video allowed")
+	}
+	if signature {
+		if step == nil {
+			return errors.NewBadRequestError("a signature must be uploaded for a step")
+		}
+		if mediaType != "image" {
+			return errors.NewBadRequestError("a signature must be an image")
+		}
+	}
+	return nil
+}
+
+// signMediaURLs fills in the presigned download URLs of each media
+func signMediaURLs(ctx context.Context, store storage.Storage, expiry time.Duration, medias []models.IncidentMedia) error {
+	for i := range medias {
+		if err := signMedia(ctx, store, expiry, &medias[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// signMedia fills in the presigned download URLs of the file of a media and, once they were made,
+// of its thumbnail and preview
+func signMedia(ctx context.Context, store storage.Storage, expiry time.Duration, media *models.IncidentMedia) error {
+	for _, file := range []struct {
+		key string
+		url *string
+	}{
+		{media.Key(), &media.FileUrl},
+		{media.ThumbnailKey, &media.ThumbnailUrl},
+		{media.PreviewKey, &media.PreviewUrl},
+	} {
+		if file.key == "" {
+			continue
+		}
+		u, err := store.PresignGet(ctx, file.key, expiry)
+		if err != nil {
+			return errors.NewInternalError("failed to create download URL", err)
+		}
+		*file.url = u.String()
+	}
+	return nil
+}
+
+// mediaObjectKey returns the key a file uploaded for an incident is stored under. The ID of the
+// upload or media keeps files with the same name apart, even when they are uploaded at once.
+func mediaObjectKey(incidentID uuid.UUID, id uuid.UUID, fileName string) string {
+	return fmt.Sprintf("%s/%s/%s", incidentID, id, path.Base(fileName))
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"scs-guard/internal/models"
+	"testing"
+)
+
+func TestCheckMediaType(t *testing.T) {
+	step := &models.IncidentGuidanceStep{}
+	tests := []struct {
+		name        string
+		contentType string
+		step        *models.IncidentGuidanceStep
+		signature   bool
+		wantErr     bool
+	}{
+		{"photo", "image/jpeg", nil, false, false},
+		{"gif photo", "image/gif", nil, false, false},
+		{"video", "video/mp4", step, false, false},
+		{"document", "application/pdf", nil, false, true},
+		{"signature", "image/png", step, true, false},
+		{"signature without step", "image/png", nil, true, true},
+		{"video signature", "video/mp4", step, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkMediaType(tt.contentType, tt.step, tt.signature); (err != nil) != tt.wantErr {
+				t.Errorf("checkMediaType(%q) error = %v, wantErr %v", tt.contentType, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIncidentMediaKey(t *testing.T) {
+	legacy := models.IncidentMedia{FileName: "incident/photo.jpg"}
+	if got := legacy.Key(); got != "incident/photo.jpg" {
+		t.Errorf("expected media without object key to fall back to its file name, got %q", got)
+	}
+	media := models.IncidentMedia{FileName: "photo.jpg", ObjectKey: "incident/upload/photo.jpg"}
+	if got := media.Key(); got != "incident/upload/photo.jpg" {
+		t.Errorf("expected the object key, got %q", got)
+	}
+}
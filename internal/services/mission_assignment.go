@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	config "scs-guard/config"
+	"scs-guard/internal/dto"
+	"scs-guard/internal/events"
+	"scs-guard/internal/models"
+	repositories "scs-guard/internal/repositories"
+	"scs-guard/pkg/errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// reassignableStatuses lists the statuses in which a mission can be moved to another guard
+var reassignableStatuses = []string{
+	models.MissionStatusAssigned,
+	models.MissionStatusAccepted,
+	models.MissionStatusDeclined,
+	models.MissionStatusInProgress,
+	models.MissionStatusPaused,
+}
+
+// ReassignMission moves a mission to another guard. The mission goes back to assigned so the new
+// assignee has to accept it; completed steps are kept.
+func (s *MissionService) ReassignMission(ctx context.Context, missionID string, actorID string, reassignMissionDto dto.ReassignMissionDto) (*models.IncidentGuidance, error) {
+	mission, err := s.getMission(ctx, missionID)
+	if err != nil {
+		return nil, err
+	}
+	if !isReassignable(mission.Status) {
+		return nil, errors.NewBadRequestError("a " + mission.Status + " mission cannot be reassigned")
+	}
+	if mission.AssigneeID != nil && mission.AssigneeID.String() == reassignMissionDto.AssigneeID {
+		return nil, errors.NewBadRequestError("mission is already assigned to this guard")
+	}
+	assignee, err := s.getGuard(ctx, reassignMissionDto.AssigneeID)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := uuid.Parse(actorID)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid user id")
+	}
+
+	now := time.Now()
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		ok, err := s.incidentGuidanceRepo.UpdateStatus(ctx, missionID, []string{mission.Status}, map[string]interface{}{
+			"status":           models.MissionStatusAssigned,
+			"assignee_id":      assignee.ID,
+			"assigned_at":      now,
+			"last_activity_at": now,
+			"accepted_at":      nil,
+			"declined_at":      nil,
+			"decline_reason":   "",
+			"escalated_at":     nil,
+			"escalated_to_id":  nil,
+		})
+		if err != nil {
+			return errors.NewDatabaseError("reassign mission", err)
+		}
+		if !ok {
+			return errors.NewConflictError("mission status was changed by another request")
+		}
+		if mission.Status != models.MissionStatusAssigned {
+			if err := s.statusChangeRepo.Create(ctx, &models.MissionStatusChange{
+				IncidentGuidanceID: mission.ID,
+				FromStatus:         mission.Status,
+				ToStatus:           models.MissionStatusAssigned,
+				ActorID:            &actor,
+				Reason:             reassignMissionDto.Reason,
+			}); err != nil {
+				return errors.NewDatabaseError("create mission status change", err)
+			}
+		}
+		history := &models.MissionAssignmentHistory{
+			IncidentGuidanceID: mission.ID,
+			Action:             models.AssignmentActionReassigned,
+			ActorID:            &actor,
+			FromUserID:         mission.AssigneeID,
+			ToUserID:           &assignee.ID,
+			Reason:             reassignMissionDto.Reason,
+		}
+		if err := s.assignmentHistoryRepo.Create(ctx, history); err != nil {
+			return errors.NewDatabaseError("create mission assignment history", err)
+		}
+		// Both the previous and the new assignee are told about the reassignment
+		return s.recordEvent(ctx, events.TypeMissionReassigned, mission, history, &assignee.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getMission(ctx, missionID)
+}
+
+// GetAssignmentHistory returns who a mission was assigned, reassigned and escalated to, oldest first.
+// Guards can only read the history of missions currently assigned to them.
+func (s *MissionService) GetAssignmentHistory(ctx context.Context, missionID string, userID string, role string) ([]models.MissionAssignmentHistory, error) {
+	mission, err := s.getMission(ctx, missionID)
+	if err != nil {
+		return nil, err
+	}
+	if role == models.RoleGuard && (mission.AssigneeID == nil || mission.AssigneeID.String() != userID) {
+		return nil, errors.NewForbiddenError("mission is not assigned to you")
+	}
+	histories, err := s.assignmentHistoryRepo.GetByIncidentGuidanceID(ctx, missionID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get mission assignment history", err)
+	}
+	return histories, nil
+}
+
+// EscalateMissions escalates every mission that was not accepted within the accept timeout, was
+// declined and not reassigned within the accept timeout, or has had no activity within the progress
+// timeout to a supervisor. A mission that fails to escalate is logged and retried on the next run.
+// It returns the number of missions escalated.
+func (s *MissionService) EscalateMissions(ctx context.Context) (int, error) {
+	now := time.Now()
+	missions, err := s.incidentGuidanceRepo.GetMissionsToEscalate(ctx, now.Add(-s.escalationCfg.AcceptTimeout), now.Add(-s.escalationCfg.ProgressTimeout))
+	if err != nil {
+		return 0, errors.NewDatabaseError("get missions to escalate", err)
+	}
+	escalated := 0
+	for i := range missions {
+		mission := &missions[i]
+		ok, err := s.escalateMission(ctx, mission, escalationReason(mission.Status, s.escalationCfg))
+		if err != nil {
+			s.logger.Errorf("Failed to escalate mission %s: %v", mission.ID, err)
+			continue
+		}
+		if ok {
+			escalated++
+		}
+	}
+	return escalated, nil
+}
+
+// escalationReason explains why a mission in the given status is escalated
+func escalationReason(status string, cfg config.EscalationConfig) string {
+	switch status {
+	case models.MissionStatusAssigned:
+		return fmt.Sprintf("not accepted within %s", cfg.AcceptTimeout)
+	case models.MissionStatusDeclined:
+		return fmt.Sprintf("declined and not reassigned within %s", cfg.AcceptTimeout)
+	default:
+		return fmt.Sprintf("no activity within %s", cfg.ProgressTimeout)
+	}
+}
+
+// escalateMission escalates a mission to its supervisor. It reports false when the mission moved
+// on since it was loaded and no longer needs escalating.
+func (s *MissionService) escalateMission(ctx context.Context, mission *models.IncidentGuidance, reason string) (bool, error) {
+	supervisorID := s.resolveSupervisor(mission)
+	escalated := false
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		ok, err := s.incidentGuidanceRepo.UpdateStatus(ctx, mission.ID.String(), []string{mission.Status}, map[string]interface{}{
+			"escalated_at":    time.Now(),
+			"escalated_to_id": supervisorID,
+		})
+		if err != nil {
+			return errors.NewDatabaseError("escalate mission", err)
+		}
+		if !ok {
+			return nil
+		}
+		history := &models.MissionAssignmentHistory{
+			IncidentGuidanceID: mission.ID,
+			Action:             models.AssignmentActionEscalated,
+			FromUserID:         mission.AssigneeID,
+			ToUserID:           supervisorID,
+			Reason:             reason,
+		}
+		if err := s.assignmentHistoryRepo.Create(ctx, history); err != nil {
+			return errors.NewDatabaseError("create mission assignment history", err)
+		}
+		// The supervisor is told about the escalation along with the assignee
+		if err := s.recordEvent(ctx, events.TypeMissionEscalated, mission, history, supervisorID); err != nil {
+			return err
+		}
+		escalated = true
+		return nil
+	})
+	return escalated, err
+}
+
+// resolveSupervisor returns who a mission is escalated to: the assignee's supervisor, then the
+// configured default supervisor, then the assigner
+func (s *MissionService) resolveSupervisor(mission *models.IncidentGuidance) *uuid.UUID {
+	if mission.Assignee != nil && mission.Assignee.SupervisorID != nil {
+		return mission.Assignee.SupervisorID
+	}
+	if supervisorID, err := uuid.Parse(s.escalationCfg.SupervisorID); err == nil {
+		return &supervisorID
+	}
+	return mission.AssignerID
+}
+
+// getGuard loads a user and checks that missions can be assigned to them
+func (s *MissionService) getGuard(ctx context.Context, userID string) (*models.User, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("assignee")
+		}
+		return nil, errors.NewDatabaseError("get assignee", err)
+	}
+	if user.Role != models.RoleGuard {
+		return nil, errors.NewBadRequestError("missions can only be assigned to guards")
+	}
+	return user, nil
+}
+
+func isReassignable(status string) bool {
+	for _, s := range reassignableStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	config "scs-guard/config"
+	"scs-guard/internal/models"
+	"testing"
+	"time"
+)
+
+func TestEscalationReason(t *testing.T) {
+	cfg := config.EscalationConfig{AcceptTimeout: 10 * time.Minute, ProgressTimeout: 30 * time.Minute}
+	tests := []struct {
+		status string
+		want   string
+	}{
+		{models.MissionStatusAssigned, "not accepted within 10m0s"},
+		{models.MissionStatusDeclined, "declined and not reassigned within 10m0s"},
+		{models.MissionStatusInProgress, "no activity within 30m0s"},
+		{models.MissionStatusPaused, "no activity within 30m0s"},
+	}
+	for _, tt := range tests {
+		if got := escalationReason(tt.status, cfg); got != tt.want {
+			t.Errorf("escalationReason(%s) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
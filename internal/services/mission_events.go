@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"scs-guard/internal/events"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// SubscribeEvents subscribes a user to the mission events they are allowed to see, replaying
+// retained events after lastEventID
+func (s *MissionService) SubscribeEvents(userID string, role string, lastEventID uint64) *events.Subscription {
+	return s.broker.Subscribe(userID, role, lastEventID)
+}
+
+// recordEvent stores an event about a mission in the outbox for its assignee and the extra guards
+// given. It must run inside the transaction making the change, so the event is stored if and only
+// if the change is committed.
+func (s *MissionService) recordEvent(ctx context.Context, eventType string, mission *models.IncidentGuidance, data interface{}, extraUserIDs ...*uuid.UUID) error {
+	outboxEvent, err := newOutboxEvent(events.Event{
+		Type:       eventType,
+		MissionID:  &mission.ID,
+		IncidentID: mission.IncidentID,
+		Data:       data,
+		UserIDs:    userIDs(append([]*uuid.UUID{mission.AssigneeID}, extraUserIDs...)...),
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.outboxEventRepo.Create(ctx, outboxEvent); err != nil {
+		return errors.NewDatabaseError("create outbox event", err)
+	}
+	return nil
+}
+
+func userIDs(ids ...*uuid.UUID) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != nil {
+			result = append(result, id.String())
+		}
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/dto"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/errors"
+)
+
+// checkEvidence rejects the completion of a step with a validation error listing every evidence
+// requirement of the step that is not met
+func (s *MissionService) checkEvidence(ctx context.Context, step *models.IncidentGuidanceStep, completeMissionDto dto.CompleteMissionDto) error {
+	requirements := step.Evidence
+	var photos, signatures int64
+	if requirements.MinPhotos > 0 || requirements.RequireSignature {
+		var err error
+		if photos, signatures, err = s.incidentMediaRepo.CountStepEvidence(ctx, step.ID.String()); err != nil {
+			return errors.NewDatabaseError("count step evidence", err)
+		}
+	}
+	if missing := missingEvidence(requirements, photos, signatures, completeMissionDto); len(missing) > 0 {
+		return errors.NewValidationError("the step is missing required evidence", missing)
+	}
+	return nil
+}
+
+// missingEvidence lists the evidence requirements that the uploaded photos and signatures and the
+// completion request do not meet
+func missingEvidence(requirements models.EvidenceRequirements, photos int64, signatures int64, completeMissionDto dto.CompleteMissionDto) []dto.MissingEvidenceDto {
+	missing := []dto.MissingEvidenceDto{}
+	if photos < int64(requirements.MinPhotos) {
+		missing = append(missing, dto.MissingEvidenceDto{
+			Requirement: dto.EvidencePhotos,
+			Message:     fmt.Sprintf("%d photos are required, %d uploaded", requirements.MinPhotos, photos),
+		})
+	}
+	if requirements.RequireNote && completeMissionDto.Note == "" {
+		missing = append(missing, dto.MissingEvidenceDto{Requirement: dto.EvidenceNote, Message: "a note is required"})
+	}
+	if requirements.RequireSignature && signatures == 0 {
+		missing = append(missing, dto.MissingEvidenceDto{Requirement: dto.EvidenceSignature, Message: "a signature is required"})
+	}
+	if unchecked := uncheckedItems(requirements.Checklist, completeMissionDto.CheckedItems); len(unchecked) > 0 {
+		missing = append(missing, dto.MissingEvidenceDto{
+			Requirement: dto.EvidenceChecklist,
+			Message:     fmt.Sprintf("%d checklist items are not checked", len(unchecked)),
+			Items:       unchecked,
+		})
+	}
+	if requirements.ReadingLabel != "" && completeMissionDto.Reading == nil {
+		missing = append(missing, dto.MissingEvidenceDto{
+			Requirement: dto.EvidenceReading,
+			Message:     fmt.Sprintf("a %s reading is required", requirements.ReadingLabel),
+		})
+	}
+	return missing
+}
+
+// uncheckedItems returns the checklist items that are not in checked
+func uncheckedItems(checklist []string, checked []string) []string {
+	isChecked := make(map[string]bool, len(checked))
+	for _, item := range checked {
+		isChecked[item] = true
+	}
+	var unchecked []string
+	for _, item := range checklist {
+		if !isChecked[item] {
+			unchecked = append(unchecked, item)
+		}
+	}
+	return unchecked
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"scs-guard/internal/dto"
+	"scs-guard/internal/models"
+	"testing"
+)
+
+func TestMissingEvidence(t *testing.T) {
+	reading := 21.5
+	requirements := models.EvidenceRequirements{
+		MinPhotos:        2,
+		RequireNote:      true,
+		RequireSignature: true,
+		Checklist:        models.StringList{"Panel checked", "Doors closed"},
+		ReadingLabel:     "Temperature",
+	}
+
+	t.Run("nothing required", func(t *testing.T) {
+		if got := missingEvidence(models.EvidenceRequirements{}, 0, 0, dto.CompleteMissionDto{}); len(got) != 0 {
+			t.Errorf("expected no missing evidence, got %v", got)
+		}
+	})
+
+	t.Run("everything missing", func(t *testing.T) {
+		got := missingEvidence(requirements, 1, 0, dto.CompleteMissionDto{CheckedItems: []string{"Panel checked"}})
+		expected := []string{dto.EvidencePhotos, dto.EvidenceNote, dto.EvidenceSignature, dto.EvidenceChecklist, dto.EvidenceReading}
+		if len(got) != len(expected) {
+			t.Fatalf("expected %d missing requirements, got %v", len(expected), got)
+		}
+		for i, requirement := range expected {
+			if got[i].Requirement != requirement {
+				t.Errorf("missing requirement %d = %s, want %s", i, got[i].Requirement, requirement)
+			}
+		}
+		if items := got[3].Items; len(items) != 1 || items[0] != "Doors closed" {
+			t.Errorf("expected only the unchecked item to be listed, got %v", items)
+		}
+	})
+
+	t.Run("everything given", func(t *testing.T) {
+		completeDto := dto.CompleteMissionDto{
+			Note:         "Panel shows a fault in zone 3",
+			CheckedItems: []string{"Doors closed", "Panel checked"},
+			Reading:      &reading,
+		}
+		if got := missingEvidence(requirements, 2, 1, completeDto); len(got) != 0 {
+			t.Errorf("expected no missing evidence, got %v", got)
+		}
+	})
+}
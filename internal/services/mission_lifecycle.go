@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/events"
+	"scs-guard/internal/models"
+	repositories "scs-guard/internal/repositories"
+	"scs-guard/pkg/errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// missionTransitions lists the statuses a mission may move to from each status.
+// Declined missions can only be reassigned; completed and aborted missions are final.
+var missionTransitions = map[string][]string{
+	models.MissionStatusAssigned:   {models.MissionStatusAccepted, models.MissionStatusDeclined, models.MissionStatusAborted},
+	models.MissionStatusAccepted:   {models.MissionStatusInProgress, models.MissionStatusAborted},
+	models.MissionStatusInProgress: {models.MissionStatusPaused, models.MissionStatusCompleted, models.MissionStatusAborted},
+	models.MissionStatusPaused:     {models.MissionStatusInProgress, models.MissionStatusAborted},
+}
+
+// canTransition reports whether a mission may move from one status to another
+func canTransition(from string, to string) bool {
+	for _, status := range missionTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptMission lets the assignee accept an assigned mission
+func (s *MissionService) AcceptMission(ctx context.Context, missionID string, userID string) (*models.IncidentGuidance, error) {
+	mission, err := s.getAssigneeMission(ctx, missionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.transitionMission(ctx, mission, models.MissionStatusAccepted, mission.AssigneeID, "", map[string]interface{}{
+			"accepted_at": time.Now(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getMission(ctx, missionID)
+}
+
+// DeclineMission lets the assignee decline an assigned mission. The reason is kept on the
+// mission so the assigner can see why it was declined.
+func (s *MissionService) DeclineMission(ctx context.Context, missionID string, userID string, reason string) (*models.IncidentGuidance, error) {
+	mission, err := s.getAssigneeMission(ctx, missionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.transitionMission(ctx, mission, models.MissionStatusDeclined, mission.AssigneeID, reason, map[string]interface{}{
+			"declined_at":    time.Now(),
+			"decline_reason": reason,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getMission(ctx, missionID)
+}
+
+// StartMission starts an accepted mission or resumes a paused one
+func (s *MissionService) StartMission(ctx context.Context, missionID string, userID string) (*models.IncidentGuidance, error) {
+	mission, err := s.getAssigneeMission(ctx, missionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canTransition(mission.Status, models.MissionStatusInProgress) {
+		return nil, invalidTransitionError(mission.Status, models.MissionStatusInProgress)
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.startMission(ctx, mission)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getMission(ctx, missionID)
+}
+
+// PauseMission pauses a mission in progress
+func (s *MissionService) PauseMission(ctx context.Context, missionID string, userID string) (*models.IncidentGuidance, error) {
+	mission, err := s.getAssigneeMission(ctx, missionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.transitionMission(ctx, mission, models.MissionStatusPaused, mission.AssigneeID, "", map[string]interface{}{
+			"paused_at": time.Now(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getMission(ctx, missionID)
+}
+
+// FinishMission completes a mission in progress once all of its steps are completed
+func (s *MissionService) FinishMission(ctx context.Context, missionID string, userID string) (*models.IncidentGuidance, error) {
+	mission, err := s.getAssigneeMission(ctx, missionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canTransition(mission.Status, models.MissionStatusCompleted) {
+		return nil, invalidTransitionError(mission.Status, models.MissionStatusCompleted)
+	}
+	remaining, err := s.incidentGuidanceStepRepo.CountIncompleteSteps(ctx, missionID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("count incomplete steps", err)
+	}
+	if remaining > 0 {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("mission has %d incomplete steps", remaining))
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.completeMission(ctx, mission)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getMission(ctx, missionID)
+}
+
+// AbortMission aborts a mission that has not finished yet. Any user allowed to assign missions
+// may abort one, including missions dispatched automatically, which have no assigner; the caller
+// is recorded as the actor.
+func (s *MissionService) AbortMission(ctx context.Context, missionID string, userID string, reason string) (*models.IncidentGuidance, error) {
+	mission, err := s.getMission(ctx, missionID)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := abortActor(mission, userID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.transitionMission(ctx, mission, models.MissionStatusAborted, actor, reason, map[string]interface{}{
+			"aborted_at":   time.Now(),
+			"abort_reason": reason,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getMission(ctx, missionID)
+}
+
+// abortActor checks that a mission can be aborted and returns the caller aborting it
+func abortActor(mission *models.IncidentGuidance, userID string) (*uuid.UUID, error) {
+	actor, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid user ID")
+	}
+	if !canTransition(mission.Status, models.MissionStatusAborted) {
+		return nil, invalidTransitionError(mission.Status, models.MissionStatusAborted)
+	}
+	return &actor, nil
+}
+
+// GetAssignedMissions returns the missions assigned by a user, including their status and decline reasons
+func (s *MissionService) GetAssignedMissions(ctx context.Context, userID string) ([]models.IncidentGuidance, error) {
+	missions, err := s.incidentGuidanceRepo.GetIncidentGuidanceByAssignerID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get assigned missions", err)
+	}
+	return missions, nil
+}
+
+func (s *MissionService) getMission(ctx context.Context, missionID string) (*models.IncidentGuidance, error) {
+	mission, err := s.incidentGuidanceRepo.GetIncidentGuidanceByID(ctx, missionID)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("mission")
+		}
+		return nil, errors.NewDatabaseError("get mission", err)
+	}
+	return mission, nil
+}
+
+// getAssigneeMission loads a mission and checks that it is assigned to userID
+func (s *MissionService) getAssigneeMission(ctx context.Context, missionID string, userID string) (*models.IncidentGuidance, error) {
+	mission, err := s.getMission(ctx, missionID)
+	if err != nil {
+		return nil, err
+	}
+	if mission.AssigneeID == nil || mission.AssigneeID.String() != userID {
+		return nil, errors.NewForbiddenError("mission is not assigned to you")
+	}
+	return mission, nil
+}
+
+// transitionMission moves a mission to the target status and records the change in its history and
+// the outbox, failing if the transition is not allowed or the mission was changed concurrently.
+// It must run inside a transaction.
+func (s *MissionService) transitionMission(ctx context.Context, mission *models.IncidentGuidance, target string, actor *uuid.UUID, reason string, updates map[string]interface{}) error {
+	if !canTransition(mission.Status, target) {
+		return invalidTransitionError(mission.Status, target)
+	}
+	updates["status"] = target
+	updates["last_activity_at"] = time.Now()
+	ok, err := s.incidentGuidanceRepo.UpdateStatus(ctx, mission.ID.String(), []string{mission.Status}, updates)
+	if err != nil {
+		return errors.NewDatabaseError("update mission status", err)
+	}
+	if !ok {
+		return errors.NewConflictError("mission status was changed by another request")
+	}
+	if err := s.statusChangeRepo.Create(ctx, &models.MissionStatusChange{
+		IncidentGuidanceID: mission.ID,
+		FromStatus:         mission.Status,
+		ToStatus:           target,
+		ActorID:            actor,
+		Reason:             reason,
+	}); err != nil {
+		return errors.NewDatabaseError("create mission status change", err)
+	}
+	change := events.StatusChange{From: mission.Status, To: target}
+	mission.Status = target
+	return s.recordEvent(ctx, events.TypeMissionStatusChanged, mission, change)
+}
+
+// startMission moves the mission to in progress and its incident out of new.
+// It must run inside a transaction.
+func (s *MissionService) startMission(ctx context.Context, mission *models.IncidentGuidance) error {
+	updates := map[string]interface{}{}
+	if mission.StartedAt == nil {
+		updates["started_at"] = time.Now()
+	}
+	if err := s.transitionMission(ctx, mission, models.MissionStatusInProgress, mission.AssigneeID, "", updates); err != nil {
+		return err
+	}
+	if mission.Incident != nil && mission.Incident.Status == models.IncidentStatusNew {
+		return s.incidents.transition(ctx, mission.Incident, mission, models.IncidentStatusInProgress, mission.AssigneeID, "", map[string]interface{}{})
+	}
+	return nil
+}
+
+// completeMission marks the mission completed and resolves its incident unless it was already
+// resolved. It must run inside a transaction.
+func (s *MissionService) completeMission(ctx context.Context, mission *models.IncidentGuidance) error {
+	if err := s.transitionMission(ctx, mission, models.MissionStatusCompleted, mission.AssigneeID, "", map[string]interface{}{
+		"completed_at": time.Now(),
+	}); err != nil {
+		return err
+	}
+	if mission.IncidentID == nil {
+		return nil
+	}
+	incident := mission.Incident
+	if incident == nil {
+		var err error
+		if incident, err = s.incidentRepo.GetIncidentByID(ctx, mission.IncidentID.String()); err != nil {
+			return errors.NewDatabaseError("get incident", err)
+		}
+	}
+	if !canTransitionIncident(incident.Status, models.IncidentStatusResolved) {
+		return nil
+	}
+	return s.incidents.transition(ctx, incident, mission, models.IncidentStatusResolved, mission.AssigneeID, "", map[string]interface{}{
+		"resolved_at":    time.Now(),
+		"resolved_by_id": mission.AssigneeID,
+	})
+}
+
+func invalidTransitionError(from string, to string) error {
+	return errors.NewBadRequestError(fmt.Sprintf("mission cannot move from %s to %s", from, to))
+}
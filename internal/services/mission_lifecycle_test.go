@@ -0,0 +1,59 @@
+package services
+
+import (
+	"scs-guard/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected bool
+	}{
+		{"accept assigned", models.MissionStatusAssigned, models.MissionStatusAccepted, true},
+		{"decline assigned", models.MissionStatusAssigned, models.MissionStatusDeclined, true},
+		{"start accepted", models.MissionStatusAccepted, models.MissionStatusInProgress, true},
+		{"resume paused", models.MissionStatusPaused, models.MissionStatusInProgress, true},
+		{"pause in progress", models.MissionStatusInProgress, models.MissionStatusPaused, true},
+		{"complete in progress", models.MissionStatusInProgress, models.MissionStatusCompleted, true},
+		{"abort paused", models.MissionStatusPaused, models.MissionStatusAborted, true},
+		{"start assigned", models.MissionStatusAssigned, models.MissionStatusInProgress, false},
+		{"decline accepted", models.MissionStatusAccepted, models.MissionStatusDeclined, false},
+		{"complete paused", models.MissionStatusPaused, models.MissionStatusCompleted, false},
+		{"reopen completed", models.MissionStatusCompleted, models.MissionStatusInProgress, false},
+		{"abort declined", models.MissionStatusDeclined, models.MissionStatusAborted, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canTransition(tt.from, tt.to); got != tt.expected {
+				t.Errorf("canTransition(%s, %s) = %v, expected %v", tt.from, tt.to, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAbortActor(t *testing.T) {
+	operatorID := uuid.New()
+	assigneeID := uuid.New()
+	// Dispatched missions have no assigner
+	dispatched := &models.IncidentGuidance{Status: models.MissionStatusAssigned, AssigneeID: &assigneeID}
+
+	actor, err := abortActor(dispatched, operatorID.String())
+	if err != nil {
+		t.Fatalf("abortActor() of a dispatched mission error = %v", err)
+	}
+	if *actor != operatorID {
+		t.Errorf("abortActor() = %s, want the operator %s", actor, operatorID)
+	}
+	if _, err := abortActor(&models.IncidentGuidance{Status: models.MissionStatusCompleted}, operatorID.String()); err == nil {
+		t.Error("abortActor() of a completed mission error = nil, want an invalid transition")
+	}
+	if _, err := abortActor(dispatched, "not-a-uuid"); err == nil {
+		t.Error("abortActor() with an invalid user ID error = nil, want unauthorized")
+	}
+}
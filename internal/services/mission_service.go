@@ -2,12 +2,21 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"mime/multipart"
+	config "scs-guard/config"
 	"scs-guard/internal/dto"
+	"scs-guard/internal/events"
 	"scs-guard/internal/models"
 	repositories "scs-guard/internal/repositories"
 	"scs-guard/pkg/errors"
-	minio_client "scs-guard/pkg/minio"
+	"scs-guard/pkg/logger"
+	"scs-guard/pkg/storage"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type MissionService struct {
@@ -15,18 +24,156 @@ type MissionService struct {
 	incidentGuidanceStepRepo repositories.IncidentGuidanceStepRepository
 	incidentRepo             repositories.IncidentRepository
 	incidentMediaRepo        repositories.IncidentMediaRepository
-	minioClient              minio_client.MinioClient
+	mediaUploadRepo          repositories.MediaUploadRepository
+	custodyRepo              repositories.MediaCustodyRepository
+	guidanceTemplateRepo     repositories.GuidanceTemplateRepository
+	userRepo                 repositories.UserRepository
+	assignmentHistoryRepo    repositories.MissionAssignmentHistoryRepository
+	statusChangeRepo         repositories.MissionStatusChangeRepository
+	stepHistoryRepo          repositories.StepHistoryRepository
+	overdueEventRepo         repositories.OverdueEventRepository
+	outboxEventRepo          repositories.OutboxEventRepository
+	incidents                *IncidentService
+	comments                 *CommentService
+	storage                  storage.Storage
+	mediaCfg                 config.MediaConfig
+	txManager                repositories.TransactionManager
+	escalationCfg            config.EscalationConfig
+	slaCfg                   config.SLAConfig
+	stepsCfg                 config.StepsConfig
+	broker                   events.Broker
+	logger                   logger.Logger
 }
 
-func NewMissionService(incidentGuidanceRepo repositories.IncidentGuidanceRepository, incidentGuidanceStepRepo repositories.IncidentGuidanceStepRepository, incidentRepo repositories.IncidentRepository, incidentMediaRepo repositories.IncidentMediaRepository, minioClient minio_client.MinioClient) *MissionService {
-	// TODO: Pass minioClient as a parameter or initialize here as needed
+func NewMissionService(incidentGuidanceRepo repositories.IncidentGuidanceRepository, incidentGuidanceStepRepo repositories.IncidentGuidanceStepRepository, incidentRepo repositories.IncidentRepository, incidentMediaRepo repositories.IncidentMediaRepository, mediaUploadRepo repositories.MediaUploadRepository, custodyRepo repositories.MediaCustodyRepository, guidanceTemplateRepo repositories.GuidanceTemplateRepository, userRepo repositories.UserRepository, assignmentHistoryRepo repositories.MissionAssignmentHistoryRepository, statusChangeRepo repositories.MissionStatusChangeRepository, stepHistoryRepo repositories.StepHistoryRepository, overdueEventRepo repositories.OverdueEventRepository, outboxEventRepo repositories.OutboxEventRepository, incidents *IncidentService, comments *CommentService, store storage.Storage, mediaCfg config.MediaConfig, txManager repositories.TransactionManager, escalationCfg config.EscalationConfig, slaCfg config.SLAConfig, stepsCfg config.StepsConfig, broker events.Broker, logger logger.Logger) *MissionService {
 	return &MissionService{
 		incidentGuidanceRepo:     incidentGuidanceRepo,
 		incidentGuidanceStepRepo: incidentGuidanceStepRepo,
 		incidentRepo:             incidentRepo,
 		incidentMediaRepo:        incidentMediaRepo,
-		minioClient:              minioClient,
+		mediaUploadRepo:          mediaUploadRepo,
+		custodyRepo:              custodyRepo,
+		guidanceTemplateRepo:     guidanceTemplateRepo,
+		userRepo:                 userRepo,
+		assignmentHistoryRepo:    assignmentHistoryRepo,
+		statusChangeRepo:         statusChangeRepo,
+		stepHistoryRepo:          stepHistoryRepo,
+		overdueEventRepo:         overdueEventRepo,
+		outboxEventRepo:          outboxEventRepo,
+		incidents:                incidents,
+		comments:                 comments,
+		storage:                  store,
+		mediaCfg:                 mediaCfg,
+		txManager:                txManager,
+		escalationCfg:            escalationCfg,
+		slaCfg:                   slaCfg,
+		stepsCfg:                 stepsCfg,
+		broker:                   broker,
+		logger:                   logger,
+	}
+}
+
+// AssignMission instantiates a guidance template for an incident and assigns it to a guard.
+// The mission and a copy of every template step are created in one transaction.
+func (s *MissionService) AssignMission(ctx context.Context, assignerID string, assignMissionDto dto.AssignMissionDto) (*models.IncidentGuidance, error) {
+	incident, err := s.incidentRepo.GetIncidentByID(ctx, assignMissionDto.IncidentID)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("incident")
+		}
+		return nil, errors.NewDatabaseError("get incident", err)
+	}
+	template, err := s.guidanceTemplateRepo.GetGuidanceTemplateByID(ctx, assignMissionDto.GuidanceTemplateID)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("guidance template")
+		}
+		return nil, errors.NewDatabaseError("get guidance template", err)
+	}
+	if template.Status != models.TemplateStatusPublished {
+		return nil, errors.NewBadRequestError("only the current version of a guidance template can be assigned, template is " + template.Status)
+	}
+	assignee, err := s.getGuard(ctx, assignMissionDto.AssigneeID)
+	if err != nil {
+		return nil, err
+	}
+	assigner, err := uuid.Parse(assignerID)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid user id")
+	}
+	mission, err := s.createMission(ctx, incident, template, assignee, &assigner)
+	if err != nil {
+		return nil, err
+	}
+	return s.getMission(ctx, mission.ID.String())
+}
+
+// createMission instantiates a template for an incident and assigns it to a guard. A nil assigner
+// means the mission was dispatched automatically.
+func (s *MissionService) createMission(ctx context.Context, incident *models.Incident, template *models.GuidanceTemplate, assignee *models.User, assigner *uuid.UUID) (*models.IncidentGuidance, error) {
+	now := time.Now()
+	mission := &models.IncidentGuidance{
+		IncidentID:         &incident.ID,
+		GuidanceTemplateID: &template.ID,
+		AssignerID:         assigner,
+		AssigneeID:         &assignee.ID,
+		Status:             models.MissionStatusAssigned,
+		AssignedAt:         &now,
+		LastActivityAt:     &now,
+		StrictOrder:        template.StrictOrder,
+	}
+	steps := make([]models.IncidentGuidanceStep, 0, len(template.GuidanceSteps))
+	for _, step := range template.GuidanceSteps {
+		steps = append(steps, models.IncidentGuidanceStep{
+			StepNumber:  int64(step.StepNumber),
+			Title:       step.Title,
+			Description: step.Description,
+			Optional:    step.Optional,
+			Evidence:    step.Evidence,
+			Branching:   step.Branching,
+			State:       initialStepState(step.Branching),
+		})
+	}
+	s.applyDeadlines(mission, steps, template.GuidanceSteps, incident.Severity, now)
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// An incident is handled by one mission at a time
+		unfinished, err := s.incidentGuidanceRepo.HasUnfinishedMission(ctx, incident.ID.String())
+		if err != nil {
+			return errors.NewDatabaseError("check the missions of the incident", err)
+		}
+		if unfinished {
+			return errors.NewConflictError("the incident already has an unfinished mission")
+		}
+		if _, err := s.incidentGuidanceRepo.CreateIncidentGuidance(ctx, mission); err != nil {
+			if repositories.IsDuplicateKey(err) {
+				return errors.NewConflictError("a mission for this incident and guidance template already exists")
+			}
+			return errors.NewDatabaseError("create mission", err)
+		}
+		if err := s.assignmentHistoryRepo.Create(ctx, &models.MissionAssignmentHistory{
+			IncidentGuidanceID: mission.ID,
+			Action:             models.AssignmentActionAssigned,
+			ActorID:            assigner,
+			ToUserID:           &assignee.ID,
+		}); err != nil {
+			return errors.NewDatabaseError("create mission assignment history", err)
+		}
+		if len(steps) > 0 {
+			for i := range steps {
+				steps[i].IncidentGuidanceID = mission.ID
+			}
+			if _, err := s.incidentGuidanceStepRepo.CreateIncidentGuidanceSteps(ctx, steps); err != nil {
+				return errors.NewDatabaseError("create mission steps", err)
+			}
+		}
+		mission.IncidentGuidanceSteps = steps
+		return s.recordEvent(ctx, events.TypeMissionAssigned, mission, mission)
+	})
+	if err != nil {
+		return nil, err
 	}
+	return mission, nil
 }
 
 func (s *MissionService) GetAssignments(ctx context.Context, userID string) ([]models.IncidentGuidance, error) {
@@ -37,59 +184,143 @@ func (s *MissionService) GetAssignments(ctx context.Context, userID string) ([]m
 	return assignments, nil
 }
 
-func (s *MissionService) CompleteStep(ctx context.Context, completeMissionDto dto.CompleteMissionDto) error {
-	// TODO: Check if the step belongs to the assignment
-	stepInfo, err := s.incidentGuidanceStepRepo.GetIncidentGuidanceStepByID(ctx, completeMissionDto.StepID)
+func (s *MissionService) CompleteStep(ctx context.Context, userID string, completeMissionDto dto.CompleteMissionDto) error {
+	mission, err := s.getAssigneeMission(ctx, completeMissionDto.MissionID, userID)
 	if err != nil {
-		return errors.NewDatabaseError("get step", err)
+		return err
 	}
-	if stepInfo.IncidentGuidanceID.String() != completeMissionDto.MissionID {
-		return errors.NewBadRequestError("step does not belong to the mission")
+	stepInfo, err := s.getWorkableStep(mission, completeMissionDto.StepID)
+	if err != nil {
+		return err
+	}
+	if err := checkAnswer(stepInfo, completeMissionDto.Answer); err != nil {
+		return err
 	}
-	if stepInfo.IsCompleted {
-		return errors.NewBadRequestError("step already completed")
+	if err := s.checkEvidence(ctx, stepInfo, completeMissionDto); err != nil {
+		return err
 	}
-	s.incidentGuidanceStepRepo.UpdateIncidentGuidanceStep(ctx, completeMissionDto.StepID, true)
-	return nil
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		updates := map[string]interface{}{
+			"completed_at":    now,
+			"completed_by_id": mission.AssigneeID,
+			"reading":         completeMissionDto.Reading,
+			"answer":          completeMissionDto.Answer,
+		}
+		if len(stepInfo.Evidence.Checklist) > 0 {
+			updates["checked_items"] = stepInfo.Evidence.Checklist
+		}
+		ok, err := s.incidentGuidanceStepRepo.CompleteIncidentGuidanceStep(ctx, completeMissionDto.StepID, updates)
+		if err != nil {
+			return errors.NewDatabaseError("complete step", err)
+		}
+		if !ok {
+			return errors.NewConflictError("step was completed by another request")
+		}
+		stepInfo.IsCompleted = true
+		stepInfo.CompletedAt = &now
+		stepInfo.CompletedByID = mission.AssigneeID
+		stepInfo.Reading = completeMissionDto.Reading
+		stepInfo.Answer = completeMissionDto.Answer
+		if len(stepInfo.Evidence.Checklist) > 0 {
+			stepInfo.CheckedItems = stepInfo.Evidence.Checklist
+		}
+		if err := s.recordStepHistory(ctx, mission, stepInfo, models.StepActionCompleted, ""); err != nil {
+			return err
+		}
+		if err := s.recordEvent(ctx, events.TypeStepCompleted, mission, stepInfo); err != nil {
+			return err
+		}
+		if err := s.applyBranching(ctx, mission, stepInfo, completeMissionDto.Answer); err != nil {
+			return err
+		}
+		if completeMissionDto.Note != "" && mission.IncidentID != nil {
+			if err := s.comments.addComment(ctx, &models.Comment{
+				IncidentID:             *mission.IncidentID,
+				TargetType:             models.CommentTargetStep,
+				IncidentGuidanceID:     &mission.ID,
+				IncidentGuidanceStepID: &stepInfo.ID,
+				AuthorID:               *mission.AssigneeID,
+				Body:                   completeMissionDto.Note,
+			}, mission); err != nil {
+				return err
+			}
+		}
+		return s.afterStepProgress(ctx, mission, now)
+	})
 }
 
-func (s *MissionService) UpdateIncidentInfo(ctx context.Context, incidentID string, validFiles []map[string]interface{}) error {
-	incident, err := s.incidentRepo.GetIncidentByID(ctx, incidentID)
+// UpdateIncidentInfo uploads media documenting an incident. When stepID is set the media are
+// evidence for that step of the incident mission, and signature marks them as its signature.
+func (s *MissionService) UpdateIncidentInfo(ctx context.Context, userID string, incidentID string, stepID string, signature bool, validFiles []map[string]interface{}) error {
+	incident, guidance, step, err := s.getMediaTarget(ctx, userID, incidentID, stepID)
 	if err != nil {
-		return errors.NewBadRequestError("incident not found")
+		return err
 	}
-	// Upload files to minio
-	var incidentMedias []models.IncidentMedia
 	for _, validFile := range validFiles {
-		objectName := validFile["file_name"].(string)
+		mimeType := validFile["mime_type"].(string)
+		if err := checkMediaType(mimeType, step, signature); err != nil {
+			return err
+		}
+		if maxSize := s.maxMediaSize(getFileType(mimeType)); validFile["file_size"].(int64) > maxSize {
+			return errors.NewBadRequestError(fmt.Sprintf("file size exceeds %d bytes", maxSize))
+		}
+	}
+	// Store every file under a key of its own, so files with the same name do not overwrite each
+	// other, hashing them on the way
+	incidentMedias := make([]models.IncidentMedia, len(validFiles))
+	files := make([]mediaFile, len(validFiles))
+	for i, validFile := range validFiles {
+		fileName := validFile["file_name"].(string)
 		file := validFile["file"].(multipart.File)
-		fileSize := validFile["file_size"].(int64)
 		fileType := validFile["mime_type"].(string)
-		fileInfo, err := s.minioClient.UploadFile(objectName, file, fileSize, fileType)
-		if err != nil {
-			return err
+		id := uuid.New()
+		media := &incidentMedias[i]
+		*media = models.IncidentMedia{
+			Base:             models.Base{ID: id},
+			IncidentID:       incident.ID,
+			FileName:         fileName,
+			ObjectKey:        mediaObjectKey(incident.ID, id, fileName),
+			MediaType:        getFileType(fileType),
+			FileType:         fileType,
+			UploadedByID:     guidance.AssigneeID,
+			Signature:        signature,
+			ProcessingStatus: models.MediaProcessingPending,
 		}
-		incidentMedias = append(incidentMedias, models.IncidentMedia{
-			IncidentID: incident.ID,
-			FileName:   objectName,
-			FileSize:   fileInfo.Size,
-			FileUrl:    "http://" + s.minioClient.Endpoint + "/" + s.minioClient.BucketName + "/" + fileInfo.Key,
-			MediaType:  getFileType(fileType),
-			FileType:   fileType,
-		})
+		if step != nil {
+			media.IncidentGuidanceStepID = &step.ID
+		}
+		if media.MediaType == "image" {
+			applyPhotoMetadata(media, readPhotoMetadata(file), incident, s.mediaCfg)
+			if _, err := file.Seek(0, io.SeekStart); err != nil {
+				return errors.NewInternalError("failed to read the uploaded file", err)
+			}
+		}
+		files[i] = mediaFile{data: file, size: validFile["file_size"].(int64), media: media}
 	}
-	// Create incident media
-	if err := s.incidentMediaRepo.BatchCreate(ctx, incidentMedias); err != nil {
-		return errors.NewDatabaseError("create incident media", err)
+	if err := s.storeMediaFiles(ctx, files); err != nil {
+		return err
 	}
-
-	return nil
+	// Create incident media, removing the stored files again when they cannot be recorded
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.incidentMediaRepo.BatchCreate(ctx, incidentMedias); err != nil {
+			return errors.NewDatabaseError("create incident media", err)
+		}
+		if err := s.recordUploadCustody(ctx, incidentMedias); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, events.TypeMediaUploaded, guidance, incidentMedias)
+	})
+	if err != nil {
+		s.discardMediaFiles(ctx, files)
+	}
+	return err
 }
 func getFileType(contentType string) string {
-	if contentType == "image/jpeg" || contentType == "image/png" {
+	if strings.HasPrefix(contentType, "image/") {
 		return "image"
 	}
-	if contentType == "video/mp4" {
+	if strings.HasPrefix(contentType, "video/") {
 		return "video"
 	}
 	return "other"
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/errors"
+	"time"
+)
+
+// deadlineFactor returns the multiplier applied to template step durations for an incident severity
+func (s *MissionService) deadlineFactor(severity string) float64 {
+	switch severity {
+	case "high":
+		return s.slaCfg.HighSeverityFactor
+	case "low":
+		return s.slaCfg.LowSeverityFactor
+	default:
+		return s.slaCfg.MediumSeverityFactor
+	}
+}
+
+// applyDeadlines sets the due time of every mission step from the duration of its template step,
+// scaled by the incident severity. Steps are due one after the other starting at start, and the
+// mission is due when its last step is. Steps without a duration get no deadline.
+func (s *MissionService) applyDeadlines(mission *models.IncidentGuidance, steps []models.IncidentGuidanceStep, templateSteps []models.GuidanceStep, severity string, start time.Time) {
+	factor := s.deadlineFactor(severity)
+	var elapsed time.Duration
+	for i, templateStep := range templateSteps {
+		if templateStep.DurationMinutes <= 0 {
+			continue
+		}
+		elapsed += time.Duration(float64(templateStep.DurationMinutes) * factor * float64(time.Minute))
+		dueAt := start.Add(elapsed)
+		steps[i].DueAt = &dueAt
+	}
+	if elapsed > 0 {
+		dueAt := start.Add(elapsed)
+		mission.DueAt = &dueAt
+	}
+}
+
+// DetectOverdue flags the steps and missions whose deadline has passed and records an overdue
+// event for each of them. It returns the number of steps and missions flagged.
+func (s *MissionService) DetectOverdue(ctx context.Context) (int, error) {
+	now := time.Now()
+	flagged := 0
+
+	steps, err := s.incidentGuidanceStepRepo.GetNewlyOverdueSteps(ctx, now)
+	if err != nil {
+		return flagged, errors.NewDatabaseError("get overdue steps", err)
+	}
+	for _, step := range steps {
+		stepID := step.ID
+		event := &models.OverdueEvent{
+			Kind:                   models.OverdueKindStep,
+			IncidentGuidanceID:     step.IncidentGuidanceID,
+			IncidentGuidanceStepID: &stepID,
+			DueAt:                  *step.DueAt,
+		}
+		if step.IncidentGuidance != nil {
+			event.IncidentID = step.IncidentGuidance.IncidentID
+			event.AssigneeID = step.IncidentGuidance.AssigneeID
+		}
+		ok, err := s.flagOverdue(ctx, event, func(ctx context.Context) (bool, error) {
+			return s.incidentGuidanceStepRepo.MarkOverdue(ctx, step.ID.String(), now)
+		})
+		if err != nil {
+			return flagged, errors.NewDatabaseError("flag overdue step", err)
+		}
+		if ok {
+			flagged++
+		}
+	}
+
+	missions, err := s.incidentGuidanceRepo.GetNewlyOverdueMissions(ctx, now)
+	if err != nil {
+		return flagged, errors.NewDatabaseError("get overdue missions", err)
+	}
+	for _, mission := range missions {
+		event := &models.OverdueEvent{
+			Kind:               models.OverdueKindMission,
+			IncidentID:         mission.IncidentID,
+			IncidentGuidanceID: mission.ID,
+			AssigneeID:         mission.AssigneeID,
+			DueAt:              *mission.DueAt,
+		}
+		ok, err := s.flagOverdue(ctx, event, func(ctx context.Context) (bool, error) {
+			return s.incidentGuidanceRepo.MarkOverdue(ctx, mission.ID.String(), now)
+		})
+		if err != nil {
+			return flagged, errors.NewDatabaseError("flag overdue mission", err)
+		}
+		if ok {
+			flagged++
+		}
+	}
+	return flagged, nil
+}
+
+// flagOverdue marks a step or mission overdue and records its overdue event in one transaction.
+// It returns false without recording anything when another worker flagged it first.
+func (s *MissionService) flagOverdue(ctx context.Context, event *models.OverdueEvent, markOverdue func(ctx context.Context) (bool, error)) (bool, error) {
+	flagged := false
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		ok, err := markOverdue(ctx)
+		if err != nil || !ok {
+			return err
+		}
+		flagged = true
+		return s.overdueEventRepo.Create(ctx, event)
+	})
+	return flagged && err == nil, err
+}
+
+// GetOverdueMissions returns the unfinished missions that are overdue or have an overdue step
+func (s *MissionService) GetOverdueMissions(ctx context.Context) ([]models.IncidentGuidance, error) {
+	missions, err := s.incidentGuidanceRepo.GetOverdueMissions(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get overdue missions", err)
+	}
+	return missions, nil
+}
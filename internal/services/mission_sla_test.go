@@ -0,0 +1,59 @@
+package services
+
+import (
+	"scs-guard/config"
+	"scs-guard/internal/models"
+	"testing"
+	"time"
+)
+
+func TestApplyDeadlines(t *testing.T) {
+	s := &MissionService{slaCfg: config.SLAConfig{HighSeverityFactor: 0.5, MediumSeverityFactor: 1, LowSeverityFactor: 2}}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	templateSteps := []models.GuidanceStep{{DurationMinutes: 10}, {DurationMinutes: 0}, {DurationMinutes: 20}}
+
+	tests := []struct {
+		name       string
+		severity   string
+		stepDue    []time.Duration
+		missionDue time.Duration
+	}{
+		{"medium severity", "medium", []time.Duration{10 * time.Minute, 0, 30 * time.Minute}, 30 * time.Minute},
+		{"high severity", "high", []time.Duration{5 * time.Minute, 0, 15 * time.Minute}, 15 * time.Minute},
+		{"low severity", "low", []time.Duration{20 * time.Minute, 0, 60 * time.Minute}, 60 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mission := &models.IncidentGuidance{}
+			steps := make([]models.IncidentGuidanceStep, len(templateSteps))
+			s.applyDeadlines(mission, steps, templateSteps, tt.severity, start)
+
+			for i, due := range tt.stepDue {
+				if due == 0 {
+					if steps[i].DueAt != nil {
+						t.Errorf("step %d: expected no deadline, got %v", i, steps[i].DueAt)
+					}
+					continue
+				}
+				if steps[i].DueAt == nil || !steps[i].DueAt.Equal(start.Add(due)) {
+					t.Errorf("step %d: expected deadline %v, got %v", i, start.Add(due), steps[i].DueAt)
+				}
+			}
+			if mission.DueAt == nil || !mission.DueAt.Equal(start.Add(tt.missionDue)) {
+				t.Errorf("expected mission deadline %v, got %v", start.Add(tt.missionDue), mission.DueAt)
+			}
+		})
+	}
+}
+
+func TestApplyDeadlinesWithoutDurations(t *testing.T) {
+	s := &MissionService{slaCfg: config.SLAConfig{MediumSeverityFactor: 1}}
+	mission := &models.IncidentGuidance{}
+	steps := make([]models.IncidentGuidanceStep, 2)
+	s.applyDeadlines(mission, steps, []models.GuidanceStep{{}, {}}, "medium", time.Now())
+
+	if mission.DueAt != nil {
+		t.Errorf("expected no mission deadline, got %v", mission.DueAt)
+	}
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/events"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SkipStep skips an optional step of a mission assigned to userID. The reason is kept on the step
+// and in its history.
+func (s *MissionService) SkipStep(ctx context.Context, missionID string, stepID string, userID string, reason string) (*models.IncidentGuidance, error) {
+	mission, err := s.getAssigneeMission(ctx, missionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	step, err := s.getWorkableStep(mission, stepID)
+	if err != nil {
+		return nil, err
+	}
+	if !step.Optional {
+		return nil, errors.NewBadRequestError("only optional steps can be skipped")
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		ok, err := s.incidentGuidanceStepRepo.SkipIncidentGuidanceStep(ctx, stepID, map[string]interface{}{
+			"skipped_at":    now,
+			"skipped_by_id": mission.AssigneeID,
+			"skip_reason":   reason,
+		})
+		if err != nil {
+			return errors.NewDatabaseError("skip step", err)
+		}
+		if !ok {
+			return errors.NewConflictError("step was completed or skipped by another request")
+		}
+		step.State = models.StepStateSkipped
+		step.SkippedAt = &now
+		step.SkippedByID = mission.AssigneeID
+		step.SkipReason = reason
+		if err := s.recordStepHistory(ctx, mission, step, models.StepActionSkipped, reason); err != nil {
+			return err
+		}
+		if err := s.recordEvent(ctx, events.TypeStepSkipped, mission, step); err != nil {
+			return err
+		}
+		// Skipping a question step leaves it unanswered, so the steps depending on it are skipped too
+		if err := s.applyBranching(ctx, mission, step, ""); err != nil {
+			return err
+		}
+		return s.afterStepProgress(ctx, mission, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getMission(ctx, missionID)
+}
+
+// UndoStepCompletion reverts the completion of a step of a mission assigned to userID within the
+// grace period after it was completed. The completion stays in the step history. Steps activated
+// or skipped by the answer to the step become pending again.
+func (s *MissionService) UndoStepCompletion(ctx context.Context, missionID string, stepID string, userID string, reason string) (*models.IncidentGuidance, error) {
+	if s.stepsCfg.UndoGracePeriod <= 0 {
+		return nil, errors.NewBadRequestError("undoing step completions is disabled")
+	}
+	mission, err := s.getAssigneeMission(ctx, missionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mission.Status != models.MissionStatusAccepted && mission.Status != models.MissionStatusInProgress {
+		return nil, errors.NewBadRequestError("step completions can only be undone on an accepted or in progress mission, mission is " + mission.Status)
+	}
+	step := findStep(mission, stepID)
+	if step == nil {
+		return nil, errors.NewNotFoundError("mission step")
+	}
+	if !step.IsCompleted {
+		return nil, errors.NewBadRequestError("step is not completed")
+	}
+	since := time.Now().Add(-s.stepsCfg.UndoGracePeriod)
+	if step.CompletedAt == nil || step.CompletedAt.Before(since) {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("step completions can only be undone within %s", s.stepsCfg.UndoGracePeriod))
+	}
+	if mission.StrictOrder {
+		if later := laterDoneStep(mission.IncidentGuidanceSteps, step); later != nil {
+			return nil, errors.NewBadRequestError(fmt.Sprintf("steps are done in order, undo step %d first", later.StepNumber))
+		}
+	}
+	dependents, err := branchDependents(mission.IncidentGuidanceSteps, step)
+	if err != nil {
+		return nil, err
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		ok, err := s.incidentGuidanceStepRepo.UndoCompletion(ctx, stepID, since)
+		if err != nil {
+			return errors.NewDatabaseError("undo step completion", err)
+		}
+		if !ok {
+			return errors.NewConflictError("step completion was changed by another request")
+		}
+		if err := s.incidentGuidanceStepRepo.UpdateStates(ctx, dependents, models.StepStatePending); err != nil {
+			return errors.NewDatabaseError("reset step states", err)
+		}
+		answer := step.Answer
+		step.IsCompleted = false
+		step.CompletedAt = nil
+		step.CompletedByID = nil
+		step.Answer = ""
+		step.Reading = nil
+		step.CheckedItems = nil
+		if err := s.recordStepHistory(ctx, mission, step, models.StepActionCompletionUndone, reason); err != nil {
+			return err
+		}
+		if err := s.recordEvent(ctx, events.TypeStepCompletionUndone, mission, map[string]interface{}{
+			"step":            step,
+			"previous_answer": answer,
+			"reason":          reason,
+		}); err != nil {
+			return err
+		}
+		if err := s.incidentGuidanceRepo.UpdateIncidentGuidance(ctx, missionID, map[string]interface{}{
+			"last_activity_at": time.Now(),
+		}); err != nil {
+			return errors.NewDatabaseError("update mission activity", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getMission(ctx, missionID)
+}
+
+// GetStepHistory returns the completions, skips and undone completions of the steps of a
+// mission, oldest first. Guards can only see the history of their own missions.
+func (s *MissionService) GetStepHistory(ctx context.Context, missionID string, userID string, role string) ([]models.StepHistory, error) {
+	mission, err := s.getMission(ctx, missionID)
+	if err != nil {
+		return nil, err
+	}
+	if role == models.RoleGuard && (mission.AssigneeID == nil || mission.AssigneeID.String() != userID) {
+		return nil, errors.NewForbiddenError("mission is not assigned to you")
+	}
+	histories, err := s.stepHistoryRepo.GetByIncidentGuidanceID(ctx, missionID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get step history", err)
+	}
+	return histories, nil
+}
+
+// getWorkableStep returns a step of the mission that can be completed or skipped now: the mission
+// is under way, the step is active and not completed, and with strict ordering every earlier step
+// is done
+func (s *MissionService) getWorkableStep(mission *models.IncidentGuidance, stepID string) (*models.IncidentGuidanceStep, error) {
+	if mission.Status != models.MissionStatusAccepted && mission.Status != models.MissionStatusInProgress {
+		return nil, errors.NewBadRequestError("steps can only be completed or skipped on an accepted or in progress mission, mission is " + mission.Status)
+	}
+	step := findStep(mission, stepID)
+	if step == nil {
+		return nil, errors.NewBadRequestError("step does not belong to the mission")
+	}
+	if step.IsCompleted {
+		return nil, errors.NewBadRequestError("step already completed")
+	}
+	switch {
+	case step.State == models.StepStatePending:
+		return nil, errors.NewBadRequestError("step depends on a question that has not been answered yet")
+	case step.State == models.StepStateSkipped:
+		return nil, errors.NewBadRequestError("step was skipped")
+	}
+	if mission.StrictOrder {
+		if blocking := earlierOpenStep(mission.IncidentGuidanceSteps, step); blocking != nil {
+			return nil, errors.NewBadRequestError(fmt.Sprintf("steps must be done in order, complete or skip step %d first", blocking.StepNumber))
+		}
+	}
+	return step, nil
+}
+
+// afterStepProgress records activity on a mission after one of its steps was completed or
+// skipped, starting it when it was only accepted and completing it when no step is left. It must
+// run inside a transaction.
+func (s *MissionService) afterStepProgress(ctx context.Context, mission *models.IncidentGuidance, now time.Time) error {
+	missionID := mission.ID.String()
+	if err := s.incidentGuidanceRepo.UpdateIncidentGuidance(ctx, missionID, map[string]interface{}{
+		"last_activity_at": now,
+	}); err != nil {
+		return errors.NewDatabaseError("update mission activity", err)
+	}
+	// Completing or skipping a step on an accepted mission starts it
+	if mission.Status == models.MissionStatusAccepted {
+		if err := s.startMission(ctx, mission); err != nil {
+			return err
+		}
+	}
+	remaining, err := s.incidentGuidanceStepRepo.CountIncompleteSteps(ctx, missionID)
+	if err != nil {
+		return errors.NewDatabaseError("count incomplete steps", err)
+	}
+	if remaining == 0 {
+		return s.completeMission(ctx, mission)
+	}
+	return nil
+}
+
+func (s *MissionService) recordStepHistory(ctx context.Context, mission *models.IncidentGuidance, step *models.IncidentGuidanceStep, action string, reason string) error {
+	if err := s.stepHistoryRepo.Create(ctx, &models.StepHistory{
+		IncidentGuidanceID:     mission.ID,
+		IncidentGuidanceStepID: step.ID,
+		StepNumber:             step.StepNumber,
+		Action:                 action,
+		ActorID:                mission.AssigneeID,
+		Answer:                 step.Answer,
+		Reason:                 reason,
+	}); err != nil {
+		return errors.NewDatabaseError("create step history", err)
+	}
+	return nil
+}
+
+func findStep(mission *models.IncidentGuidance, stepID string) *models.IncidentGuidanceStep {
+	for i := range mission.IncidentGuidanceSteps {
+		if mission.IncidentGuidanceSteps[i].ID.String() == stepID {
+			return &mission.IncidentGuidanceSteps[i]
+		}
+	}
+	return nil
+}
+
+// earlierOpenStep returns the first step before step that is active and not completed, or nil
+// if every earlier step is completed or skipped. Pending steps wait for a question before them, so
+// they never hold up later steps.
+func earlierOpenStep(steps []models.IncidentGuidanceStep, step *models.IncidentGuidanceStep) *models.IncidentGuidanceStep {
+	for i := range steps {
+		earlier := &steps[i]
+		if earlier.StepNumber < step.StepNumber && earlier.State == models.StepStateActive && !earlier.IsCompleted {
+			return earlier
+		}
+	}
+	return nil
+}
+
+// laterDoneStep returns the first step after step that was completed or skipped by the guard, or
+// nil if there is none
+func laterDoneStep(steps []models.IncidentGuidanceStep, step *models.IncidentGuidanceStep) *models.IncidentGuidanceStep {
+	for i := range steps {
+		later := &steps[i]
+		if later.StepNumber > step.StepNumber && (later.IsCompleted || later.SkippedByID != nil) {
+			return later
+		}
+	}
+	return nil
+}
+
+// branchDependents returns the steps whose state was decided by the answer to a question step,
+// directly or through the questions depending on it, so that they can be made pending again. It
+// fails when one of them was already completed or skipped by the guard.
+func branchDependents(steps []models.IncidentGuidanceStep, question *models.IncidentGuidanceStep) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if question.Branching.Key == "" {
+		return ids, nil
+	}
+	keys := []string{question.Branching.Key}
+	for len(keys) > 0 {
+		key := keys[0]
+		keys = keys[1:]
+		for i := range steps {
+			step := &steps[i]
+			if step.Branching.ConditionKey != key {
+				continue
+			}
+			if step.IsCompleted || step.SkippedByID != nil {
+				return nil, errors.NewBadRequestError(fmt.Sprintf("step %d depends on the answer and was already done, undo it first", step.StepNumber))
+			}
+			ids = append(ids, step.ID)
+			if step.Branching.Key != "" {
+				keys = append(keys, step.Branching.Key)
+			}
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"scs-guard/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func newMissionStep(number int64, state string, completed bool) models.IncidentGuidanceStep {
+	step := models.IncidentGuidanceStep{StepNumber: number, State: state, IsCompleted: completed}
+	step.ID = uuid.New()
+	return step
+}
+
+func TestEarlierOpenStep(t *testing.T) {
+	steps := []models.IncidentGuidanceStep{
+		newMissionStep(1, models.StepStateActive, true),
+		newMissionStep(2, models.StepStateSkipped, false),
+		newMissionStep(3, models.StepStatePending, false),
+		newMissionStep(4, models.StepStateActive, false),
+		newMissionStep(5, models.StepStateActive, false),
+	}
+
+	if got := earlierOpenStep(steps, &steps[3]); got != nil {
+		t.Errorf("expected completed, skipped and pending steps not to block step 4, got step %d", got.StepNumber)
+	}
+	if got := earlierOpenStep(steps, &steps[4]); got == nil || got.StepNumber != 4 {
+		t.Errorf("expected step 4 to block step 5, got %v", got)
+	}
+}
+
+func TestLaterDoneStep(t *testing.T) {
+	guard := uuid.New()
+	steps := []models.IncidentGuidanceStep{
+		newMissionStep(1, models.StepStateActive, true),
+		newMissionStep(2, models.StepStateSkipped, false),
+		newMissionStep(3, models.StepStateSkipped, false),
+	}
+
+	if got := laterDoneStep(steps, &steps[0]); got != nil {
+		t.Errorf("expected steps skipped because of an answer to be ignored, got step %d", got.StepNumber)
+	}
+	steps[2].SkippedByID = &guard
+	if got := laterDoneStep(steps, &steps[0]); got == nil || got.StepNumber != 3 {
+		t.Errorf("expected the step skipped by the guard to be found, got %v", got)
+	}
+}
+
+func TestBranchDependents(t *testing.T) {
+	question := newMissionStep(1, models.StepStateActive, true)
+	question.Branching = models.StepBranching{Key: "smoke", QuestionType: models.QuestionTypeYesNo}
+	fire := newMissionStep(2, models.StepStateActive, false)
+	fire.Branching = models.StepBranching{Key: "fire", QuestionType: models.QuestionTypeYesNo, ConditionKey: "smoke", ConditionAnswers: models.StringList{"yes"}}
+	extinguish := newMissionStep(3, models.StepStatePending, false)
+	extinguish.Branching = models.StepBranching{ConditionKey: "fire", ConditionAnswers: models.StringList{"yes"}}
+	reset := newMissionStep(4, models.StepStateSkipped, false)
+	reset.Branching = models.StepBranching{ConditionKey: "smoke", ConditionAnswers: models.StringList{"no"}}
+	report := newMissionStep(5, models.StepStateActive, false)
+
+	t.Run("resets every dependent", func(t *testing.T) {
+		ids, err := branchDependents([]models.IncidentGuidanceStep{question, fire, extinguish, reset, report}, &question)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(ids) != 3 || ids[0] != fire.ID || ids[1] != reset.ID || ids[2] != extinguish.ID {
+			t.Errorf("expected steps 2, 4 and 3 to be reset, got %v", ids)
+		}
+	})
+
+	t.Run("completed dependent", func(t *testing.T) {
+		done := fire
+		done.IsCompleted = true
+		if _, err := branchDependents([]models.IncidentGuidanceStep{question, done, extinguish, reset}, &question); err == nil {
+			t.Error("expected an error when a dependent step was already completed")
+		}
+	})
+
+	t.Run("step without question", func(t *testing.T) {
+		ids, err := branchDependents([]models.IncidentGuidanceStep{question, report}, &report)
+		if err != nil || len(ids) != 0 {
+			t.Errorf("expected nothing to reset, got %v, %v", ids, err)
+		}
+	})
+}
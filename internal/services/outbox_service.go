@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	config "scs-guard/config"
+	"scs-guard/internal/events"
+	"scs-guard/internal/models"
+	repositories "scs-guard/internal/repositories"
+	"scs-guard/pkg/errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxService relays the domain events stored in the outbox to the configured sinks.
+// Delivery is at least once: an event is retried with exponential backoff until every sink
+// accepted it, so sinks may see the same event key more than once.
+type OutboxService struct {
+	outboxEventRepo repositories.OutboxEventRepository
+	sinks           []events.Sink
+	cfg             config.OutboxConfig
+}
+
+func NewOutboxService(outboxEventRepo repositories.OutboxEventRepository, sinks []events.Sink, cfg config.OutboxConfig) *OutboxService {
+	return &OutboxService{
+		outboxEventRepo: outboxEventRepo,
+		sinks:           sinks,
+		cfg:             cfg,
+	}
+}
+
+// Relay publishes a batch of pending events and returns how many were published
+func (s *OutboxService) Relay(ctx context.Context) (int, error) {
+	now := time.Now()
+	pending, err := s.outboxEventRepo.ClaimPending(ctx, now, now.Add(s.cfg.Lease), s.cfg.BatchSize)
+	if err != nil {
+		return 0, errors.NewDatabaseError("claim outbox events", err)
+	}
+	published := 0
+	for i := range pending {
+		outboxEvent := &pending[i]
+		updates := map[string]interface{}{"attempts": outboxEvent.Attempts + 1}
+		if err := s.deliver(ctx, toEvent(outboxEvent)); err != nil {
+			updates["last_error"] = err.Error()
+			if outboxEvent.Attempts+1 >= s.cfg.MaxAttempts {
+				updates["status"] = models.OutboxStatusFailed
+			} else {
+				updates["next_attempt_at"] = time.Now().Add(retryDelay(outboxEvent.Attempts+1, s.cfg.RetryBaseDelay, s.cfg.RetryMaxDelay))
+			}
+		} else {
+			updates["status"] = models.OutboxStatusPublished
+			updates["published_at"] = time.Now()
+			updates["last_error"] = ""
+			published++
+		}
+		if err := s.outboxEventRepo.Update(ctx, outboxEvent.ID.String(), updates); err != nil {
+			return published, errors.NewDatabaseError("update outbox event", err)
+		}
+	}
+	return published, nil
+}
+
+// Cleanup removes published events older than the retention period
+func (s *OutboxService) Cleanup(ctx context.Context) (int64, error) {
+	deleted, err := s.outboxEventRepo.DeletePublishedBefore(ctx, time.Now().Add(-s.cfg.Retention))
+	if err != nil {
+		return 0, errors.NewDatabaseError("delete published outbox events", err)
+	}
+	return deleted, nil
+}
+
+func (s *OutboxService) deliver(ctx context.Context, event events.Event) error {
+	for _, sink := range s.sinks {
+		if err := sink.Send(ctx, event); err != nil {
+			return fmt.Errorf("%s: %w", sink.Name(), err)
+		}
+	}
+	return nil
+}
+
+// retryDelay returns the exponential backoff before the next delivery attempt
+func retryDelay(attempts int, base time.Duration, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+// newOutboxEvent builds the outbox row for an event, encoding its data as the payload
+func newOutboxEvent(event events.Event) (*models.OutboxEvent, error) {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event payload: %w", err)
+	}
+	now := time.Now()
+	return &models.OutboxEvent{
+		Base:          models.Base{ID: uuid.New()},
+		Type:          event.Type,
+		MissionID:     event.MissionID,
+		IncidentID:    event.IncidentID,
+		UserIDs:       strings.Join(event.UserIDs, ","),
+		Payload:       string(payload),
+		OccurredAt:    now,
+		Status:        models.OutboxStatusPending,
+		NextAttemptAt: now,
+	}, nil
+}
+
+// toEvent converts an outbox row back into the event published to sinks
+func toEvent(outboxEvent *models.OutboxEvent) events.Event {
+	var userIDs []string
+	if outboxEvent.UserIDs != "" {
+		userIDs = strings.Split(outboxEvent.UserIDs, ",")
+	}
+	return events.Event{
+		Key:        outboxEvent.ID.String(),
+		Type:       outboxEvent.Type,
+		MissionID:  outboxEvent.MissionID,
+		IncidentID: outboxEvent.IncidentID,
+		Data:       json.RawMessage(outboxEvent.Payload),
+		OccurredAt: outboxEvent.OccurredAt,
+		UserIDs:    userIDs,
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"encoding/json"
+	"scs-guard/internal/events"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{10, time.Minute},
+		{100, time.Minute},
+	}
+
+	for _, tt := range tests {
+		if delay := retryDelay(tt.attempts, time.Second, time.Minute); delay != tt.expected {
+			t.Errorf("attempt %d: expected %v, got %v", tt.attempts, tt.expected, delay)
+		}
+	}
+}
+
+func TestOutboxEventRoundTrip(t *testing.T) {
+	missionID := uuid.New()
+	outboxEvent, err := newOutboxEvent(events.Event{
+		Type:      events.TypeMissionStatusChanged,
+		MissionID: &missionID,
+		Data:      events.StatusChange{From: "accepted", To: "in_progress"},
+		UserIDs:   []string{"guard-1", "guard-2"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	event := toEvent(outboxEvent)
+	if event.Key != outboxEvent.ID.String() {
+		t.Errorf("Expected key %s, got %s", outboxEvent.ID, event.Key)
+	}
+	if event.Type != events.TypeMissionStatusChanged || *event.MissionID != missionID {
+		t.Errorf("Expected %s for mission %s, got %s for %v", events.TypeMissionStatusChanged, missionID, event.Type, event.MissionID)
+	}
+	if !event.VisibleTo("guard-2", "guard") {
+		t.Error("Expected event to stay visible to its guards")
+	}
+	var change events.StatusChange
+	if err := json.Unmarshal(event.Data.(json.RawMessage), &change); err != nil || change.To != "in_progress" {
+		t.Errorf("Expected payload to decode to the status change, got %+v (%v)", change, err)
+	}
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/models"
+	"scs-guard/pkg/errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// validateBranching checks the questions and conditions of the steps of a template: keys are
+// unique, choice questions have at least two options, and every condition names an earlier
+// question step and only answers it can be given.
+func validateBranching(steps []models.GuidanceStep) error {
+	questions := map[string]models.StepBranching{}
+	for _, step := range steps {
+		branching := step.Branching
+		if branching.QuestionType == models.QuestionTypeChoice && len(branching.Options) < 2 {
+			return errors.NewBadRequestError(fmt.Sprintf("step %d: a choice question needs at least two options", step.StepNumber))
+		}
+		if branching.QuestionType != models.QuestionTypeChoice && len(branching.Options) > 0 {
+			return errors.NewBadRequestError(fmt.Sprintf("step %d: only choice questions have options", step.StepNumber))
+		}
+		if branching.ConditionKey == "" && len(branching.ConditionAnswers) > 0 {
+			return errors.NewBadRequestError(fmt.Sprintf("step %d: condition answers need a condition key", step.StepNumber))
+		}
+		if branching.ConditionKey != "" {
+			question, ok := questions[branching.ConditionKey]
+			if !ok {
+				return errors.NewBadRequestError(fmt.Sprintf("step %d: condition key %q does not name an earlier question step", step.StepNumber, branching.ConditionKey))
+			}
+			if len(branching.ConditionAnswers) == 0 {
+				return errors.NewBadRequestError(fmt.Sprintf("step %d: a condition needs at least one answer", step.StepNumber))
+			}
+			for _, answer := range branching.ConditionAnswers {
+				if !containsString(question.AnswerOptions(), answer) {
+					return errors.NewBadRequestError(fmt.Sprintf("step %d: %q is not an answer to question %q, expected one of %s", step.StepNumber, answer, branching.ConditionKey, strings.Join(question.AnswerOptions(), ", ")))
+				}
+			}
+		}
+		if branching.Key == "" {
+			if branching.QuestionType != "" {
+				return errors.NewBadRequestError(fmt.Sprintf("step %d: a question step needs a key", step.StepNumber))
+			}
+			continue
+		}
+		if _, ok := questions[branching.Key]; ok {
+			return errors.NewBadRequestError(fmt.Sprintf("step %d: key %q is used by another step", step.StepNumber, branching.Key))
+		}
+		questions[branching.Key] = branching
+	}
+	return nil
+}
+
+// initialStepState returns the state of a new mission step: steps with a condition wait for the
+// answer to their question
+func initialStepState(branching models.StepBranching) string {
+	if branching.ConditionKey != "" {
+		return models.StepStatePending
+	}
+	return models.StepStateActive
+}
+
+// checkAnswer checks that a step asking a question is answered with one of its options and that
+// other steps are not answered
+func checkAnswer(step *models.IncidentGuidanceStep, answer string) error {
+	options := step.Branching.AnswerOptions()
+	if options == nil {
+		if answer != "" {
+			return errors.NewBadRequestError("the step does not ask a question")
+		}
+		return nil
+	}
+	if !containsString(options, answer) {
+		return errors.NewBadRequestError(fmt.Sprintf("the step must be answered with one of %s", strings.Join(options, ", ")))
+	}
+	return nil
+}
+
+// branchOutcome returns the pending steps a question step activates and the steps it skips when
+// answered. Skipping a question step also skips every step depending on it.
+func branchOutcome(steps []models.IncidentGuidanceStep, question *models.IncidentGuidanceStep, answer string) ([]uuid.UUID, []uuid.UUID) {
+	var activated, skipped []uuid.UUID
+	if question.Branching.Key == "" {
+		return activated, skipped
+	}
+	skippedKeys := []string{}
+	for _, step := range steps {
+		if step.State != models.StepStatePending || step.Branching.ConditionKey != question.Branching.Key {
+			continue
+		}
+		if containsString(step.Branching.ConditionAnswers, answer) {
+			activated = append(activated, step.ID)
+			continue
+		}
+		skipped = append(skipped, step.ID)
+		if step.Branching.Key != "" {
+			skippedKeys = append(skippedKeys, step.Branching.Key)
+		}
+	}
+	for len(skippedKeys) > 0 {
+		key := skippedKeys[0]
+		skippedKeys = skippedKeys[1:]
+		for _, step := range steps {
+			if step.Branching.ConditionKey != key || step.State != models.StepStatePending {
+				continue
+			}
+			skipped = append(skipped, step.ID)
+			if step.Branching.Key != "" {
+				skippedKeys = append(skippedKeys, step.Branching.Key)
+			}
+		}
+	}
+	return activated, skipped
+}
+
+// applyBranching activates and skips the steps depending on the question answered. It must run
+// inside a transaction.
+func (s *MissionService) applyBranching(ctx context.Context, mission *models.IncidentGuidance, question *models.IncidentGuidanceStep, answer string) error {
+	activated, skipped := branchOutcome(mission.IncidentGuidanceSteps, question, answer)
+	if err := s.incidentGuidanceStepRepo.UpdateStates(ctx, activated, models.StepStateActive); err != nil {
+		return errors.NewDatabaseError("activate steps", err)
+	}
+	if err := s.incidentGuidanceStepRepo.UpdateStates(ctx, skipped, models.StepStateSkipped); err != nil {
+		return errors.NewDatabaseError("skip steps", err)
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"scs-guard/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateBranching(t *testing.T) {
+	question := models.GuidanceStep{StepNumber: 1, Branching: models.StepBranching{Key: "smoke", QuestionType: models.QuestionTypeYesNo}}
+	tests := []struct {
+		name    string
+		steps   []models.GuidanceStep
+		wantErr bool
+	}{
+		{"no branching", []models.GuidanceStep{{StepNumber: 1}, {StepNumber: 2}}, false},
+		{"condition on earlier question", []models.GuidanceStep{question, {StepNumber: 2, Branching: models.StepBranching{ConditionKey: "smoke", ConditionAnswers: models.StringList{"yes"}}}}, false},
+		{"condition on later question", []models.GuidanceStep{{StepNumber: 1, Branching: models.StepBranching{ConditionKey: "smoke", ConditionAnswers: models.StringList{"yes"}}}, question}, true},
+		{"unknown answer", []models.GuidanceStep{question, {StepNumber: 2, Branching: models.StepBranching{ConditionKey: "smoke", ConditionAnswers: models.StringList{"maybe"}}}}, true},
+		{"condition without answers", []models.GuidanceStep{question, {StepNumber: 2, Branching: models.StepBranching{ConditionKey: "smoke"}}}, true},
+		{"duplicate key", []models.GuidanceStep{question, {StepNumber: 2, Branching: models.StepBranching{Key: "smoke"}}}, true},
+		{"question without key", []models.GuidanceStep{{StepNumber: 1, Branching: models.StepBranching{QuestionType: models.QuestionTypeYesNo}}}, true},
+		{"choice with one option", []models.GuidanceStep{{StepNumber: 1, Branching: models.StepBranching{Key: "kind", QuestionType: models.QuestionTypeChoice, Options: models.StringList{"Smoke"}}}}, true},
+		{"options on yes/no question", []models.GuidanceStep{{StepNumber: 1, Branching: models.StepBranching{Key: "kind", QuestionType: models.QuestionTypeYesNo, Options: models.StringList{"a", "b"}}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateBranching(tt.steps); (err != nil) != tt.wantErr {
+				t.Errorf("validateBranching() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBranchOutcome(t *testing.T) {
+	newStep := func(state string, branching models.StepBranching) models.IncidentGuidanceStep {
+		step := models.IncidentGuidanceStep{State: state, Branching: branching}
+		step.ID = uuid.New()
+		return step
+	}
+	question := newStep(models.StepStateActive, models.StepBranching{Key: "smoke", QuestionType: models.QuestionTypeYesNo})
+	evacuate := newStep(models.StepStatePending, models.StepBranching{Key: "fire", QuestionType: models.QuestionTypeYesNo, ConditionKey: "smoke", ConditionAnswers: models.StringList{"yes"}})
+	extinguish := newStep(models.StepStatePending, models.StepBranching{ConditionKey: "fire", ConditionAnswers: models.StringList{"yes"}})
+	reset := newStep(models.StepStatePending, models.StepBranching{ConditionKey: "smoke", ConditionAnswers: models.StringList{"no"}})
+	report := newStep(models.StepStateActive, models.StepBranching{})
+	steps := []models.IncidentGuidanceStep{question, evacuate, extinguish, reset, report}
+
+	t.Run("yes", func(t *testing.T) {
+		activated, skipped := branchOutcome(steps, &question, "yes")
+		if len(activated) != 1 || activated[0] != evacuate.ID {
+			t.Errorf("expected only the evacuation step to be activated, got %v", activated)
+		}
+		if len(skipped) != 1 || skipped[0] != reset.ID {
+			t.Errorf("expected only the reset step to be skipped, got %v", skipped)
+		}
+	})
+
+	t.Run("no skips dependents of skipped steps", func(t *testing.T) {
+		activated, skipped := branchOutcome(steps, &question, "no")
+		if len(activated) != 1 || activated[0] != reset.ID {
+			t.Errorf("expected only the reset step to be activated, got %v", activated)
+		}
+		if len(skipped) != 2 || skipped[0] != evacuate.ID || skipped[1] != extinguish.ID {
+			t.Errorf("expected the evacuation and extinguishing steps to be skipped, got %v", skipped)
+		}
+	})
+
+	t.Run("step without question", func(t *testing.T) {
+		activated, skipped := branchOutcome(steps, &report, "")
+		if len(activated) != 0 || len(skipped) != 0 {
+			t.Errorf("expected no change, got %v activated and %v skipped", activated, skipped)
+		}
+	})
+}
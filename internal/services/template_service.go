@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"scs-guard/internal/dto"
+	"scs-guard/internal/models"
+	repositories "scs-guard/internal/repositories"
+	"scs-guard/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// TemplateService manages guidance templates. Template versions are immutable once created:
+// every edit creates a new version, so missions keep the procedure they were instantiated from.
+type TemplateService struct {
+	guidanceTemplateRepo repositories.GuidanceTemplateRepository
+	txManager            repositories.TransactionManager
+}
+
+func NewTemplateService(guidanceTemplateRepo repositories.GuidanceTemplateRepository, txManager repositories.TransactionManager) *TemplateService {
+	return &TemplateService{
+		guidanceTemplateRepo: guidanceTemplateRepo,
+		txManager:            txManager,
+	}
+}
+
+func (s *TemplateService) CreateTemplate(ctx context.Context, createTemplateDto dto.CreateGuidanceTemplateDto) (*models.GuidanceTemplate, error) {
+	id := uuid.New()
+	template := &models.GuidanceTemplate{
+		Base:          models.Base{ID: id},
+		Name:          createTemplateDto.Name,
+		Description:   createTemplateDto.Description,
+		Category:      createTemplateDto.Category,
+		LineageID:     &id,
+		Version:       1,
+		Status:        models.TemplateStatusPublished,
+		GuidanceSteps: toGuidanceSteps(createTemplateDto.Steps),
+		StrictOrder:   createTemplateDto.StrictOrder,
+	}
+	if err := validateBranching(template.GuidanceSteps); err != nil {
+		return nil, err
+	}
+	if _, err := s.guidanceTemplateRepo.CreateGuidanceTemplate(ctx, template); err != nil {
+		return nil, errors.NewDatabaseError("create guidance template", err)
+	}
+	return s.GetTemplate(ctx, id.String())
+}
+
+func (s *TemplateService) GetTemplates(ctx context.Context, filter repositories.GuidanceTemplateFilter) ([]models.GuidanceTemplate, error) {
+	templates, err := s.guidanceTemplateRepo.GetGuidanceTemplates(ctx, filter)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get guidance templates", err)
+	}
+	return templates, nil
+}
+
+func (s *TemplateService) GetTemplate(ctx context.Context, id string) (*models.GuidanceTemplate, error) {
+	template, err := s.guidanceTemplateRepo.GetGuidanceTemplateByID(ctx, id)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("guidance template")
+		}
+		return nil, errors.NewDatabaseError("get guidance template", err)
+	}
+	return template, nil
+}
+
+// GetTemplateVersions returns every version of the template the given version belongs to
+func (s *TemplateService) GetTemplateVersions(ctx context.Context, id string) ([]models.GuidanceTemplate, error) {
+	template, err := s.GetTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.guidanceTemplateRepo.GetGuidanceTemplateVersions(ctx, template.Lineage().String())
+	if err != nil {
+		return nil, errors.NewDatabaseError("get guidance template versions", err)
+	}
+	return versions, nil
+}
+
+// UpdateTemplate replaces the details and steps of a template by creating a new version
+func (s *TemplateService) UpdateTemplate(ctx context.Context, id string, updateTemplateDto dto.UpdateGuidanceTemplateDto) (*models.GuidanceTemplate, error) {
+	return s.createNextVersion(ctx, id, func(template *models.GuidanceTemplate) error {
+		template.Name = updateTemplateDto.Name
+		template.Description = updateTemplateDto.Description
+		template.Category = updateTemplateDto.Category
+		template.GuidanceSteps = toGuidanceSteps(updateTemplateDto.Steps)
+		template.StrictOrder = updateTemplateDto.StrictOrder
+		return nil
+	})
+}
+
+// AddStep inserts a step into a template by creating a new version
+func (s *TemplateService) AddStep(ctx context.Context, id string, addStepDto dto.AddGuidanceStepDto) (*models.GuidanceTemplate, error) {
+	return s.createNextVersion(ctx, id, func(template *models.GuidanceTemplate) error {
+		steps := template.GuidanceSteps
+		position := addStepDto.Position
+		if position == 0 || position > len(steps)+1 {
+			position = len(steps) + 1
+		}
+		step := toGuidanceSteps([]dto.GuidanceStepDto{addStepDto.GuidanceStepDto})[0]
+		steps = append(steps[:position-1], append([]models.GuidanceStep{step}, steps[position-1:]...)...)
+		template.GuidanceSteps = steps
+		return nil
+	})
+}
+
+// UpdateStep edits a step of a template by creating a new version
+func (s *TemplateService) UpdateStep(ctx context.Context, id string, stepID string, updateStepDto dto.GuidanceStepDto) (*models.GuidanceTemplate, error) {
+	return s.createNextVersion(ctx, id, func(template *models.GuidanceTemplate) error {
+		index, err := stepIndex(template, stepID)
+		if err != nil {
+			return err
+		}
+		template.GuidanceSteps[index].Title = updateStepDto.Title
+		template.GuidanceSteps[index].Description = updateStepDto.Description
+		template.GuidanceSteps[index].DurationMinutes = updateStepDto.DurationMinutes
+		template.GuidanceSteps[index].Optional = updateStepDto.Optional
+		template.GuidanceSteps[index].Evidence = toEvidenceRequirements(updateStepDto.Evidence)
+		template.GuidanceSteps[index].Branching = toStepBranching(updateStepDto.Branching)
+		return nil
+	})
+}
+
+// DeleteStep removes a step from a template by creating a new version
+func (s *TemplateService) DeleteStep(ctx context.Context, id string, stepID string) (*models.GuidanceTemplate, error) {
+	return s.createNextVersion(ctx, id, func(template *models.GuidanceTemplate) error {
+		index, err := stepIndex(template, stepID)
+		if err != nil {
+			return err
+		}
+		template.GuidanceSteps = append(template.GuidanceSteps[:index], template.GuidanceSteps[index+1:]...)
+		return nil
+	})
+}
+
+// ReorderSteps changes the order of the steps of a template by creating a new version.
+// stepIDs must list every step of the template exactly once.
+func (s *TemplateService) ReorderSteps(ctx context.Context, id string, stepIDs []string) (*models.GuidanceTemplate, error) {
+	return s.createNextVersion(ctx, id, func(template *models.GuidanceTemplate) error {
+		if len(stepIDs) != len(template.GuidanceSteps) {
+			return errors.NewBadRequestError(fmt.Sprintf("step_ids must list all %d steps of the template", len(template.GuidanceSteps)))
+		}
+		reordered := make([]models.GuidanceStep, 0, len(stepIDs))
+		seen := make(map[int]bool, len(stepIDs))
+		for _, stepID := range stepIDs {
+			index, err := stepIndex(template, stepID)
+			if err != nil {
+				return err
+			}
+			if seen[index] {
+				return errors.NewBadRequestError("step " + stepID + " is listed more than once")
+			}
+			seen[index] = true
+			reordered = append(reordered, template.GuidanceSteps[index])
+		}
+		template.GuidanceSteps = reordered
+		return nil
+	})
+}
+
+// DuplicateTemplate copies a template version into a new template with its own version history
+func (s *TemplateService) DuplicateTemplate(ctx context.Context, id string, name string) (*models.GuidanceTemplate, error) {
+	source, err := s.GetTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	template := copyTemplate(source, name)
+	if _, err := s.guidanceTemplateRepo.CreateGuidanceTemplate(ctx, template); err != nil {
+		return nil, errors.NewDatabaseError("duplicate guidance template", err)
+	}
+	return s.GetTemplate(ctx, template.ID.String())
+}
+
+// ArchiveTemplate archives the current version of a template so it can no longer be assigned or edited
+func (s *TemplateService) ArchiveTemplate(ctx context.Context, id string) (*models.GuidanceTemplate, error) {
+	template, err := s.GetTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if template.Status != models.TemplateStatusPublished {
+		return nil, errors.NewBadRequestError("only the current version of a template can be archived, template is " + template.Status)
+	}
+	ok, err := s.guidanceTemplateRepo.UpdateStatus(ctx, id, models.TemplateStatusPublished, models.TemplateStatusArchived)
+	if err != nil {
+		return nil, errors.NewDatabaseError("archive guidance template", err)
+	}
+	if !ok {
+		return nil, errors.NewConflictError("guidance template was changed by another request")
+	}
+	return s.GetTemplate(ctx, id)
+}
+
+// createNextVersion copies the current version of a template, applies mutate to the copy and
+// stores it as the new current version, superseding the previous one
+func (s *TemplateService) createNextVersion(ctx context.Context, id string, mutate func(template *models.GuidanceTemplate) error) (*models.GuidanceTemplate, error) {
+	current, err := s.GetTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch current.Status {
+	case models.TemplateStatusArchived:
+		return nil, errors.NewBadRequestError("archived templates cannot be edited")
+	case models.TemplateStatusSuperseded:
+		return nil, errors.NewConflictError("only the latest version of a template can be edited")
+	}
+
+	lineageID := current.Lineage()
+	next := &models.GuidanceTemplate{
+		Base:          models.Base{ID: uuid.New()},
+		Name:          current.Name,
+		Description:   current.Description,
+		Category:      current.Category,
+		LineageID:     &lineageID,
+		Version:       current.Version + 1,
+		Status:        models.TemplateStatusPublished,
+		GuidanceSteps: append([]models.GuidanceStep(nil), current.GuidanceSteps...),
+		StrictOrder:   current.StrictOrder,
+	}
+	if err := mutate(next); err != nil {
+		return nil, err
+	}
+	next.GuidanceSteps = copySteps(next.GuidanceSteps)
+	if err := validateBranching(next.GuidanceSteps); err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		ok, err := s.guidanceTemplateRepo.UpdateStatus(ctx, current.ID.String(), models.TemplateStatusPublished, models.TemplateStatusSuperseded)
+		if err != nil {
+			return errors.NewDatabaseError("supersede guidance template", err)
+		}
+		if !ok {
+			return errors.NewConflictError("guidance template was changed by another request")
+		}
+		if _, err := s.guidanceTemplateRepo.CreateGuidanceTemplate(ctx, next); err != nil {
+			return errors.NewDatabaseError("create guidance template version", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTemplate(ctx, next.ID.String())
+}
+
+func toGuidanceSteps(stepDtos []dto.GuidanceStepDto) []models.GuidanceStep {
+	steps := make([]models.GuidanceStep, 0, len(stepDtos))
+	for i, stepDto := range stepDtos {
+		steps = append(steps, models.GuidanceStep{
+			StepNumber:      i + 1,
+			Title:           stepDto.Title,
+			Description:     stepDto.Description,
+			DurationMinutes: stepDto.DurationMinutes,
+			Optional:        stepDto.Optional,
+			Evidence:        toEvidenceRequirements(stepDto.Evidence),
+			Branching:       toStepBranching(stepDto.Branching),
+		})
+	}
+	return steps
+}
+
+func toEvidenceRequirements(evidenceDto dto.EvidenceRequirementsDto) models.EvidenceRequirements {
+	return models.EvidenceRequirements{
+		MinPhotos:        evidenceDto.MinPhotos,
+		RequireNote:      evidenceDto.RequireNote,
+		RequireSignature: evidenceDto.RequireSignature,
+		Checklist:        evidenceDto.Checklist,
+		ReadingLabel:     evidenceDto.ReadingLabel,
+		ReadingUnit:      evidenceDto.ReadingUnit,
+	}
+}
+
+func toStepBranching(branchingDto dto.StepBranchingDto) models.StepBranching {
+	return models.StepBranching{
+		Key:              branchingDto.Key,
+		QuestionType:     branchingDto.QuestionType,
+		Options:          branchingDto.Options,
+		ConditionKey:     branchingDto.ConditionKey,
+		ConditionAnswers: branchingDto.ConditionAnswers,
+	}
+}
+
+// copyTemplate returns the first version of a new template with the settings and steps of source,
+// named "Copy of" the source when no name is given
+func copyTemplate(source *models.GuidanceTemplate, name string) *models.GuidanceTemplate {
+	if name == "" {
+		name = "Copy of " + source.Name
+	}
+	id := uuid.New()
+	return &models.GuidanceTemplate{
+		Base:          models.Base{ID: id},
+		Name:          name,
+		Description:   source.Description,
+		Category:      source.Category,
+		LineageID:     &id,
+		Version:       1,
+		Status:        models.TemplateStatusPublished,
+		GuidanceSteps: copySteps(source.GuidanceSteps),
+		StrictOrder:   source.StrictOrder,
+	}
+}
+
+// copySteps returns new, unsaved steps with the content of steps, numbered in slice order
+func copySteps(steps []models.GuidanceStep) []models.GuidanceStep {
+	copies := make([]models.GuidanceStep, 0, len(steps))
+	for i, step := range steps {
+		step.Base = models.Base{}
+		step.GuidanceTemplateID = uuid.Nil
+		step.GuidanceTemplate = nil
+		step.StepNumber = i + 1
+		copies = append(copies, step)
+	}
+	return copies
+}
+
+func stepIndex(template *models.GuidanceTemplate, stepID string) (int, error) {
+	for i, step := range template.GuidanceSteps {
+		if step.ID.String() == stepID {
+			return i, nil
+		}
+	}
+	return 0, errors.NewNotFoundError("guidance step")
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"scs-guard/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCopyTemplate(t *testing.T) {
+	lineageID := uuid.New()
+	source := &models.GuidanceTemplate{
+		Name:        "Fire alarm",
+		Category:    "Emergency",
+		LineageID:   &lineageID,
+		Version:     3,
+		Status:      models.TemplateStatusSuperseded,
+		StrictOrder: true,
+		GuidanceSteps: []models.GuidanceStep{
+			{Base: models.Base{ID: uuid.New()}, StepNumber: 2, Title: "Check the panel"},
+			{Base: models.Base{ID: uuid.New()}, StepNumber: 5, Title: "Call the fire brigade"},
+		},
+	}
+	source.ID = uuid.New()
+
+	copied := copyTemplate(source, "")
+	if copied.Name != "Copy of Fire alarm" || copied.Category != "Emergency" {
+		t.Errorf("copyTemplate() = %q in %q, want Copy of Fire alarm in Emergency", copied.Name, copied.Category)
+	}
+	if !copied.StrictOrder {
+		t.Error("copyTemplate() of a strict order template is not strict order")
+	}
+	if copied.ID == source.ID || copied.LineageID == nil || *copied.LineageID != copied.ID || copied.Version != 1 || copied.Status != models.TemplateStatusPublished {
+		t.Errorf("copyTemplate() = %+v, want the published first version of a new lineage", copied)
+	}
+	for i, step := range copied.GuidanceSteps {
+		if step.ID != uuid.Nil || step.StepNumber != i+1 {
+			t.Errorf("copyTemplate() step %d = %s number %d, want a new step numbered %d", i, step.ID, step.StepNumber, i+1)
+		}
+	}
+	if named := copyTemplate(source, "Night fire alarm"); named.Name != "Night fire alarm" {
+		t.Errorf("copyTemplate() name = %q, want Night fire alarm", named.Name)
+	}
+}
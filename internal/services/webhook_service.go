@@ -0,0 +1,302 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	config "scs-guard/config"
+	"scs-guard/internal/dto"
+	"scs-guard/internal/events"
+	"scs-guard/internal/models"
+	repositories "scs-guard/internal/repositories"
+	"scs-guard/pkg/errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// WebhookService manages webhook subscriptions and delivers mission events to them. It is an
+// outbox sink: every relayed event is queued once per matching subscription, and queued
+// deliveries are sent signed with the subscription secret, retried with exponential backoff and
+// dead-lettered after the last attempt.
+type WebhookService struct {
+	webhookRepo repositories.WebhookRepository
+	client      *http.Client
+	cfg         config.WebhookConfig
+}
+
+func NewWebhookService(webhookRepo repositories.WebhookRepository, client *http.Client, cfg config.WebhookConfig) *WebhookService {
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		client:      client,
+		cfg:         cfg,
+	}
+}
+
+// CreateSubscription subscribes a URL to mission events. The secret is only returned here.
+func (s *WebhookService) CreateSubscription(ctx context.Context, userID string, createDto dto.CreateWebhookSubscriptionDto) (*dto.WebhookSubscriptionSecretDto, error) {
+	secret := createDto.Secret
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+	subscription := &models.WebhookSubscription{
+		Name:       createDto.Name,
+		URL:        createDto.URL,
+		Secret:     secret,
+		EventTypes: strings.Join(createDto.EventTypes, ","),
+		Active:     true,
+	}
+	if createdBy, err := uuid.Parse(userID); err == nil {
+		subscription.CreatedByID = &createdBy
+	}
+	if err := s.webhookRepo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, errors.NewDatabaseError("create webhook subscription", err)
+	}
+	return &dto.WebhookSubscriptionSecretDto{WebhookSubscription: *subscription, Secret: secret}, nil
+}
+
+func (s *WebhookService) GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	subscriptions, err := s.webhookRepo.GetSubscriptions(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get webhook subscriptions", err)
+	}
+	return subscriptions, nil
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	subscription, err := s.webhookRepo.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("webhook subscription")
+		}
+		return nil, errors.NewDatabaseError("get webhook subscription", err)
+	}
+	return subscription, nil
+}
+
+// UpdateSubscription edits a subscription, rotating its secret when a new one is given
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id string, updateDto dto.UpdateWebhookSubscriptionDto) (*models.WebhookSubscription, error) {
+	if _, err := s.GetSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+	updates := map[string]interface{}{
+		"name":        updateDto.Name,
+		"url":         updateDto.URL,
+		"event_types": strings.Join(updateDto.EventTypes, ","),
+		"active":      updateDto.Active,
+	}
+	if updateDto.Secret != "" {
+		updates["secret"] = updateDto.Secret
+	}
+	if err := s.webhookRepo.UpdateSubscription(ctx, id, updates); err != nil {
+		return nil, errors.NewDatabaseError("update webhook subscription", err)
+	}
+	return s.GetSubscription(ctx, id)
+}
+
+// DeleteSubscription removes a subscription and its delivery log
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id string) error {
+	if _, err := s.GetSubscription(ctx, id); err != nil {
+		return err
+	}
+	if err := s.webhookRepo.DeleteSubscription(ctx, id); err != nil {
+		return errors.NewDatabaseError("delete webhook subscription", err)
+	}
+	return nil
+}
+
+// GetDeliveries returns a page of the delivery log of a subscription, newest first
+func (s *WebhookService) GetDeliveries(ctx context.Context, subscriptionID string, filter repositories.WebhookDeliveryFilter) (*dto.WebhookDeliveryPageDto, error) {
+	if _, err := s.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	deliveries, total, err := s.webhookRepo.GetDeliveries(ctx, subscriptionID, filter)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get webhook deliveries", err)
+	}
+	return &dto.WebhookDeliveryPageDto{Deliveries: deliveries, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+// RetryDelivery queues a dead-lettered delivery again with a fresh set of attempts
+func (s *WebhookService) RetryDelivery(ctx context.Context, subscriptionID string, deliveryID string) (*models.WebhookDelivery, error) {
+	delivery, err := s.webhookRepo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		if repositories.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("webhook delivery")
+		}
+		return nil, errors.NewDatabaseError("get webhook delivery", err)
+	}
+	if delivery.SubscriptionID.String() != subscriptionID {
+		return nil, errors.NewNotFoundError("webhook delivery")
+	}
+	if delivery.Status != models.WebhookDeliveryDead {
+		return nil, errors.NewBadRequestError("only dead deliveries can be retried, delivery is " + delivery.Status)
+	}
+	if err := s.webhookRepo.UpdateDelivery(ctx, deliveryID, map[string]interface{}{
+		"status":          models.WebhookDeliveryPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+	}); err != nil {
+		return nil, errors.NewDatabaseError("retry webhook delivery", err)
+	}
+	delivery, err = s.webhookRepo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get webhook delivery", err)
+	}
+	return delivery, nil
+}
+
+func (s *WebhookService) Name() string {
+	return "webhooks"
+}
+
+// Send queues an event for every active subscription whose filter matches it
+func (s *WebhookService) Send(ctx context.Context, event events.Event) error {
+	subscriptions, err := s.webhookRepo.GetActiveSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	var payload []byte
+	var deliveries []models.WebhookDelivery
+	now := time.Now()
+	for _, subscription := range subscriptions {
+		if !subscribesTo(subscription, event.Type) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				return fmt.Errorf("failed to encode event: %w", err)
+			}
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventKey:       event.Key,
+			EventType:      event.Type,
+			Payload:        string(payload),
+			Status:         models.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return s.webhookRepo.CreateDeliveries(ctx, deliveries)
+}
+
+// DeliverPending sends a batch of queued deliveries and returns how many were delivered
+func (s *WebhookService) DeliverPending(ctx context.Context) (int, error) {
+	now := time.Now()
+	pending, err := s.webhookRepo.ClaimPendingDeliveries(ctx, now, now.Add(s.cfg.Lease), s.cfg.BatchSize)
+	if err != nil {
+		return 0, errors.NewDatabaseError("claim webhook deliveries", err)
+	}
+	delivered := 0
+	for i := range pending {
+		delivery := &pending[i]
+		if delivery.Subscription == nil {
+			continue
+		}
+		statusCode, err := sendWebhook(ctx, s.client, delivery.Subscription.URL, delivery.Subscription.Secret, delivery, time.Now())
+		updates := map[string]interface{}{
+			"attempts":         delivery.Attempts + 1,
+			"last_status_code": statusCode,
+		}
+		if err != nil {
+			updates["last_error"] = err.Error()
+			if delivery.Attempts+1 >= s.cfg.MaxAttempts {
+				updates["status"] = models.WebhookDeliveryDead
+			} else {
+				updates["next_attempt_at"] = time.Now().Add(retryDelay(delivery.Attempts+1, s.cfg.RetryBaseDelay, s.cfg.RetryMaxDelay))
+			}
+		} else {
+			updates["status"] = models.WebhookDeliveryDelivered
+			updates["delivered_at"] = time.Now()
+			updates["last_error"] = ""
+			delivered++
+		}
+		if err := s.webhookRepo.UpdateDelivery(ctx, delivery.ID.String(), updates); err != nil {
+			return delivered, errors.NewDatabaseError("update webhook delivery", err)
+		}
+	}
+	return delivered, nil
+}
+
+// SignWebhookPayload returns the signature of a delivery: the hex HMAC-SHA256, keyed with the
+// subscription secret, of the timestamp, a dot and the request body. Receivers recompute it to
+// check that a delivery is authentic and reject old timestamps to prevent replays.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// sendWebhook posts a delivery to url and returns the response status code. Any response other
+// than 2xx fails the delivery.
+func sendWebhook(ctx context.Context, client *http.Client, url string, secret string, delivery *models.WebhookDelivery, now time.Time) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := now.Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", delivery.EventKey)
+	req.Header.Set(WebhookHeaderEvent, delivery.EventType)
+	req.Header.Set(WebhookHeaderDelivery, delivery.ID.String())
+	req.Header.Set(WebhookHeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookHeaderSignature, SignWebhookPayload(secret, timestamp, body))
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook returned %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// subscribesTo reports whether a subscription wants events of a type
+func subscribesTo(subscription models.WebhookSubscription, eventType string) bool {
+	if subscription.EventTypes == "" {
+		return true
+	}
+	for _, t := range strings.Split(subscription.EventTypes, ",") {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"scs-guard/internal/models"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSendWebhookSignsDelivery(t *testing.T) {
+	const secret = "0123456789abcdef0123456789abcdef"
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	delivery := &models.WebhookDelivery{
+		Base:      models.Base{ID: uuid.New()},
+		EventKey:  "event-1",
+		EventType: "media.uploaded",
+		Payload:   `{"key":"event-1","type":"media.uploaded"}`,
+	}
+	now := time.Unix(1700000000, 0)
+	statusCode, err := sendWebhook(context.Background(), server.Client(), server.URL, secret, delivery, now)
+	if err != nil || statusCode != http.StatusOK {
+		t.Fatalf("Expected a 200 delivery, got %d (%v)", statusCode, err)
+	}
+
+	if string(body) != delivery.Payload {
+		t.Errorf("Expected body %s, got %s", delivery.Payload, body)
+	}
+	timestamp, _ := strconv.ParseInt(received.Header.Get(WebhookHeaderTimestamp), 10, 64)
+	if expected := SignWebhookPayload(secret, timestamp, body); received.Header.Get(WebhookHeaderSignature) != expected {
+		t.Errorf("Expected signature %s, got %s", expected, received.Header.Get(WebhookHeaderSignature))
+	}
+	if received.Header.Get(WebhookHeaderEvent) != "media.uploaded" || received.Header.Get(WebhookHeaderDelivery) != delivery.ID.String() {
+		t.Errorf("Unexpected event headers %v", received.Header)
+	}
+	if received.Header.Get("Idempotency-Key") != "event-1" {
+		t.Errorf("Expected Idempotency-Key event-1, got %q", received.Header.Get("Idempotency-Key"))
+	}
+}
+
+func TestSendWebhookFailsOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	statusCode, err := sendWebhook(context.Background(), server.Client(), server.URL, "secret", &models.WebhookDelivery{Payload: "{}"}, time.Now())
+	if err == nil || statusCode != http.StatusBadGateway {
+		t.Errorf("Expected a failed 502 delivery, got %d (%v)", statusCode, err)
+	}
+}
+
+func TestSignWebhookPayloadDependsOnSecret(t *testing.T) {
+	body := []byte(`{"type":"step.completed"}`)
+	if SignWebhookPayload("secret-a", 1, body) == SignWebhookPayload("secret-b", 1, body) {
+		t.Error("Expected different secrets to produce different signatures")
+	}
+	if SignWebhookPayload("secret-a", 1, body) == SignWebhookPayload("secret-a", 2, body) {
+		t.Error("Expected different timestamps to produce different signatures")
+	}
+}
+
+func TestSubscribesTo(t *testing.T) {
+	all := models.WebhookSubscription{}
+	filtered := models.WebhookSubscription{EventTypes: "mission.status_changed,media.uploaded"}
+
+	if !subscribesTo(all, "step.completed") {
+		t.Error("Expected a subscription without filter to receive every event")
+	}
+	if !subscribesTo(filtered, "media.uploaded") {
+		t.Error("Expected the filtered subscription to receive media.uploaded")
+	}
+	if subscribesTo(filtered, "step.completed") {
+		t.Error("Expected the filtered subscription not to receive step.completed")
+	}
+}
@@ -0,0 +1,29 @@
+package workers
+
+import (
+	"context"
+	"scs-guard/internal/services"
+	"scs-guard/pkg/logger"
+)
+
+// EscalationJob escalates missions that are not accepted or not progressing in time
+type EscalationJob struct {
+	missionService *services.MissionService
+	logger         logger.Logger
+}
+
+func NewEscalationJob(missionService *services.MissionService, logger logger.Logger) *EscalationJob {
+	return &EscalationJob{missionService: missionService, logger: logger}
+}
+
+func (j *EscalationJob) Name() string {
+	return "mission-escalation"
+}
+
+func (j *EscalationJob) Run(ctx context.Context) error {
+	escalated, err := j.missionService.EscalateMissions(ctx)
+	if escalated > 0 {
+		j.logger.Infof("Escalated %d missions", escalated)
+	}
+	return err
+}
@@ -0,0 +1,29 @@
+package workers
+
+import (
+	"context"
+	"scs-guard/internal/services"
+	"scs-guard/pkg/logger"
+)
+
+// MediaProcessingJob makes the thumbnails and previews of uploaded images
+type MediaProcessingJob struct {
+	mediaService *services.MediaService
+	logger       logger.Logger
+}
+
+func NewMediaProcessingJob(mediaService *services.MediaService, logger logger.Logger) *MediaProcessingJob {
+	return &MediaProcessingJob{mediaService: mediaService, logger: logger}
+}
+
+func (j *MediaProcessingJob) Name() string {
+	return "media-processing"
+}
+
+func (j *MediaProcessingJob) Run(ctx context.Context) error {
+	processed, err := j.mediaService.ProcessPendingMedia(ctx)
+	if processed > 0 {
+		j.logger.Infof("Processed %d incident media", processed)
+	}
+	return err
+}
@@ -0,0 +1,29 @@
+package workers
+
+import (
+	"context"
+	"scs-guard/internal/services"
+	"scs-guard/pkg/logger"
+)
+
+// MediaPurgeJob removes the files of deleted media once their retention period expired
+type MediaPurgeJob struct {
+	mediaService *services.MediaService
+	logger       logger.Logger
+}
+
+func NewMediaPurgeJob(mediaService *services.MediaService, logger logger.Logger) *MediaPurgeJob {
+	return &MediaPurgeJob{mediaService: mediaService, logger: logger}
+}
+
+func (j *MediaPurgeJob) Name() string {
+	return "media-purge"
+}
+
+func (j *MediaPurgeJob) Run(ctx context.Context) error {
+	purged, err := j.mediaService.PurgeDeletedMedia(ctx)
+	if purged > 0 {
+		j.logger.Infof("Purged the files of %d deleted incident media", purged)
+	}
+	return err
+}
@@ -0,0 +1,33 @@
+package workers
+
+import (
+	"context"
+	"scs-guard/internal/services"
+	"scs-guard/pkg/logger"
+	"time"
+)
+
+// MediaReconcileJob reports objects in storage that belong to no incident media
+type MediaReconcileJob struct {
+	mediaService *services.MediaService
+	logger       logger.Logger
+}
+
+func NewMediaReconcileJob(mediaService *services.MediaService, logger logger.Logger) *MediaReconcileJob {
+	return &MediaReconcileJob{mediaService: mediaService, logger: logger}
+}
+
+func (j *MediaReconcileJob) Name() string {
+	return "media-reconcile"
+}
+
+func (j *MediaReconcileJob) Run(ctx context.Context) error {
+	orphans, removed, err := j.mediaService.ReconcileStorage(ctx)
+	for _, orphan := range orphans {
+		j.logger.Warnf("Orphaned object %s (%d bytes, stored %s) belongs to no incident media", orphan.Key, orphan.Size, orphan.LastModified.Format(time.RFC3339))
+	}
+	if len(orphans) > 0 {
+		j.logger.Infof("Found %d orphaned objects in storage, removed %d", len(orphans), removed)
+	}
+	return err
+}
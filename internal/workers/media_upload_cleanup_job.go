@@ -0,0 +1,29 @@
+package workers
+
+import (
+	"context"
+	"scs-guard/internal/services"
+	"scs-guard/pkg/logger"
+)
+
+// MediaUploadCleanupJob discards media uploads that were abandoned before being confirmed
+type MediaUploadCleanupJob struct {
+	missionService *services.MissionService
+	logger         logger.Logger
+}
+
+func NewMediaUploadCleanupJob(missionService *services.MissionService, logger logger.Logger) *MediaUploadCleanupJob {
+	return &MediaUploadCleanupJob{missionService: missionService, logger: logger}
+}
+
+func (j *MediaUploadCleanupJob) Name() string {
+	return "media-upload-cleanup"
+}
+
+func (j *MediaUploadCleanupJob) Run(ctx context.Context) error {
+	discarded, err := j.missionService.CleanupAbandonedUploads(ctx)
+	if discarded > 0 {
+		j.logger.Infof("Discarded %d abandoned media uploads", discarded)
+	}
+	return err
+}
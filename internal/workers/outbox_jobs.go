@@ -0,0 +1,51 @@
+package workers
+
+import (
+	"context"
+	"scs-guard/internal/services"
+	"scs-guard/pkg/logger"
+)
+
+// OutboxRelayJob publishes pending outbox events to the configured sinks
+type OutboxRelayJob struct {
+	outboxService *services.OutboxService
+	logger        logger.Logger
+}
+
+func NewOutboxRelayJob(outboxService *services.OutboxService, logger logger.Logger) *OutboxRelayJob {
+	return &OutboxRelayJob{outboxService: outboxService, logger: logger}
+}
+
+func (j *OutboxRelayJob) Name() string {
+	return "outbox-relay"
+}
+
+func (j *OutboxRelayJob) Run(ctx context.Context) error {
+	published, err := j.outboxService.Relay(ctx)
+	if published > 0 {
+		j.logger.Debugf("Published %d outbox events", published)
+	}
+	return err
+}
+
+// OutboxCleanupJob removes published outbox events past their retention
+type OutboxCleanupJob struct {
+	outboxService *services.OutboxService
+	logger        logger.Logger
+}
+
+func NewOutboxCleanupJob(outboxService *services.OutboxService, logger logger.Logger) *OutboxCleanupJob {
+	return &OutboxCleanupJob{outboxService: outboxService, logger: logger}
+}
+
+func (j *OutboxCleanupJob) Name() string {
+	return "outbox-cleanup"
+}
+
+func (j *OutboxCleanupJob) Run(ctx context.Context) error {
+	deleted, err := j.outboxService.Cleanup(ctx)
+	if deleted > 0 {
+		j.logger.Infof("Deleted %d published outbox events", deleted)
+	}
+	return err
+}
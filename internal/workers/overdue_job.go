@@ -0,0 +1,29 @@
+package workers
+
+import (
+	"context"
+	"scs-guard/internal/services"
+	"scs-guard/pkg/logger"
+)
+
+// OverdueJob flags missions and steps that missed their deadline
+type OverdueJob struct {
+	missionService *services.MissionService
+	logger         logger.Logger
+}
+
+func NewOverdueJob(missionService *services.MissionService, logger logger.Logger) *OverdueJob {
+	return &OverdueJob{missionService: missionService, logger: logger}
+}
+
+func (j *OverdueJob) Name() string {
+	return "overdue-detection"
+}
+
+func (j *OverdueJob) Run(ctx context.Context) error {
+	flagged, err := j.missionService.DetectOverdue(ctx)
+	if flagged > 0 {
+		j.logger.Infof("Flagged %d overdue missions and steps", flagged)
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+package workers
+
+import (
+	"context"
+	"scs-guard/pkg/logger"
+	"sync"
+	"time"
+)
+
+// Job is a unit of background work run periodically by the Scheduler
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+type scheduledJob struct {
+	job      Job
+	interval time.Duration
+}
+
+// Scheduler runs background jobs at fixed intervals alongside the HTTP server
+type Scheduler struct {
+	logger logger.Logger
+	jobs   []scheduledJob
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(logger logger.Logger) *Scheduler {
+	return &Scheduler{logger: logger}
+}
+
+// Add registers a job to run every interval. Jobs must be added before Start.
+func (s *Scheduler) Add(job Job, interval time.Duration) {
+	s.jobs = append(s.jobs, scheduledJob{job: job, interval: interval})
+}
+
+// Start runs every job in its own goroutine until Stop is called
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, scheduled := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, scheduled)
+	}
+}
+
+// Stop cancels the running jobs and waits for them to return
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, scheduled scheduledJob) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(scheduled.interval)
+	defer ticker.Stop()
+
+	s.logger.Infof("Worker %s started, interval: %s", scheduled.job.Name(), scheduled.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Infof("Worker %s stopped", scheduled.job.Name())
+			return
+		case <-ticker.C:
+			if err := scheduled.job.Run(ctx); err != nil {
+				s.logger.Errorf("Worker %s failed: %v", scheduled.job.Name(), err)
+			}
+		}
+	}
+}
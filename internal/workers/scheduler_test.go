@@ -0,0 +1,44 @@
+package workers
+
+import (
+	"context"
+	"scs-guard/pkg/logger"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingJob struct {
+	runs atomic.Int32
+}
+
+func (j *countingJob) Name() string {
+	return "counting"
+}
+
+func (j *countingJob) Run(ctx context.Context) error {
+	j.runs.Add(1)
+	return nil
+}
+
+func TestSchedulerRunsJobsUntilStopped(t *testing.T) {
+	job := &countingJob{}
+	scheduler := NewScheduler(logger.GetLogger())
+	scheduler.Add(job, 5*time.Millisecond)
+	scheduler.Start(context.Background())
+
+	deadline := time.Now().Add(time.Second)
+	for job.runs.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	scheduler.Stop()
+
+	runs := job.runs.Load()
+	if runs < 2 {
+		t.Fatalf("Expected job to run at least twice, ran %d times", runs)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if job.runs.Load() != runs {
+		t.Errorf("Expected job to stop running after Stop")
+	}
+}
@@ -0,0 +1,29 @@
+package workers
+
+import (
+	"context"
+	"scs-guard/internal/services"
+	"scs-guard/pkg/logger"
+)
+
+// WebhookDeliveryJob sends queued webhook deliveries
+type WebhookDeliveryJob struct {
+	webhookService *services.WebhookService
+	logger         logger.Logger
+}
+
+func NewWebhookDeliveryJob(webhookService *services.WebhookService, logger logger.Logger) *WebhookDeliveryJob {
+	return &WebhookDeliveryJob{webhookService: webhookService, logger: logger}
+}
+
+func (j *WebhookDeliveryJob) Name() string {
+	return "webhook-delivery"
+}
+
+func (j *WebhookDeliveryJob) Run(ctx context.Context) error {
+	delivered, err := j.webhookService.DeliverPending(ctx)
+	if delivered > 0 {
+		j.logger.Debugf("Delivered %d webhooks", delivered)
+	}
+	return err
+}
@@ -0,0 +1,427 @@
+package exif
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotJPEG is returned for files that do not start like a JPEG image
+	ErrNotJPEG = errors.New("not a JPEG image")
+	// ErrNoExif is returned for JPEG images without EXIF metadata
+	ErrNoExif = errors.New("no EXIF metadata")
+	// ErrInvalid is returned for EXIF metadata that cannot be parsed
+	ErrInvalid = errors.New("invalid EXIF metadata")
+)
+
+// IsNotJPEG reports whether err is caused by a file that is not a JPEG image
+func IsNotJPEG(err error) bool {
+	return errors.Is(err, ErrNotJPEG)
+}
+
+// Metadata is what a photo tells about when, where and with what it was taken. Fields the photo
+// does not carry are left empty.
+type Metadata struct {
+	// CapturedAt is when the photo was taken
+	CapturedAt *time.Time
+	// Latitude and Longitude are where the photo was taken, in decimal degrees
+	Latitude  *float64
+	Longitude *float64
+	// Make and Model are the manufacturer and model of the camera or phone
+	Make  string
+	Model string
+	// Orientation is how the image must be rotated or flipped to be displayed upright, from 1
+	// (as stored) to 8; 0 when unknown
+	Orientation int
+}
+
+// JPEG markers
+const (
+	markerPrefix = 0xFF
+	markerSOI    = 0xD8
+	markerEOI    = 0xD9
+	markerSOS    = 0xDA
+	markerAPP0   = 0xE0
+	markerAPP1   = 0xE1
+	markerAPP13  = 0xED
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// TIFF tags read from the image, EXIF and GPS directories
+const (
+	tagMake               = 0x010F
+	tagModel              = 0x0110
+	tagOrientation        = 0x0112
+	tagDateTime           = 0x0132
+	tagExifIFD            = 0x8769
+	tagGPSIFD             = 0x8825
+	tagDateTimeOriginal   = 0x9003
+	tagOffsetTimeOriginal = 0x9011
+	tagGPSLatitudeRef     = 0x0001
+	tagGPSLatitude        = 0x0002
+	tagGPSLongitudeRef    = 0x0003
+	tagGPSLongitude       = 0x0004
+	tagGPSTimeStamp       = 0x0007
+	tagGPSDateStamp       = 0x001D
+)
+
+// TIFF value types
+const (
+	typeByte      = 1
+	typeASCII     = 2
+	typeShort     = 3
+	typeLong      = 4
+	typeRational  = 5
+	typeUndefined = 7
+	typeSLong     = 9
+	typeSRational = 10
+)
+
+var typeSizes = map[uint16]uint32{
+	typeByte: 1, typeASCII: 1, typeShort: 2, typeLong: 4, typeRational: 8, typeUndefined: 1, typeSLong: 4, typeSRational: 8,
+}
+
+// dateTimeLayout is how EXIF writes dates and times
+const dateTimeLayout = "2006:01:02 15:04:05"
+
+// Decode reads the EXIF metadata of a JPEG image. Only the segments before the image data are
+// read. Capture times without a time zone are read in the local time zone of the server.
+func Decode(r io.Reader) (*Metadata, error) {
+	var metadata *Metadata
+	_, err := readSegments(bufio.NewReader(r), func(marker byte, data []byte) (bool, error) {
+		if marker != markerAPP1 || !bytes.HasPrefix(data, exifHeader) {
+			return true, nil
+		}
+		var err error
+		metadata, err = parseTIFF(data[len(exifHeader):])
+		return false, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil {
+		return nil, ErrNoExif
+	}
+	return metadata, nil
+}
+
+// Strip copies a JPEG image from r to w without its EXIF, XMP and IPTC metadata, which can reveal
+// where, when and with what the photo was taken. The image data is copied unchanged. The
+// orientation is kept in a minimal EXIF segment so that the copy is still displayed upright.
+func Strip(w io.Writer, r io.Reader) error {
+	br := bufio.NewReader(r)
+	type segment struct {
+		marker byte
+		data   []byte
+	}
+	var kept []segment
+	orientation := 0
+	last, err := readSegments(br, func(marker byte, data []byte) (bool, error) {
+		switch {
+		case marker == markerAPP1 && bytes.HasPrefix(data, exifHeader):
+			if metadata, err := parseTIFF(data[len(exifHeader):]); err == nil {
+				orientation = metadata.Orientation
+			}
+		case marker == markerAPP1 || marker == markerAPP13:
+			// XMP and IPTC metadata
+		default:
+			kept = append(kept, segment{marker, data})
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	if orientation > 1 && orientation <= 8 {
+		// The EXIF segment goes right after the JFIF segment, which must come first
+		at := 0
+		if len(kept) > 0 && kept[0].marker == markerAPP0 {
+			at = 1
+		}
+		kept = append(kept[:at], append([]segment{{markerAPP1, orientationExif(orientation)}}, kept[at:]...)...)
+	}
+
+	if _, err := w.Write([]byte{markerPrefix, markerSOI}); err != nil {
+		return err
+	}
+	for _, segment := range kept {
+		if err := writeSegment(w, segment.marker, segment.data); err != nil {
+			return err
+		}
+	}
+	// Everything from the start of scan marker on is the image data
+	if _, err := w.Write([]byte{markerPrefix, last}); err != nil {
+		return err
+	}
+	_, err = io.Copy(w, br)
+	return err
+}
+
+// readSegments calls fn with every marker segment of a JPEG image up to the start of its image
+// data, which is left unread, and returns the marker it stopped at. fn returns false to stop
+// reading.
+func readSegments(r *bufio.Reader, fn func(marker byte, data []byte) (bool, error)) (byte, error) {
+	var soi [2]byte
+	if _, err := io.ReadFull(r, soi[:]); err != nil || soi[0] != markerPrefix || soi[1] != markerSOI {
+		return 0, ErrNotJPEG
+	}
+	for {
+		prefix, err := r.ReadByte()
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrNotJPEG, err)
+		}
+		if prefix != markerPrefix {
+			return 0, fmt.Errorf("%w: expected a marker", ErrNotJPEG)
+		}
+		marker, err := r.ReadByte()
+		// Markers may be preceded by any number of fill bytes
+		for err == nil && marker == markerPrefix {
+			marker, err = r.ReadByte()
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrNotJPEG, err)
+		}
+		if marker == markerSOS || marker == markerEOI {
+			return marker, nil
+		}
+		var length [2]byte
+		if _, err := io.ReadFull(r, length[:]); err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrNotJPEG, err)
+		}
+		size := int(binary.BigEndian.Uint16(length[:]))
+		if size < 2 {
+			return 0, fmt.Errorf("%w: invalid segment length", ErrNotJPEG)
+		}
+		data := make([]byte, size-2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrNotJPEG, err)
+		}
+		more, err := fn(marker, data)
+		if err != nil || !more {
+			return marker, err
+		}
+	}
+}
+
+func writeSegment(w io.Writer, marker byte, data []byte) error {
+	header := []byte{markerPrefix, marker, 0, 0}
+	binary.BigEndian.PutUint16(header[2:], uint16(len(data)+2))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// orientationExif returns an EXIF segment holding only the orientation tag
+func orientationExif(orientation int) []byte {
+	var buf bytes.Buffer
+	buf.Write(exifHeader)
+	buf.WriteString("MM\x00\x2A")
+	binary.Write(&buf, binary.BigEndian, uint32(8))
+	binary.Write(&buf, binary.BigEndian, uint16(1))
+	binary.Write(&buf, binary.BigEndian, []uint16{tagOrientation, typeShort})
+	binary.Write(&buf, binary.BigEndian, uint32(1))
+	binary.Write(&buf, binary.BigEndian, []uint16{uint16(orientation), 0})
+	binary.Write(&buf, binary.BigEndian, uint32(0))
+	return buf.Bytes()
+}
+
+// tiff reads values out of the TIFF structure EXIF metadata is stored in
+type tiff struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+// entry is a tag of a TIFF directory
+type entry struct {
+	typ    uint16
+	count  uint32
+	offset uint32
+	inline []byte
+}
+
+func parseTIFF(data []byte) (*Metadata, error) {
+	if len(data) < 8 {
+		return nil, ErrInvalid
+	}
+	t := &tiff{data: data}
+	switch string(data[:2]) {
+	case "II":
+		t.order = binary.LittleEndian
+	case "MM":
+		t.order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("%w: unknown byte order", ErrInvalid)
+	}
+	if t.order.Uint16(data[2:]) != 42 {
+		return nil, fmt.Errorf("%w: not a TIFF header", ErrInvalid)
+	}
+
+	image, err := t.directory(t.order.Uint32(data[4:]))
+	if err != nil {
+		return nil, err
+	}
+	metadata := &Metadata{
+		Make:        t.ascii(image[tagMake]),
+		Model:       t.ascii(image[tagModel]),
+		Orientation: int(t.uint(image[tagOrientation])),
+	}
+	var exif, gps map[uint16]entry
+	if e, ok := image[tagExifIFD]; ok {
+		if exif, err = t.directory(t.uint(e)); err != nil {
+			return nil, err
+		}
+	}
+	if e, ok := image[tagGPSIFD]; ok {
+		if gps, err = t.directory(t.uint(e)); err != nil {
+			return nil, err
+		}
+	}
+	metadata.CapturedAt = t.capturedAt(image, exif, gps)
+	metadata.Latitude = t.coordinate(gps[tagGPSLatitude], t.ascii(gps[tagGPSLatitudeRef]), "S", 90)
+	metadata.Longitude = t.coordinate(gps[tagGPSLongitude], t.ascii(gps[tagGPSLongitudeRef]), "W", 180)
+	if metadata.Latitude == nil || metadata.Longitude == nil {
+		metadata.Latitude, metadata.Longitude = nil, nil
+	}
+	return metadata, nil
+}
+
+// directory reads the tags of the directory at offset
+func (t *tiff) directory(offset uint32) (map[uint16]entry, error) {
+	if uint64(offset)+2 > uint64(len(t.data)) {
+		return nil, fmt.Errorf("%w: directory out of bounds", ErrInvalid)
+	}
+	count := uint32(t.order.Uint16(t.data[offset:]))
+	start := offset + 2
+	if uint64(start)+uint64(count)*12 > uint64(len(t.data)) {
+		return nil, fmt.Errorf("%w: directory out of bounds", ErrInvalid)
+	}
+	entries := make(map[uint16]entry, count)
+	for i := uint32(0); i < count; i++ {
+		raw := t.data[start+i*12 : start+i*12+12]
+		e := entry{
+			typ:    t.order.Uint16(raw[2:]),
+			count:  t.order.Uint32(raw[4:]),
+			offset: t.order.Uint32(raw[8:]),
+			inline: raw[8:12],
+		}
+		entries[t.order.Uint16(raw)] = e
+	}
+	return entries, nil
+}
+
+// value returns the bytes of the value of an entry, or nil when they are out of bounds
+func (t *tiff) value(e entry) []byte {
+	size, ok := typeSizes[e.typ]
+	if !ok || e.count == 0 {
+		return nil
+	}
+	total := uint64(size) * uint64(e.count)
+	if total <= 4 {
+		return e.inline[:total]
+	}
+	if uint64(e.offset)+total > uint64(len(t.data)) {
+		return nil
+	}
+	return t.data[e.offset : uint64(e.offset)+total]
+}
+
+func (t *tiff) ascii(e entry) string {
+	if e.typ != typeASCII {
+		return ""
+	}
+	value := t.value(e)
+	if i := bytes.IndexByte(value, 0); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(string(value))
+}
+
+// uint returns the first value of a short or long entry
+func (t *tiff) uint(e entry) uint32 {
+	value := t.value(e)
+	switch {
+	case e.typ == typeShort && len(value) >= 2:
+		return uint32(t.order.Uint16(value))
+	case e.typ == typeLong && len(value) >= 4:
+		return t.order.Uint32(value)
+	}
+	return 0
+}
+
+// rationals returns the values of a rational entry
+func (t *tiff) rationals(e entry) []float64 {
+	value := t.value(e)
+	if e.typ != typeRational || value == nil {
+		return nil
+	}
+	rationals := make([]float64, e.count)
+	for i := range rationals {
+		numerator := t.order.Uint32(value[i*8:])
+		denominator := t.order.Uint32(value[i*8+4:])
+		if denominator == 0 {
+			return nil
+		}
+		rationals[i] = float64(numerator) / float64(denominator)
+	}
+	return rationals
+}
+
+// coordinate converts degrees, minutes and seconds to decimal degrees, negative towards the
+// negative reference
+func (t *tiff) coordinate(e entry, ref string, negativeRef string, limit float64) *float64 {
+	dms := t.rationals(e)
+	if len(dms) != 3 {
+		return nil
+	}
+	degrees := dms[0] + dms[1]/60 + dms[2]/3600
+	if math.IsNaN(degrees) || degrees > limit {
+		return nil
+	}
+	if ref == negativeRef {
+		degrees = -degrees
+	}
+	return &degrees
+}
+
+// capturedAt returns when the photo was taken. The original time with its offset is the most
+// precise, then the GPS time, which is in UTC; times without a time zone come last.
+func (t *tiff) capturedAt(image, exif, gps map[uint16]entry) *time.Time {
+	original := t.ascii(exif[tagDateTimeOriginal])
+	if offset := t.ascii(exif[tagOffsetTimeOriginal]); original != "" && offset != "" {
+		if captured, err := time.Parse(dateTimeLayout+"-07:00", original+offset); err == nil {
+			return &captured
+		}
+	}
+	if captured := t.gpsTime(gps); captured != nil {
+		return captured
+	}
+	for _, local := range []string{original, t.ascii(image[tagDateTime])} {
+		if captured, err := time.ParseInLocation(dateTimeLayout, local, time.Local); err == nil {
+			return &captured
+		}
+	}
+	return nil
+}
+
+func (t *tiff) gpsTime(gps map[uint16]entry) *time.Time {
+	date, err := time.Parse("2006:01:02", t.ascii(gps[tagGPSDateStamp]))
+	if err != nil {
+		return nil
+	}
+	hms := t.rationals(gps[tagGPSTimeStamp])
+	if len(hms) != 3 {
+		return nil
+	}
+	captured := date.Add(time.Duration((hms[0]*3600 + hms[1]*60 + hms[2]) * float64(time.Second)))
+	return &captured
+}
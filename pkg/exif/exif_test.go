@@ -0,0 +1,218 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/jpeg"
+	"math"
+	"testing"
+	"time"
+)
+
+// testTag is a tag written by buildTIFF
+type testTag struct {
+	tag   uint16
+	typ   uint16
+	value []byte
+	count uint32
+}
+
+func asciiTag(tag uint16, value string) testTag {
+	return testTag{tag: tag, typ: typeASCII, value: append([]byte(value), 0), count: uint32(len(value) + 1)}
+}
+
+func shortTag(tag uint16, value uint16) testTag {
+	return testTag{tag: tag, typ: typeShort, value: binary.LittleEndian.AppendUint16(nil, value), count: 1}
+}
+
+func rationalTag(tag uint16, values ...uint32) testTag {
+	var data []byte
+	for i := 0; i < len(values); i += 2 {
+		data = binary.LittleEndian.AppendUint32(data, values[i])
+		data = binary.LittleEndian.AppendUint32(data, values[i+1])
+	}
+	return testTag{tag: tag, typ: typeRational, value: data, count: uint32(len(values) / 2)}
+}
+
+// buildTIFF writes a little endian TIFF structure with an image directory and optional EXIF and
+// GPS directories
+func buildTIFF(image []testTag, exif []testTag, gps []testTag) []byte {
+	le := binary.LittleEndian
+	var out []byte
+	out = append(out, 'I', 'I', 42, 0, 8, 0, 0, 0)
+
+	var write func(tags []testTag, subdirs map[uint16][]testTag) uint32
+	write = func(tags []testTag, subdirs map[uint16][]testTag) uint32 {
+		offset := uint32(len(out))
+		count := len(tags) + len(subdirs)
+		dir := make([]byte, 2+count*12+4)
+		le.PutUint16(dir, uint16(count))
+		out = append(out, dir...)
+		i := 0
+		entry := func(tag uint16, typ uint16, n uint32, value []byte) {
+			raw := out[offset+2+uint32(i)*12:]
+			le.PutUint16(raw, tag)
+			le.PutUint16(raw[2:], typ)
+			le.PutUint32(raw[4:], n)
+			if len(value) <= 4 {
+				copy(raw[8:12], value)
+			} else {
+				le.PutUint32(raw[8:], uint32(len(out)))
+				out = append(out, value...)
+			}
+			i++
+		}
+		for _, tag := range tags {
+			entry(tag.tag, tag.typ, tag.count, tag.value)
+		}
+		for tag, sub := range subdirs {
+			if sub == nil {
+				continue
+			}
+			at := i
+			entry(tag, typeLong, 1, make([]byte, 4))
+			subOffset := write(sub, nil)
+			le.PutUint32(out[offset+2+uint32(at)*12+8:], subOffset)
+		}
+		return offset
+	}
+	subdirs := map[uint16][]testTag{}
+	if exif != nil {
+		subdirs[tagExifIFD] = exif
+	}
+	if gps != nil {
+		subdirs[tagGPSIFD] = gps
+	}
+	write(image, subdirs)
+	return out
+}
+
+// buildJPEG encodes a small image and inserts the given segments after its start of image marker
+func buildJPEG(t *testing.T, segments ...[]byte) []byte {
+	t.Helper()
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, image.NewGray(image.Rect(0, 0, 8, 8)), nil); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+	var out bytes.Buffer
+	out.Write(encoded.Bytes()[:2])
+	for _, segment := range segments {
+		out.Write(segment)
+	}
+	out.Write(encoded.Bytes()[2:])
+	return out.Bytes()
+}
+
+func segment(marker byte, data []byte) []byte {
+	var buf bytes.Buffer
+	writeSegment(&buf, marker, data)
+	return buf.Bytes()
+}
+
+func exifSegment(tiff []byte) []byte {
+	return segment(markerAPP1, append(append([]byte{}, exifHeader...), tiff...))
+}
+
+func TestDecode(t *testing.T) {
+	tiff := buildTIFF(
+		[]testTag{asciiTag(tagMake, "Apple"), asciiTag(tagModel, "iPhone 15"), shortTag(tagOrientation, 6)},
+		[]testTag{asciiTag(tagDateTimeOriginal, "2026:03:14 09:26:53"), asciiTag(tagOffsetTimeOriginal, "+01:00")},
+		[]testTag{
+			asciiTag(tagGPSLatitudeRef, "N"), rationalTag(tagGPSLatitude, 52, 1, 30, 1, 1800, 100),
+			asciiTag(tagGPSLongitudeRef, "W"), rationalTag(tagGPSLongitude, 13, 1, 24, 1, 0, 1),
+		},
+	)
+	metadata, err := Decode(bytes.NewReader(buildJPEG(t, exifSegment(tiff))))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if metadata.Make != "Apple" || metadata.Model != "iPhone 15" || metadata.Orientation != 6 {
+		t.Errorf("Decode() device = %q %q orientation %d, want Apple iPhone 15 orientation 6", metadata.Make, metadata.Model, metadata.Orientation)
+	}
+	if want := time.Date(2026, 3, 14, 8, 26, 53, 0, time.UTC); metadata.CapturedAt == nil || !metadata.CapturedAt.Equal(want) {
+		t.Errorf("Decode() captured at = %v, want %v", metadata.CapturedAt, want)
+	}
+	if metadata.Latitude == nil || math.Abs(*metadata.Latitude-52.505) > 1e-9 {
+		t.Errorf("Decode() latitude = %v, want 52.505", metadata.Latitude)
+	}
+	if metadata.Longitude == nil || math.Abs(*metadata.Longitude+13.4) > 1e-9 {
+		t.Errorf("Decode() longitude = %v, want -13.4", metadata.Longitude)
+	}
+}
+
+func TestDecodePrefersGPSTimeOverLocalTime(t *testing.T) {
+	tiff := buildTIFF(
+		nil,
+		[]testTag{asciiTag(tagDateTimeOriginal, "2026:03:14 09:26:53")},
+		[]testTag{asciiTag(tagGPSDateStamp, "2026:03:14"), rationalTag(tagGPSTimeStamp, 8, 1, 26, 1, 53, 1)},
+	)
+	metadata, err := Decode(bytes.NewReader(buildJPEG(t, exifSegment(tiff))))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if want := time.Date(2026, 3, 14, 8, 26, 53, 0, time.UTC); metadata.CapturedAt == nil || !metadata.CapturedAt.Equal(want) {
+		t.Errorf("Decode() captured at = %v, want %v", metadata.CapturedAt, want)
+	}
+	if metadata.Latitude != nil || metadata.Longitude != nil {
+		t.Errorf("Decode() location = %v, %v, want none", metadata.Latitude, metadata.Longitude)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"not a JPEG", []byte("\x89PNG\r\n\x1a\n"), ErrNotJPEG},
+		{"no EXIF", buildJPEG(t), ErrNoExif},
+		{"truncated TIFF", buildJPEG(t, exifSegment([]byte("II*\x00"))), ErrInvalid},
+		{"directory out of bounds", buildJPEG(t, exifSegment([]byte("II*\x00\xff\x00\x00\x00"))), ErrInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(bytes.NewReader(tt.data)); !errors.Is(err, tt.want) {
+				t.Errorf("Decode() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrip(t *testing.T) {
+	tiff := buildTIFF(
+		[]testTag{asciiTag(tagMake, "Apple"), shortTag(tagOrientation, 8)},
+		nil,
+		[]testTag{asciiTag(tagGPSLatitudeRef, "N"), rationalTag(tagGPSLatitude, 52, 1, 0, 1, 0, 1)},
+	)
+	xmp := segment(markerAPP1, []byte("http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta/>"))
+	original := buildJPEG(t, exifSegment(tiff), xmp, segment(markerAPP13, []byte("Photoshop 3.0\x00")))
+
+	var stripped bytes.Buffer
+	if err := Strip(&stripped, bytes.NewReader(original)); err != nil {
+		t.Fatalf("Strip() error = %v", err)
+	}
+	for _, leaked := range []string{"Apple", "xmpmeta", "Photoshop"} {
+		if bytes.Contains(stripped.Bytes(), []byte(leaked)) {
+			t.Errorf("Strip() kept %q", leaked)
+		}
+	}
+	metadata, err := Decode(bytes.NewReader(stripped.Bytes()))
+	if err != nil {
+		t.Fatalf("Decode() of the stripped image error = %v", err)
+	}
+	if metadata.Orientation != 8 || metadata.Latitude != nil || metadata.Make != "" {
+		t.Errorf("Decode() of the stripped image = %+v, want only orientation 8", metadata)
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(stripped.Bytes())); err != nil {
+		t.Errorf("jpeg.Decode() of the stripped image error = %v", err)
+	}
+}
+
+func TestStripRejectsOtherFormats(t *testing.T) {
+	var stripped bytes.Buffer
+	if err := Strip(&stripped, bytes.NewReader([]byte("GIF89a"))); !errors.Is(err, ErrNotJPEG) {
+		t.Errorf("Strip() error = %v, want ErrNotJPEG", err)
+	}
+}
@@ -0,0 +1,97 @@
+package imaging
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+
+	// Decoders of the formats photos are uploaded in
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// ErrTooLarge is returned for images with more pixels than allowed, which would take too much
+// memory to decode
+var ErrTooLarge = errors.New("image has too many pixels")
+
+// Decode decodes a JPEG, PNG, GIF or WebP image and returns it with the name of its format. The
+// dimensions are read first so that a small file cannot expand into a bitmap of more than
+// maxPixels pixels.
+func Decode(r io.ReadSeeker, maxPixels int64) (image.Image, string, error) {
+	config, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, "", err
+	}
+	if pixels := int64(config.Width) * int64(config.Height); pixels > maxPixels {
+		return nil, "", fmt.Errorf("%w: %dx%d", ErrTooLarge, config.Width, config.Height)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, "", err
+	}
+	return image.Decode(r)
+}
+
+// Resize scales img down so that its longest side is at most maxSide pixels, keeping its aspect
+// ratio. Smaller images keep their size. Transparent areas are filled with white, since the result
+// is meant to be encoded as JPEG.
+func Resize(img image.Image, maxSide int) *image.RGBA {
+	width, height := fit(img.Bounds().Dx(), img.Bounds().Dy(), maxSide)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Over, nil)
+	return dst
+}
+
+// Orient rotates and flips img as the EXIF orientation of its photo says, from 1 (as stored) to 8,
+// so that it is displayed upright. Unknown orientations leave img as it is.
+func Orient(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	origin := img.Bounds().Min
+	// source returns the pixel of img shown at x, y of the oriented image
+	source := map[int]func(x, y int) (int, int){
+		2: func(x, y int) (int, int) { return w - 1 - x, y },
+		3: func(x, y int) (int, int) { return w - 1 - x, h - 1 - y },
+		4: func(x, y int) (int, int) { return x, h - 1 - y },
+		5: func(x, y int) (int, int) { return y, x },
+		6: func(x, y int) (int, int) { return y, h - 1 - x },
+		7: func(x, y int) (int, int) { return w - 1 - y, h - 1 - x },
+		8: func(x, y int) (int, int) { return w - 1 - y, x },
+	}[orientation]
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			sx, sy := source(x, y)
+			dst.SetRGBA(x, y, img.RGBAAt(origin.X+sx, origin.Y+sy))
+		}
+	}
+	return dst
+}
+
+// EncodeJPEG writes img as a JPEG of the given quality, from 1 to 100
+func EncodeJPEG(w io.Writer, img image.Image, quality int) error {
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+}
+
+// fit returns the size of a width x height image scaled down to fit in a maxSide square
+func fit(width int, height int, maxSide int) (int, int) {
+	if width <= maxSide && height <= maxSide {
+		return width, height
+	}
+	if width >= height {
+		return maxSide, max(1, height*maxSide/width)
+	}
+	return max(1, width*maxSide/height), maxSide
+}
@@ -0,0 +1,116 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width int, height int) *bytes.Reader {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		wantW, wantH  int
+	}{
+		{"smaller than the box", 200, 100, 200, 100},
+		{"landscape", 4000, 3000, 320, 240},
+		{"portrait", 3000, 4000, 240, 320},
+		{"square", 1000, 1000, 320, 320},
+		{"very thin", 10000, 5, 320, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, h := fit(tt.width, tt.height, 320)
+			if w != tt.wantW || h != tt.wantH {
+				t.Errorf("fit() = %dx%d, want %dx%d", w, h, tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	img, format, err := Decode(encodePNG(t, 40, 30), 10000)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if format != "png" || img.Bounds().Dx() != 40 || img.Bounds().Dy() != 30 {
+		t.Errorf("Decode() = %s %v, want png 40x30", format, img.Bounds())
+	}
+}
+
+func TestDecodeRejectsTooManyPixels(t *testing.T) {
+	if _, _, err := Decode(encodePNG(t, 200, 100), 10000); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Decode() error = %v, want ErrTooLarge", err)
+	}
+}
+
+func TestDecodeRejectsUnknownFormat(t *testing.T) {
+	if _, _, err := Decode(bytes.NewReader([]byte("not an image")), 10000); !errors.Is(err, image.ErrFormat) {
+		t.Errorf("Decode() error = %v, want image.ErrFormat", err)
+	}
+}
+
+func TestResizeFillsTransparencyWithWhite(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 100, 50))
+	resized := Resize(src, 20)
+	if resized.Bounds().Dx() != 20 || resized.Bounds().Dy() != 10 {
+		t.Fatalf("Resize() size = %v, want 20x10", resized.Bounds())
+	}
+	if got := resized.RGBAAt(5, 5); got != (color.RGBA{R: 255, G: 255, B: 255, A: 255}) {
+		t.Errorf("Resize() pixel = %v, want white", got)
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeJPEG(&buf, resized, 80); err != nil {
+		t.Fatalf("EncodeJPEG() error = %v", err)
+	}
+	if _, format, err := image.Decode(&buf); err != nil || format != "jpeg" {
+		t.Errorf("EncodeJPEG() wrote %s, error = %v, want jpeg", format, err)
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// A 3x2 image with a red top left corner
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	red := color.RGBA{R: 255, A: 255}
+	src.SetRGBA(0, 0, red)
+
+	tests := []struct {
+		orientation int
+		wantW       int
+		wantH       int
+		redX, redY  int
+	}{
+		{1, 3, 2, 0, 0},
+		{2, 3, 2, 2, 0},
+		{3, 3, 2, 2, 1},
+		{4, 3, 2, 0, 1},
+		{5, 2, 3, 0, 0},
+		{6, 2, 3, 1, 0},
+		{7, 2, 3, 1, 2},
+		{8, 2, 3, 0, 2},
+	}
+	for _, tt := range tests {
+		oriented := Orient(src, tt.orientation)
+		if oriented.Bounds().Dx() != tt.wantW || oriented.Bounds().Dy() != tt.wantH {
+			t.Errorf("Orient(%d) size = %v, want %dx%d", tt.orientation, oriented.Bounds(), tt.wantW, tt.wantH)
+			continue
+		}
+		if got := oriented.RGBAAt(tt.redX, tt.redY); got != red {
+			t.Errorf("Orient(%d) pixel at %d,%d = %v, want red", tt.orientation, tt.redX, tt.redY, got)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"scs-guard/pkg/logger"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LocalSignedPath is the path under which the API serves presigned URLs of local storage
+const LocalSignedPath = "/api/v1/storage/"
+
+// ErrInvalidSignature is returned for a presigned URL of local storage that was tampered with or
+// has expired
+var ErrInvalidSignature = errors.New("invalid or expired signature")
+
+// LocalStorage stores objects as files in a directory. Presigned URLs point at the API, which
+// checks their signature and serves or stores the file. The directory holds:
+//
+//	objects/<key>                 content of the objects
+//	meta/<key>.json               content type and ETag of the objects
+//	multipart/<upload id>/<part>  parts of multipart uploads in progress
+//	tmp/                          files being written
+type LocalStorage struct {
+	root       string
+	baseURL    string
+	signingKey []byte
+	logger     logger.Logger
+}
+
+// localMeta is what is kept of an object or multipart upload besides its content
+type localMeta struct {
+	Key         string `json:"key,omitempty"`
+	ContentType string `json:"content_type"`
+	ETag        string `json:"etag,omitempty"`
+}
+
+// NewLocalStorage stores objects under dir. baseURL is the address of the API that presigned URLs
+// point at. Without a signing key a random one is used, so presigned URLs stop working on restart.
+func NewLocalStorage(dir string, baseURL string, signingKey string, logger logger.Logger) (*LocalStorage, error) {
+	for _, sub := range []string{"objects", "meta", "multipart", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create storage directory: %w", err)
+		}
+	}
+	key := []byte(signingKey)
+	if len(key) == 0 {
+		logger.Warn("No storage signing key configured, presigned URLs will not survive a restart")
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate signing key: %w", err)
+		}
+	}
+	return &LocalStorage{root: dir, baseURL: strings.TrimSuffix(baseURL, "/"), signingKey: key, logger: logger}, nil
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) (ObjectInfo, error) {
+	target, err := s.objectPath(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	etag, written, err := s.writeFile(target, data, size)
+	if err != nil {
+		s.logger.Errorf("Failed to store %s: %v", key, err)
+		return ObjectInfo{}, err
+	}
+	if err := s.writeMeta(s.metaPath(key), localMeta{ContentType: contentType, ETag: etag}); err != nil {
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{Key: cleanKey(key), Size: written, ContentType: contentType, ETag: etag, LastModified: time.Now()}, nil
+}
+
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadSeekCloser, ObjectInfo, error) {
+	info, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	target, _ := s.objectPath(key)
+	file, err := os.Open(target)
+	if err != nil {
+		return nil, ObjectInfo{}, mapFileError(err)
+	}
+	return file, info, nil
+}
+
+func (s *LocalStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	target, err := s.objectPath(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	stat, err := os.Stat(target)
+	if err != nil {
+		return ObjectInfo{}, mapFileError(err)
+	}
+	if stat.IsDir() {
+		return ObjectInfo{}, ErrNotFound
+	}
+	meta, err := s.readMeta(s.metaPath(key))
+	if err != nil && !IsNotFound(err) {
+		return ObjectInfo{}, err
+	}
+	if meta.ContentType == "" {
+		meta.ContentType = "application/octet-stream"
+	}
+	return ObjectInfo{Key: cleanKey(key), Size: stat.Size(), ContentType: meta.ContentType, ETag: meta.ETag, LastModified: stat.ModTime()}, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	target, err := s.objectPath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		s.logger.Errorf("Failed to remove %s: %v", key, err)
+		return err
+	}
+	if err := os.Remove(s.metaPath(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStorage) PresignPut(ctx context.Context, key string, expiry time.Duration) (*url.URL, error) {
+	return s.presign("PUT", key, expiry)
+}
+
+func (s *LocalStorage) PresignGet(ctx context.Context, key string, expiry time.Duration) (*url.URL, error) {
+	return s.presign("GET", key, expiry)
+}
+
+func (s *LocalStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects := filepath.Join(s.root, "objects")
+	// Only walk the deepest directory that can hold keys starting with prefix
+	start := filepath.Join(objects, filepath.FromSlash(path.Dir(path.Clean("/"+prefix+"x"))))
+	var infos []ObjectInfo
+	err := filepath.WalkDir(start, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(objects, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, strings.TrimPrefix(prefix, "/")) {
+			return nil
+		}
+		info, err := s.Stat(ctx, key)
+		if err != nil {
+			return err
+		}
+		infos = append(infos, info)
+		return nil
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to list objects under %s: %v", prefix, err)
+		return nil, err
+	}
+	return infos, nil
+}
+
+func (s *LocalStorage) NewMultipartUpload(ctx context.Context, key string, contentType string) (string, error) {
+	if _, err := s.objectPath(key); err != nil {
+		return "", err
+	}
+	uploadID := uuid.New().String()
+	dir := filepath.Join(s.root, "multipart", uploadID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to start multipart upload: %w", err)
+	}
+	if err := s.writeMeta(filepath.Join(dir, "upload.json"), localMeta{Key: cleanKey(key), ContentType: contentType}); err != nil {
+		return "", err
+	}
+	return uploadID, nil
+}
+
+func (s *LocalStorage) PutPart(ctx context.Context, key string, uploadID string, partNumber int, data io.Reader, size int64) (string, error) {
+	dir, _, err := s.multipartUpload(key, uploadID)
+	if err != nil {
+		return "", err
+	}
+	etag, _, err := s.writeFile(filepath.Join(dir, strconv.Itoa(partNumber)), data, size)
+	if err != nil {
+		s.logger.Errorf("Failed to store part %d of %s: %v", partNumber, key, err)
+		return "", err
+	}
+	return etag, nil
+}
+
+func (s *LocalStorage) CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []CompletePart) error {
+	dir, meta, err := s.multipartUpload(key, uploadID)
+	if err != nil {
+		return err
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	files := make([]io.Reader, 0, len(parts))
+	for _, part := range parts {
+		file, err := os.Open(filepath.Join(dir, strconv.Itoa(part.PartNumber)))
+		if err != nil {
+			return fmt.Errorf("part %d: %w", part.PartNumber, mapFileError(err))
+		}
+		defer file.Close()
+		files = append(files, file)
+	}
+	if _, err := s.Put(ctx, key, io.MultiReader(files...), -1, meta.ContentType); err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+func (s *LocalStorage) AbortMultipartUpload(ctx context.Context, key string, uploadID string) error {
+	dir, _, err := s.multipartUpload(key, uploadID)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// VerifySignature checks a presigned URL for method on key, given its expires and signature query
+// parameters
+func (s *LocalStorage) VerifySignature(method string, key string, expires string, signature string) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > unix {
+		return ErrInvalidSignature
+	}
+	expected := s.sign(method, cleanKey(key), expires)
+	given, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(given, expected) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *LocalStorage) presign(method string, key string, expiry time.Duration) (*url.URL, error) {
+	if _, err := s.objectPath(key); err != nil {
+		return nil, err
+	}
+	key = cleanKey(key)
+	u, err := url.Parse(s.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build presigned URL: %w", err)
+	}
+	u.Path += LocalSignedPath + key
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	u.RawQuery = url.Values{
+		"expires":   {expires},
+		"signature": {hex.EncodeToString(s.sign(method, key, expires))},
+	}.Encode()
+	return u, nil
+}
+
+func (s *LocalStorage) sign(method string, key string, expires string) []byte {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(method + "\n" + key + "\n" + expires))
+	return mac.Sum(nil)
+}
+
+// multipartUpload returns the directory and metadata of a multipart upload of key
+func (s *LocalStorage) multipartUpload(key string, uploadID string) (string, localMeta, error) {
+	if _, err := uuid.Parse(uploadID); err != nil {
+		return "", localMeta{}, ErrNotFound
+	}
+	dir := filepath.Join(s.root, "multipart", uploadID)
+	meta, err := s.readMeta(filepath.Join(dir, "upload.json"))
+	if err != nil {
+		return "", localMeta{}, err
+	}
+	if meta.Key != cleanKey(key) {
+		return "", localMeta{}, ErrNotFound
+	}
+	return dir, meta, nil
+}
+
+// writeFile writes data to target through a temporary file, so that readers never see a partial
+// file, and returns the MD5 of the content as ETag. A size of -1 means unknown.
+func (s *LocalStorage) writeFile(target string, data io.Reader, size int64) (string, int64, error) {
+	tmp, err := os.CreateTemp(filepath.Join(s.root, "tmp"), "upload-*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(tmp.Name())
+	hash := md5.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hash), data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	if size >= 0 && written != size {
+		return "", 0, fmt.Errorf("expected %d bytes, got %d", size, written)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", 0, err
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), written, nil
+}
+
+func (s *LocalStorage) writeMeta(target string, meta localMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.writeFile(target, bytes.NewReader(data), int64(len(data)))
+	return err
+}
+
+func (s *LocalStorage) readMeta(target string) (localMeta, error) {
+	var meta localMeta
+	data, err := os.ReadFile(target)
+	if err != nil {
+		return meta, mapFileError(err)
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return meta, fmt.Errorf("failed to read object metadata: %w", err)
+	}
+	return meta, nil
+}
+
+// objectPath returns the file an object is stored in. Keys cannot point outside the directory.
+func (s *LocalStorage) objectPath(key string) (string, error) {
+	key = cleanKey(key)
+	if key == "" {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return filepath.Join(s.root, "objects", filepath.FromSlash(key)), nil
+}
+
+func (s *LocalStorage) metaPath(key string) string {
+	return filepath.Join(s.root, "meta", filepath.FromSlash(cleanKey(key))+".json")
+}
+
+// cleanKey removes leading slashes and dot segments from a key
+func cleanKey(key string) string {
+	return strings.TrimPrefix(path.Clean("/"+key), "/")
+}
+
+func mapFileError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+	return err
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"scs-guard/pkg/logger"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLocalStorage(t *testing.T) *LocalStorage {
+	t.Helper()
+	s, err := NewLocalStorage(t.TempDir(), "http://localhost:8080", "secret", logger.GetLogger())
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	return s
+}
+
+func TestLocalStoragePutGetDelete(t *testing.T) {
+	s := newTestLocalStorage(t)
+	ctx := context.Background()
+
+	if _, err := s.Put(ctx, "incident/upload/photo.jpg", strings.NewReader("jpeg"), 4, "image/jpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	info, err := s.Stat(ctx, "incident/upload/photo.jpg")
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if info.Size != 4 || info.ContentType != "image/jpeg" || info.ETag == "" {
+		t.Errorf("Stat() = %+v, want 4 bytes of image/jpeg with an ETag", info)
+	}
+	file, _, err := s.Get(ctx, "incident/upload/photo.jpg")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	content, _ := io.ReadAll(file)
+	file.Close()
+	if string(content) != "jpeg" {
+		t.Errorf("Get() content = %q, want %q", content, "jpeg")
+	}
+
+	objects, err := s.List(ctx, "incident/up")
+	if err != nil || len(objects) != 1 || objects[0].Key != "incident/upload/photo.jpg" {
+		t.Errorf("List() = %+v, %v, want the stored object", objects, err)
+	}
+	if objects, _ := s.List(ctx, "other/"); len(objects) != 0 {
+		t.Errorf("List(other/) = %+v, want no objects", objects)
+	}
+
+	if err := s.Delete(ctx, "incident/upload/photo.jpg"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.Stat(ctx, "incident/upload/photo.jpg"); !IsNotFound(err) {
+		t.Errorf("Stat() after Delete() error = %v, want not found", err)
+	}
+	if err := s.Delete(ctx, "incident/upload/photo.jpg"); err != nil {
+		t.Errorf("Delete() of a missing object error = %v, want nil", err)
+	}
+}
+
+func TestLocalStorageRejectsSizeMismatch(t *testing.T) {
+	s := newTestLocalStorage(t)
+	if _, err := s.Put(context.Background(), "short", strings.NewReader("abc"), 4, "text/plain"); err == nil {
+		t.Error("expected an error when the content is shorter than its size")
+	}
+}
+
+func TestLocalStorageKeysStayInDirectory(t *testing.T) {
+	s := newTestLocalStorage(t)
+	path, err := s.objectPath("../../etc/passwd")
+	if err != nil {
+		t.Fatalf("objectPath() error = %v", err)
+	}
+	if !strings.HasPrefix(path, s.root) {
+		t.Errorf("objectPath() = %q, want a path under %q", path, s.root)
+	}
+	if _, err := s.objectPath("/"); err == nil {
+		t.Error("expected an error for an empty key")
+	}
+}
+
+func TestLocalStorageMultipartUpload(t *testing.T) {
+	s := newTestLocalStorage(t)
+	ctx := context.Background()
+
+	uploadID, err := s.NewMultipartUpload(ctx, "incident/upload/video.mp4", "video/mp4")
+	if err != nil {
+		t.Fatalf("NewMultipartUpload() error = %v", err)
+	}
+	var parts []CompletePart
+	for i, data := range []string{"second", "first-"} {
+		number := 2 - i
+		etag, err := s.PutPart(ctx, "incident/upload/video.mp4", uploadID, number, strings.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("PutPart(%d) error = %v", number, err)
+		}
+		parts = append(parts, CompletePart{PartNumber: number, ETag: etag})
+	}
+	if _, err := s.PutPart(ctx, "incident/other.mp4", uploadID, 3, strings.NewReader("x"), 1); !IsNotFound(err) {
+		t.Errorf("PutPart() for another key error = %v, want not found", err)
+	}
+	if err := s.CompleteMultipartUpload(ctx, "incident/upload/video.mp4", uploadID, parts); err != nil {
+		t.Fatalf("CompleteMultipartUpload() error = %v", err)
+	}
+
+	file, info, err := s.Get(ctx, "incident/upload/video.mp4")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	content, _ := io.ReadAll(file)
+	file.Close()
+	if string(content) != "first-second" || info.ContentType != "video/mp4" {
+		t.Errorf("Get() = %q of %s, want the parts in order as video/mp4", content, info.ContentType)
+	}
+	if err := s.AbortMultipartUpload(ctx, "incident/upload/video.mp4", uploadID); !IsNotFound(err) {
+		t.Errorf("AbortMultipartUpload() after completion error = %v, want not found", err)
+	}
+}
+
+func TestLocalStoragePresignedURLs(t *testing.T) {
+	s := newTestLocalStorage(t)
+	ctx := context.Background()
+
+	u, err := s.PresignPut(ctx, "incident/upload/my photo.jpg", time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPut() error = %v", err)
+	}
+	if !strings.HasPrefix(u.Path, LocalSignedPath) {
+		t.Fatalf("PresignPut() path = %q, want it under %q", u.Path, LocalSignedPath)
+	}
+	key := strings.TrimPrefix(u.Path, LocalSignedPath)
+	expires, signature := u.Query().Get("expires"), u.Query().Get("signature")
+
+	if err := s.VerifySignature(http.MethodPut, key, expires, signature); err != nil {
+		t.Errorf("VerifySignature() error = %v", err)
+	}
+	if err := s.VerifySignature(http.MethodGet, key, expires, signature); err == nil {
+		t.Error("expected an upload URL not to allow downloads")
+	}
+	if err := s.VerifySignature(http.MethodPut, "incident/other.jpg", expires, signature); err == nil {
+		t.Error("expected the signature not to cover another key")
+	}
+
+	expired, _ := s.PresignGet(ctx, key, -time.Minute)
+	if err := s.VerifySignature(http.MethodGet, key, expired.Query().Get("expires"), expired.Query().Get("signature")); err == nil {
+		t.Error("expected an expired URL to be rejected")
+	}
+}
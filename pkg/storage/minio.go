@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"scs-guard/pkg/logger"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// MinioStorage stores objects in a bucket of MinIO or another S3 compatible service
+type MinioStorage struct {
+	client     *minio.Client
+	logger     logger.Logger
+	BucketName string
+	Endpoint   string
+}
+
+func NewMinioStorage(endpoint string, accessKeyID string, secretAccessKey string, bucketName string, logger logger.Logger) (*MinioStorage, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
+		Secure: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := client.MakeBucket(context.Background(), bucketName, minio.MakeBucketOptions{}); err != nil {
+		exists, errBucketExists := client.BucketExists(context.Background(), bucketName)
+		if errBucketExists == nil && exists {
+			return &MinioStorage{client: client, logger: logger, BucketName: bucketName, Endpoint: endpoint}, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &MinioStorage{client: client, logger: logger, BucketName: bucketName, Endpoint: endpoint}, nil
+}
+
+func (s *MinioStorage) Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) (ObjectInfo, error) {
+	info, err := s.client.PutObject(ctx, s.BucketName, key, data, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to upload file to Minio: %v", err)
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{Key: info.Key, Size: info.Size, ContentType: contentType, ETag: info.ETag, LastModified: info.LastModified}, nil
+}
+
+func (s *MinioStorage) Get(ctx context.Context, key string) (io.ReadSeekCloser, ObjectInfo, error) {
+	object, err := s.client.GetObject(ctx, s.BucketName, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, ObjectInfo{}, mapMinioError(err)
+	}
+	// The object is only requested on first use, so a missing object shows up here
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, ObjectInfo{}, mapMinioError(err)
+	}
+	return object, toObjectInfo(info), nil
+}
+
+func (s *MinioStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	info, err := s.client.StatObject(ctx, s.BucketName, key, minio.StatObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, mapMinioError(err)
+	}
+	return toObjectInfo(info), nil
+}
+
+func (s *MinioStorage) Delete(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.BucketName, key, minio.RemoveObjectOptions{}); err != nil {
+		s.logger.Errorf("Failed to remove %s from Minio: %v", key, err)
+		return mapMinioError(err)
+	}
+	return nil
+}
+
+func (s *MinioStorage) PresignPut(ctx context.Context, key string, expiry time.Duration) (*url.URL, error) {
+	u, err := s.client.PresignedPutObject(ctx, s.BucketName, key, expiry)
+	if err != nil {
+		s.logger.Errorf("Failed to presign upload of %s: %v", key, err)
+		return nil, err
+	}
+	return u, nil
+}
+
+func (s *MinioStorage) PresignGet(ctx context.Context, key string, expiry time.Duration) (*url.URL, error) {
+	u, err := s.client.PresignedGetObject(ctx, s.BucketName, key, expiry, nil)
+	if err != nil {
+		s.logger.Errorf("Failed to presign download of %s: %v", key, err)
+		return nil, err
+	}
+	return u, nil
+}
+
+func (s *MinioStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	for info := range s.client.ListObjects(ctx, s.BucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if info.Err != nil {
+			s.logger.Errorf("Failed to list objects under %s: %v", prefix, info.Err)
+			return nil, mapMinioError(info.Err)
+		}
+		objects = append(objects, toObjectInfo(info))
+	}
+	return objects, nil
+}
+
+func (s *MinioStorage) NewMultipartUpload(ctx context.Context, key string, contentType string) (string, error) {
+	uploadID, err := s.core().NewMultipartUpload(ctx, s.BucketName, key, minio.PutObjectOptions{ContentType: contentType})
+	if err != nil {
+		s.logger.Errorf("Failed to start multipart upload of %s: %v", key, err)
+		return "", err
+	}
+	return uploadID, nil
+}
+
+func (s *MinioStorage) PutPart(ctx context.Context, key string, uploadID string, partNumber int, data io.Reader, size int64) (string, error) {
+	part, err := s.core().PutObjectPart(ctx, s.BucketName, key, uploadID, partNumber, data, size, minio.PutObjectPartOptions{})
+	if err != nil {
+		s.logger.Errorf("Failed to upload part %d of %s: %v", partNumber, key, err)
+		return "", mapMinioError(err)
+	}
+	return part.ETag, nil
+}
+
+func (s *MinioStorage) CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []CompletePart) error {
+	completeParts := make([]minio.CompletePart, len(parts))
+	for i, part := range parts {
+		completeParts[i] = minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag}
+	}
+	if _, err := s.core().CompleteMultipartUpload(ctx, s.BucketName, key, uploadID, completeParts, minio.PutObjectOptions{}); err != nil {
+		s.logger.Errorf("Failed to complete multipart upload of %s: %v", key, err)
+		return mapMinioError(err)
+	}
+	return nil
+}
+
+func (s *MinioStorage) AbortMultipartUpload(ctx context.Context, key string, uploadID string) error {
+	if err := s.core().AbortMultipartUpload(ctx, s.BucketName, key, uploadID); err != nil {
+		s.logger.Errorf("Failed to abort multipart upload of %s: %v", key, err)
+		return mapMinioError(err)
+	}
+	return nil
+}
+
+func (s *MinioStorage) core() minio.Core {
+	return minio.Core{Client: s.client}
+}
+
+// mapMinioError turns the errors MinIO returns for missing objects and uploads into ErrNotFound
+func mapMinioError(err error) error {
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey", "NoSuchUpload", "NotFound":
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+	return err
+}
+
+func toObjectInfo(info minio.ObjectInfo) ObjectInfo {
+	return ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}
+}
@@ -0,0 +1,82 @@
+// Package storage keeps incident media and other files in object storage. The backend is MinIO or
+// any other S3 compatible service, or the local filesystem for development and small deployments.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	config "scs-guard/config"
+	"scs-guard/pkg/logger"
+	"time"
+)
+
+// Storage backends selectable with STORAGE_BACKEND
+const (
+	BackendMinio = "minio"
+	BackendLocal = "local"
+)
+
+// ErrNotFound is returned when an object or multipart upload does not exist
+var ErrNotFound = errors.New("object not found")
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// CompletePart is an uploaded part of a multipart upload
+type CompletePart struct {
+	PartNumber int
+	ETag       string
+}
+
+// Storage stores objects by key. Objects are uploaded and downloaded either through the API or
+// directly by clients with presigned URLs, and large objects can be uploaded in parts.
+type Storage interface {
+	// Put stores an object of size bytes read from data
+	Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) (ObjectInfo, error)
+	// Get opens an object for reading. The caller must close it.
+	Get(ctx context.Context, key string) (io.ReadSeekCloser, ObjectInfo, error)
+	// Stat returns the size, content type and other metadata of an object
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// Delete removes an object. Removing an object that does not exist is not an error.
+	Delete(ctx context.Context, key string) error
+	// PresignPut returns a URL that lets a client upload an object directly, valid for expiry
+	PresignPut(ctx context.Context, key string, expiry time.Duration) (*url.URL, error)
+	// PresignGet returns a URL that lets a client download an object directly, valid for expiry
+	PresignGet(ctx context.Context, key string, expiry time.Duration) (*url.URL, error)
+	// List returns the objects whose key starts with prefix
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// NewMultipartUpload starts a multipart upload of an object and returns its upload ID
+	NewMultipartUpload(ctx context.Context, key string, contentType string) (string, error)
+	// PutPart uploads one part of a multipart upload and returns its ETag
+	PutPart(ctx context.Context, key string, uploadID string, partNumber int, data io.Reader, size int64) (string, error)
+	// CompleteMultipartUpload assembles the uploaded parts, given in part number order, into the object
+	CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []CompletePart) error
+	// AbortMultipartUpload discards a multipart upload and the parts uploaded so far
+	AbortMultipartUpload(ctx context.Context, key string, uploadID string) error
+}
+
+// IsNotFound reports whether err means that an object or multipart upload does not exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// New creates the storage backend selected in the config
+func New(cfg *config.Config, logger logger.Logger) (Storage, error) {
+	switch cfg.Storage.Backend {
+	case BackendMinio:
+		return NewMinioStorage(cfg.Minio.Endpoint, cfg.Minio.AccessKey, cfg.Minio.SecretKey, cfg.Minio.BucketName, logger)
+	case BackendLocal:
+		return NewLocalStorage(cfg.Storage.LocalDir, cfg.Storage.LocalBaseURL, cfg.Storage.SigningKey, logger)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage.Backend)
+	}
+}